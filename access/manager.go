@@ -106,6 +106,108 @@ func (sm *AccessServicesManager) DeleteExistingProjectGroup(projectKey string, g
 	return projectService.DeleteExistingGroup(projectKey, groupName)
 }
 
+func (sm *AccessServicesManager) GetProjectUsers(projectKey string) (*[]services.ProjectUser, error) {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.GetUsers(projectKey)
+}
+
+func (sm *AccessServicesManager) GetProjectUser(projectKey string, username string) (*services.ProjectUser, error) {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.GetUser(projectKey, username)
+}
+
+func (sm *AccessServicesManager) UpdateUserInProject(projectKey string, username string, user services.ProjectUser) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.UpdateUser(projectKey, username, user)
+}
+
+func (sm *AccessServicesManager) DeleteExistingProjectUser(projectKey string, username string) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.DeleteExistingUser(projectKey, username)
+}
+
+func (sm *AccessServicesManager) GetProjectRoles(projectKey string) (*[]services.ProjectRole, error) {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.GetRoles(projectKey)
+}
+
+func (sm *AccessServicesManager) GetProjectRole(projectKey string, roleName string) (*services.ProjectRole, error) {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.GetRole(projectKey, roleName)
+}
+
+func (sm *AccessServicesManager) CreateProjectRole(projectKey string, role services.ProjectRole) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.CreateRole(projectKey, role)
+}
+
+func (sm *AccessServicesManager) UpdateProjectRole(projectKey string, role services.ProjectRole) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.UpdateRole(projectKey, role)
+}
+
+func (sm *AccessServicesManager) DeleteProjectRole(projectKey string, roleName string) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.DeleteRole(projectKey, roleName)
+}
+
+func (sm *AccessServicesManager) GetProjectStorageQuota(projectKey string) (*services.ProjectStorageQuota, error) {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.GetStorageQuota(projectKey)
+}
+
+func (sm *AccessServicesManager) UpdateProjectStorageQuota(projectKey string, quota services.ProjectStorageQuota) error {
+	projectService := services.NewProjectService(sm.client)
+	projectService.ServiceDetails = sm.config.GetServiceDetails()
+	return projectService.UpdateStorageQuota(projectKey, quota)
+}
+
+func (sm *AccessServicesManager) GetGlobalEnvironments() ([]services.Environment, error) {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.GetGlobalEnvironments()
+}
+
+func (sm *AccessServicesManager) CreateGlobalEnvironment(name string) error {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.CreateGlobalEnvironment(name)
+}
+
+func (sm *AccessServicesManager) DeleteGlobalEnvironment(name string) error {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.DeleteGlobalEnvironment(name)
+}
+
+func (sm *AccessServicesManager) GetProjectEnvironments(projectKey string) ([]services.Environment, error) {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.GetProjectEnvironments(projectKey)
+}
+
+func (sm *AccessServicesManager) CreateProjectEnvironment(projectKey, name string) error {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.CreateProjectEnvironment(projectKey, name)
+}
+
+func (sm *AccessServicesManager) DeleteProjectEnvironment(projectKey, name string) error {
+	environmentService := services.NewEnvironmentService(sm.client)
+	environmentService.ServiceDetails = sm.config.GetServiceDetails()
+	return environmentService.DeleteProjectEnvironment(projectKey, name)
+}
+
 func (sm *AccessServicesManager) CreateAccessToken(params services.CreateTokenParams) (auth.CreateTokenResponseData, error) {
 	tokenService := services.NewTokenService(sm.client)
 	tokenService.ServiceDetails = sm.config.GetServiceDetails()
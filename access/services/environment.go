@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+const environmentsApi = "api/v1/environments"
+
+// Global environments are available to all the projects. The names of project environments are prefixed with the
+// project key.
+type Environment struct {
+	Name string `json:"name"`
+}
+
+type EnvironmentService struct {
+	client         *jfroghttpclient.JfrogHttpClient
+	ServiceDetails auth.ServiceDetails
+}
+
+func NewEnvironmentService(client *jfroghttpclient.JfrogHttpClient) *EnvironmentService {
+	return &EnvironmentService{client: client}
+}
+
+func (es *EnvironmentService) getGlobalEnvironmentsUrl() string {
+	return fmt.Sprintf("%s%s", es.ServiceDetails.GetUrl(), environmentsApi)
+}
+
+func (es *EnvironmentService) getProjectEnvironmentsUrl(projectKey string) string {
+	return fmt.Sprintf("%s%s/%s/environments", es.ServiceDetails.GetUrl(), projectsApi, projectKey)
+}
+
+func (es *EnvironmentService) GetGlobalEnvironments() ([]Environment, error) {
+	return es.getAll(es.getGlobalEnvironmentsUrl())
+}
+
+func (es *EnvironmentService) CreateGlobalEnvironment(name string) error {
+	return es.create(es.getGlobalEnvironmentsUrl(), name)
+}
+
+func (es *EnvironmentService) DeleteGlobalEnvironment(name string) error {
+	return es.delete(es.getGlobalEnvironmentsUrl() + "/" + url.PathEscape(name))
+}
+
+func (es *EnvironmentService) GetProjectEnvironments(projectKey string) ([]Environment, error) {
+	return es.getAll(es.getProjectEnvironmentsUrl(projectKey))
+}
+
+func (es *EnvironmentService) CreateProjectEnvironment(projectKey, name string) error {
+	return es.create(es.getProjectEnvironmentsUrl(projectKey), name)
+}
+
+func (es *EnvironmentService) DeleteProjectEnvironment(projectKey, name string) error {
+	return es.delete(es.getProjectEnvironmentsUrl(projectKey) + "/" + url.PathEscape(name))
+}
+
+func (es *EnvironmentService) getAll(url string) ([]Environment, error) {
+	httpDetails := es.ServiceDetails.CreateHttpClientDetails()
+	resp, body, _, err := es.client.SendGet(url, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var environments []Environment
+	err = json.Unmarshal(body, &environments)
+	return environments, errorutils.CheckError(err)
+}
+
+func (es *EnvironmentService) create(url, name string) error {
+	requestContent, err := json.Marshal(Environment{Name: name})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpDetails := es.ServiceDetails.CreateHttpClientDetails()
+	httpDetails.Headers = map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/json",
+	}
+	resp, body, err := es.client.SendPost(url, requestContent, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func (es *EnvironmentService) delete(url string) error {
+	httpDetails := es.ServiceDetails.CreateHttpClientDetails()
+	resp, body, err := es.client.SendDelete(url, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironments(t *testing.T) {
+	var requests []testAccessRequest
+	server, serviceDetails, client := startMockAccessServer(t, map[string]testAccessResponse{
+		"GET /api/v1/environments":                        {http.StatusOK, `[{"name":"DEV"},{"name":"PROD"}]`},
+		"POST /api/v1/environments":                       {http.StatusCreated, ""},
+		"DELETE /api/v1/environments/QA%2F1":              {http.StatusNoContent, ""},
+		"GET /api/v1/projects/prj/environments":           {http.StatusOK, `[{"name":"prj-QA"}]`},
+		"POST /api/v1/projects/prj/environments":          {http.StatusCreated, ""},
+		"DELETE /api/v1/projects/prj/environments/prj-QA": {http.StatusNoContent, ""},
+	}, &requests)
+	defer server.Close()
+	environmentService := NewEnvironmentService(client)
+	environmentService.ServiceDetails = serviceDetails
+
+	environments, err := environmentService.GetGlobalEnvironments()
+	assert.NoError(t, err)
+	assert.Equal(t, []Environment{{Name: "DEV"}, {Name: "PROD"}}, environments)
+	assert.NoError(t, environmentService.CreateGlobalEnvironment("QA/1"))
+	assert.Equal(t, testAccessRequest{http.MethodPost, "/api/v1/environments", `{"name":"QA/1"}`}, requests[len(requests)-1])
+	// The name is a single path segment.
+	assert.NoError(t, environmentService.DeleteGlobalEnvironment("QA/1"))
+
+	environments, err = environmentService.GetProjectEnvironments("prj")
+	assert.NoError(t, err)
+	assert.Equal(t, []Environment{{Name: "prj-QA"}}, environments)
+	assert.NoError(t, environmentService.CreateProjectEnvironment("prj", "prj-QA"))
+	assert.Equal(t, testAccessRequest{http.MethodPost, "/api/v1/projects/prj/environments", `{"name":"prj-QA"}`}, requests[len(requests)-1])
+	assert.NoError(t, environmentService.DeleteProjectEnvironment("prj", "prj-QA"))
+	assert.Error(t, environmentService.DeleteProjectEnvironment("prj", "missing"))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/madotis/jfrog-client-go/auth"
 	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
@@ -50,6 +51,21 @@ type ProjectGroups struct {
 	Members []ProjectGroup `json:"members"`
 }
 
+type ProjectUser struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+}
+
+type ProjectUsers struct {
+	Members []ProjectUser `json:"members"`
+}
+
+// The storage quota of a project. With a soft limit, uploads which exceed the quota are allowed, and only alerted on.
+type ProjectStorageQuota struct {
+	StorageQuotaBytes float64 `json:"storage_quota_bytes"`
+	SoftLimit         bool    `json:"soft_limit"`
+}
+
 func NewProjectService(client *jfroghttpclient.JfrogHttpClient) *ProjectService {
 	return &ProjectService{client: client}
 }
@@ -125,16 +141,7 @@ func (ps *ProjectService) Update(params ProjectParams) error {
 }
 
 func (ps *ProjectService) createOrUpdateRequest(project Project) (requestContent []byte, httpDetails httputils.HttpClientDetails, err error) {
-	httpDetails = ps.ServiceDetails.CreateHttpClientDetails()
-	requestContent, err = json.Marshal(project)
-	if errorutils.CheckError(err) != nil {
-		return
-	}
-	httpDetails.Headers = map[string]string{
-		"Content-Type": "application/json",
-		"Accept":       "application/json",
-	}
-	return
+	return ps.createJsonRequest(project)
 }
 
 func (ps *ProjectService) Delete(projectKey string) error {
@@ -235,3 +242,104 @@ func (ps *ProjectService) DeleteExistingGroup(projectKey string, groupName strin
 	}
 	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent)
 }
+
+func (ps *ProjectService) GetUsers(projectKey string) (*[]ProjectUser, error) {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/users", ps.getProjectsBaseUrl(), projectKey)
+	resp, body, _, err := ps.client.SendGet(url, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	// In case the requested project is not found
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var projectUsers ProjectUsers
+	err = json.Unmarshal(body, &projectUsers)
+	return &projectUsers.Members, errorutils.CheckError(err)
+}
+
+func (ps *ProjectService) GetUser(projectKey string, username string) (*ProjectUser, error) {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/users/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(username))
+	resp, body, _, err := ps.client.SendGet(url, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	// In case the requested project or user in project is not found
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var projectUser ProjectUser
+	err = json.Unmarshal(body, &projectUser)
+	return &projectUser, errorutils.CheckError(err)
+}
+
+// Adds the user to the project, or replaces its roles if it's already a member.
+func (ps *ProjectService) UpdateUser(projectKey string, username string, user ProjectUser) error {
+	requestContent, httpDetails, err := ps.createJsonRequest(user)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/users/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(username))
+	resp, body, err := ps.client.SendPut(url, requestContent, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (ps *ProjectService) DeleteExistingUser(projectKey string, username string) error {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/users/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(username))
+	resp, body, err := ps.client.SendDelete(url, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent)
+}
+
+func (ps *ProjectService) GetStorageQuota(projectKey string) (*ProjectStorageQuota, error) {
+	project, err := ps.Get(projectKey)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, errorutils.CheckErrorf("project '%s' does not exist", projectKey)
+	}
+	return &ProjectStorageQuota{StorageQuotaBytes: project.StorageQuotaBytes, SoftLimit: project.SoftLimit != nil && *project.SoftLimit}, nil
+}
+
+// Sets the storage quota of the project. The rest of the project's details are kept.
+func (ps *ProjectService) UpdateStorageQuota(projectKey string, quota ProjectStorageQuota) error {
+	project, err := ps.Get(projectKey)
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		return errorutils.CheckErrorf("project '%s' does not exist", projectKey)
+	}
+	project.ProjectKey = projectKey
+	project.StorageQuotaBytes = quota.StorageQuotaBytes
+	project.SoftLimit = &quota.SoftLimit
+	return ps.Update(ProjectParams{ProjectDetails: *project})
+}
+
+func (ps *ProjectService) createJsonRequest(content interface{}) (requestContent []byte, httpDetails httputils.HttpClientDetails, err error) {
+	httpDetails = ps.ServiceDetails.CreateHttpClientDetails()
+	requestContent, err = json.Marshal(content)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	httpDetails.Headers = map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/json",
+	}
+	return
+}
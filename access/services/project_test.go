@@ -0,0 +1,114 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	accessAuth "github.com/madotis/jfrog-client-go/access/auth"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+// A response of the mock Access server, by the method and escaped path of its request.
+type testAccessResponse struct {
+	status int
+	body   string
+}
+
+type testAccessRequest struct {
+	method string
+	path   string
+	body   string
+}
+
+// Starts a mock Access server, which records the requests it gets, and returns the details and client of the services.
+// Requests without responses get 404.
+func startMockAccessServer(t *testing.T, responses map[string]testAccessResponse, requests *[]testAccessRequest) (*httptest.Server, auth.ServiceDetails, *jfroghttpclient.JfrogHttpClient) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		*requests = append(*requests, testAccessRequest{method: r.Method, path: r.URL.EscapedPath(), body: string(body)})
+		response, ok := responses[r.Method+" "+r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(response.status)
+		_, err = w.Write([]byte(response.body))
+		assert.NoError(t, err)
+	}))
+	serviceDetails := accessAuth.NewAccessDetails()
+	serviceDetails.SetUrl(server.URL + "/")
+	client, err := jfroghttpclient.JfrogClientBuilder().Build()
+	assert.NoError(t, err)
+	return server, serviceDetails, client
+}
+
+func startMockProjectService(t *testing.T, responses map[string]testAccessResponse, requests *[]testAccessRequest) (*httptest.Server, *ProjectService) {
+	server, serviceDetails, client := startMockAccessServer(t, responses, requests)
+	projectService := NewProjectService(client)
+	projectService.ServiceDetails = serviceDetails
+	return server, projectService
+}
+
+func TestProjectUsers(t *testing.T) {
+	var requests []testAccessRequest
+	server, projectService := startMockProjectService(t, map[string]testAccessResponse{
+		"GET /api/v1/projects/prj/users":              {http.StatusOK, `{"members":[{"name":"a user","roles":["Viewer"]}]}`},
+		"GET /api/v1/projects/prj/users/a%20user":     {http.StatusOK, `{"name":"a user","roles":["Viewer"]}`},
+		"PUT /api/v1/projects/prj/users/a%20user":     {http.StatusOK, ""},
+		"DELETE /api/v1/projects/prj/users/a%20user":  {http.StatusNoContent, ""},
+		"DELETE /api/v1/projects/prj/users/a%2Fuser":  {http.StatusNoContent, ""},
+		"GET /api/v1/projects/prj/users/missing-user": {http.StatusNotFound, ""},
+	}, &requests)
+	defer server.Close()
+
+	user := ProjectUser{Name: "a user", Roles: []string{"Viewer"}}
+	users, err := projectService.GetUsers("prj")
+	if assert.NoError(t, err) && assert.NotNil(t, users) {
+		assert.Equal(t, []ProjectUser{user}, *users)
+	}
+	foundUser, err := projectService.GetUser("prj", "a user")
+	if assert.NoError(t, err) && assert.NotNil(t, foundUser) {
+		assert.Equal(t, user, *foundUser)
+	}
+	missingUser, err := projectService.GetUser("prj", "missing-user")
+	assert.NoError(t, err)
+	assert.Nil(t, missingUser)
+
+	assert.NoError(t, projectService.UpdateUser("prj", "a user", user))
+	assert.Equal(t, testAccessRequest{http.MethodPut, "/api/v1/projects/prj/users/a%20user", `{"name":"a user","roles":["Viewer"]}`}, requests[len(requests)-1])
+	assert.NoError(t, projectService.DeleteExistingUser("prj", "a user"))
+	// The username is a single path segment.
+	assert.NoError(t, projectService.DeleteExistingUser("prj", "a/user"))
+	// Users which aren't members of the project can't be deleted.
+	assert.Error(t, projectService.DeleteExistingUser("prj", "missing-user"))
+}
+
+func TestProjectStorageQuota(t *testing.T) {
+	var requests []testAccessRequest
+	server, projectService := startMockProjectService(t, map[string]testAccessResponse{
+		"GET /api/v1/projects/prj": {http.StatusOK, `{"display_name":"Project","description":"My project","storage_quota_bytes":1073741825,"project_key":"prj"}`},
+		"PUT /api/v1/projects/prj": {http.StatusOK, ""},
+	}, &requests)
+	defer server.Close()
+
+	quota, err := projectService.GetStorageQuota("prj")
+	if assert.NoError(t, err) && assert.NotNil(t, quota) {
+		assert.Equal(t, ProjectStorageQuota{StorageQuotaBytes: 1073741825}, *quota)
+	}
+
+	// The rest of the project's details are kept.
+	assert.NoError(t, projectService.UpdateStorageQuota("prj", ProjectStorageQuota{StorageQuotaBytes: 2147483648, SoftLimit: true}))
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, testAccessRequest{http.MethodPut, "/api/v1/projects/prj",
+			`{"display_name":"Project","description":"My project","soft_limit":true,"storage_quota_bytes":2147483648,"project_key":"prj"}`}, requests[2])
+	}
+
+	_, err = projectService.GetStorageQuota("missing")
+	assert.EqualError(t, err, "project 'missing' does not exist")
+	assert.EqualError(t, projectService.UpdateStorageQuota("missing", ProjectStorageQuota{}), "project 'missing' does not exist")
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+// The types of project roles. Only custom roles can be created, changed and deleted.
+const (
+	ProjectRoleTypePredefined = "PREDEFINED"
+	ProjectRoleTypeCustom     = "CUSTOM"
+	ProjectRoleTypeAdmin      = "ADMIN"
+)
+
+// A role which can be assigned to the users and groups of a project. The actions are granted on the resources of the
+// project's environments, e.g. "READ_REPOSITORY" and "DEPLOY_CACHE_REPOSITORY" on "DEV".
+type ProjectRole struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	Environments []string `json:"environments,omitempty"`
+	Actions      []string `json:"actions,omitempty"`
+}
+
+func (ps *ProjectService) GetRoles(projectKey string) (*[]ProjectRole, error) {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/roles", ps.getProjectsBaseUrl(), projectKey)
+	resp, body, _, err := ps.client.SendGet(url, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	// In case the requested project is not found
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var roles []ProjectRole
+	err = json.Unmarshal(body, &roles)
+	return &roles, errorutils.CheckError(err)
+}
+
+func (ps *ProjectService) GetRole(projectKey string, roleName string) (*ProjectRole, error) {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/roles/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(roleName))
+	resp, body, _, err := ps.client.SendGet(url, true, &httpDetails)
+	if err != nil {
+		return nil, err
+	}
+	// In case the requested project or role is not found
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	var role ProjectRole
+	err = json.Unmarshal(body, &role)
+	return &role, errorutils.CheckError(err)
+}
+
+func (ps *ProjectService) CreateRole(projectKey string, role ProjectRole) error {
+	if role.Type == "" {
+		role.Type = ProjectRoleTypeCustom
+	}
+	requestContent, httpDetails, err := ps.createJsonRequest(role)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/roles", ps.getProjectsBaseUrl(), projectKey)
+	resp, body, err := ps.client.SendPost(url, requestContent, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusCreated)
+}
+
+func (ps *ProjectService) UpdateRole(projectKey string, role ProjectRole) error {
+	if role.Type == "" {
+		role.Type = ProjectRoleTypeCustom
+	}
+	requestContent, httpDetails, err := ps.createJsonRequest(role)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/roles/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(role.Name))
+	resp, body, err := ps.client.SendPut(url, requestContent, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK)
+}
+
+func (ps *ProjectService) DeleteRole(projectKey string, roleName string) error {
+	httpDetails := ps.ServiceDetails.CreateHttpClientDetails()
+	url := fmt.Sprintf("%s/%s/roles/%s", ps.getProjectsBaseUrl(), projectKey, url.PathEscape(roleName))
+	resp, body, err := ps.client.SendDelete(url, nil, &httpDetails)
+	if err != nil {
+		return err
+	}
+	return errorutils.CheckResponseStatusWithBody(resp, body, http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectRoles(t *testing.T) {
+	var requests []testAccessRequest
+	server, projectService := startMockProjectService(t, map[string]testAccessResponse{
+		"GET /api/v1/projects/prj/roles":                       {http.StatusOK, `[{"name":"Viewer","type":"PREDEFINED","environments":["DEV","PROD"],"actions":["READ_REPOSITORY"]}]`},
+		"GET /api/v1/projects/prj/roles/Release%20Approver":    {http.StatusOK, `{"name":"Release Approver","type":"CUSTOM","environments":["PROD"]}`},
+		"POST /api/v1/projects/prj/roles":                      {http.StatusCreated, ""},
+		"PUT /api/v1/projects/prj/roles/Release%20Approver":    {http.StatusOK, ""},
+		"DELETE /api/v1/projects/prj/roles/Release%20Approver": {http.StatusNoContent, ""},
+	}, &requests)
+	defer server.Close()
+
+	roles, err := projectService.GetRoles("prj")
+	if assert.NoError(t, err) && assert.NotNil(t, roles) {
+		assert.Equal(t, []ProjectRole{{Name: "Viewer", Type: ProjectRoleTypePredefined, Environments: []string{"DEV", "PROD"}, Actions: []string{"READ_REPOSITORY"}}}, *roles)
+	}
+	role, err := projectService.GetRole("prj", "Release Approver")
+	if assert.NoError(t, err) && assert.NotNil(t, role) {
+		assert.Equal(t, ProjectRole{Name: "Release Approver", Type: ProjectRoleTypeCustom, Environments: []string{"PROD"}}, *role)
+	}
+	missingRole, err := projectService.GetRole("prj", "Missing")
+	assert.NoError(t, err)
+	assert.Nil(t, missingRole)
+
+	// Roles without types are created and updated as custom roles.
+	newRole := ProjectRole{Name: "Release Approver", Environments: []string{"PROD"}, Actions: []string{"READ_REPOSITORY"}}
+	assert.NoError(t, projectService.CreateRole("prj", newRole))
+	assert.Equal(t, testAccessRequest{http.MethodPost, "/api/v1/projects/prj/roles",
+		`{"name":"Release Approver","type":"CUSTOM","environments":["PROD"],"actions":["READ_REPOSITORY"]}`}, requests[len(requests)-1])
+	newRole.Description = "Approves releases"
+	assert.NoError(t, projectService.UpdateRole("prj", newRole))
+	assert.Equal(t, testAccessRequest{http.MethodPut, "/api/v1/projects/prj/roles/Release%20Approver",
+		`{"name":"Release Approver","description":"Approves releases","type":"CUSTOM","environments":["PROD"],"actions":["READ_REPOSITORY"]}`}, requests[len(requests)-1])
+	assert.NoError(t, projectService.DeleteRole("prj", "Release Approver"))
+	assert.Error(t, projectService.DeleteRole("prj", "Missing"))
+}
@@ -36,10 +36,20 @@ type ArtifactoryServicesManager interface {
 	GetAllRepositories() (*[]services.RepositoryDetails, error)
 	GetAllRepositoriesFiltered(params services.RepositoriesFilterParams) (*[]services.RepositoryDetails, error)
 	IsRepoExists(repoKey string) (bool, error)
+	SimulateVirtualResolution(params services.VirtualResolutionParams) (*services.VirtualResolutionReport, error)
+	PlanRepositoriesConfig(params services.RepositoriesConfigParams) (*services.RepositoriesPlan, error)
+	ApplyRepositoriesPlan(plan *services.RepositoriesPlan) error
+	ExportRepositories(params services.RepositoriesExportParams) ([]string, error)
+	ImportRepositories(params services.RepositoriesImportParams) (*services.RepositoriesImportReport, error)
 	CreatePermissionTarget(params services.PermissionTargetParams) error
 	UpdatePermissionTarget(params services.PermissionTargetParams) error
 	DeletePermissionTarget(permissionTargetName string) error
+	DeletePermissionTargetFromProject(permissionTargetName, projectKey string) error
 	GetPermissionTarget(permissionTargetName string) (*services.PermissionTargetParams, error)
+	GetAllPermissionTargets() ([]*services.PermissionTargetParams, error)
+	ListPermissionTargets(params services.PermissionTargetsListParams) (*services.PermissionTargetsPage, error)
+	PatchPermissionTarget(params services.PermissionTargetPatchParams) error
+	GetEffectivePermissions(params services.EffectivePermissionsParams) (*services.EffectivePermissions, error)
 	PublishBuildInfo(build *buildinfo.BuildInfo, projectKey string) (*clientutils.Sha256Summary, error)
 	DistributeBuild(params services.BuildDistributionParams) error
 	PromoteBuild(params services.PromotionParams) error
@@ -47,19 +57,26 @@ type ArtifactoryServicesManager interface {
 	XrayScanBuild(params services.XrayScanParams) ([]byte, error)
 	GetPathsToDelete(params services.DeleteParams) (*content.ContentReader, error)
 	DeleteFiles(reader *content.ContentReader) (int, error)
+	PlanDelete(reader *content.ContentReader) (*content.ContentReader, error)
 	ReadRemoteFile(readPath string) (io.ReadCloser, error)
 	DownloadFiles(params ...services.DownloadParams) (totalDownloaded, totalFailed int, err error)
 	DownloadFilesWithSummary(params ...services.DownloadParams) (operationSummary *utils.OperationSummary, err error)
+	PlanDownload(params ...services.DownloadParams) (*content.ContentReader, error)
+	SyncFiles(params services.SyncParams) (*services.SyncSummary, error)
 	GetUnreferencedGitLfsFiles(params services.GitLfsCleanParams) (*content.ContentReader, error)
 	SearchFiles(params services.SearchParams) (*content.ContentReader, error)
 	Aql(aql string) (io.ReadCloser, error)
+	SearchAql(aql string) (*content.ContentReader, error)
 	SetProps(params services.PropsParams) (int, error)
 	DeleteProps(params services.PropsParams) (int, error)
 	GetItemProps(relativePath string) (*utils.ItemProperties, error)
 	UploadFilesWithSummary(params ...services.UploadParams) (operationSummary *utils.OperationSummary, err error)
+	PlanUpload(params ...services.UploadParams) (*content.ContentReader, error)
 	UploadFiles(params ...services.UploadParams) (totalUploaded, totalFailed int, err error)
 	Copy(params ...services.MoveCopyParams) (successCount, failedCount int, err error)
 	Move(params ...services.MoveCopyParams) (successCount, failedCount int, err error)
+	PlanCopy(params ...services.MoveCopyParams) (*content.ContentReader, error)
+	PlanMove(params ...services.MoveCopyParams) (*content.ContentReader, error)
 	PublishGoProject(params _go.GoParams) (*utils.OperationSummary, error)
 	Ping() ([]byte, error)
 	GetConfig() config.Config
@@ -76,6 +93,13 @@ type ArtifactoryServicesManager interface {
 	UpdateReplication(params services.UpdateReplicationParams) error
 	DeleteReplication(repoKey string) error
 	GetReplication(repoKey string) ([]utils.ReplicationParams, error)
+	SetMultiPushReplication(params services.MultiPushReplicationParams) error
+	AddReplicationTarget(repoKey string, target utils.ReplicationParams) error
+	UpdateReplicationTarget(repoKey string, target utils.ReplicationParams) error
+	RemoveReplicationTarget(repoKey, targetUrl string) error
+	GetReplicationStatus(repoKey string) (*services.ReplicationStatus, error)
+	TriggerReplication(params services.TriggerReplicationParams) error
+	TriggerReplicationAndWait(params services.TriggerReplicationParams) (*services.ReplicationStatus, error)
 	GetVersion() (string, error)
 	GetRunningNodes() ([]string, error)
 	GetServiceId() (string, error)
@@ -96,9 +120,14 @@ type ArtifactoryServicesManager interface {
 	DeleteUser(name string) error
 	GetLockedUsers() ([]string, error)
 	UnlockUser(name string) error
+	SyncIdentities(params services.IdentitiesSyncParams) (*services.IdentitiesSyncReport, error)
 	ConvertLocalToFederatedRepository(repoKey string) error
 	TriggerFederatedRepositoryFullSyncAll(repoKey string) error
 	TriggerFederatedRepositoryFullSyncMirror(repoKey string, mirrorUrl string) error
+	AddFederatedRepositoryMembers(repoKey string, members ...services.FederatedRepositoryMember) error
+	RemoveFederatedRepositoryMembers(repoKey string, memberUrls ...string) error
+	GetFederatedRepositoryStatus(repoKey string) (*services.FederationStatus, error)
+	WaitForFederatedRepositoryFullSync(params services.FederatedFullSyncWaitParams) (*services.FederationStatus, error)
 	Export(params services.ExportParams) error
 	FolderInfo(relativePath string) (*utils.FolderInfo, error)
 	FileList(relativePath string, optionalParams utils.FileListParams) (*utils.FileListResponse, error)
@@ -181,6 +210,26 @@ func (esm *EmptyArtifactoryServicesManager) IsRepoExists(string) (bool, error) {
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) SimulateVirtualResolution(services.VirtualResolutionParams) (*services.VirtualResolutionReport, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) PlanRepositoriesConfig(services.RepositoriesConfigParams) (*services.RepositoriesPlan, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) ApplyRepositoriesPlan(*services.RepositoriesPlan) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) ExportRepositories(services.RepositoriesExportParams) ([]string, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) ImportRepositories(services.RepositoriesImportParams) (*services.RepositoriesImportReport, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) CreatePermissionTarget(services.PermissionTargetParams) error {
 	panic("Failed: Method is not implemented")
 }
@@ -193,10 +242,30 @@ func (esm *EmptyArtifactoryServicesManager) DeletePermissionTarget(string) error
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) DeletePermissionTargetFromProject(string, string) error {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) GetPermissionTarget(string) (*services.PermissionTargetParams, error) {
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) GetAllPermissionTargets() ([]*services.PermissionTargetParams, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) ListPermissionTargets(services.PermissionTargetsListParams) (*services.PermissionTargetsPage, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) PatchPermissionTarget(services.PermissionTargetPatchParams) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) GetEffectivePermissions(services.EffectivePermissionsParams) (*services.EffectivePermissions, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) PublishBuildInfo(*buildinfo.BuildInfo, string) (*clientutils.Sha256Summary, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -225,6 +294,10 @@ func (esm *EmptyArtifactoryServicesManager) DeleteFiles(*content.ContentReader)
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) PlanDelete(*content.ContentReader) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) ReadRemoteFile(string) (io.ReadCloser, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -237,6 +310,14 @@ func (esm *EmptyArtifactoryServicesManager) DownloadFilesWithSummary(...services
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) PlanDownload(...services.DownloadParams) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) SyncFiles(services.SyncParams) (*services.SyncSummary, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) GetUnreferencedGitLfsFiles(services.GitLfsCleanParams) (*content.ContentReader, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -249,6 +330,10 @@ func (esm *EmptyArtifactoryServicesManager) Aql(string) (io.ReadCloser, error) {
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) SearchAql(string) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) SetProps(services.PropsParams) (int, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -269,6 +354,10 @@ func (esm *EmptyArtifactoryServicesManager) UploadFilesWithSummary(...services.U
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) PlanUpload(...services.UploadParams) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) Copy(...services.MoveCopyParams) (int, int, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -277,6 +366,14 @@ func (esm *EmptyArtifactoryServicesManager) Move(...services.MoveCopyParams) (in
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) PlanCopy(...services.MoveCopyParams) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) PlanMove(...services.MoveCopyParams) (*content.ContentReader, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) PublishGoProject(_go.GoParams) (*utils.OperationSummary, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -341,6 +438,34 @@ func (esm *EmptyArtifactoryServicesManager) GetReplication(string) ([]utils.Repl
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) SetMultiPushReplication(services.MultiPushReplicationParams) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) AddReplicationTarget(string, utils.ReplicationParams) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) UpdateReplicationTarget(string, utils.ReplicationParams) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) RemoveReplicationTarget(string, string) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) GetReplicationStatus(string) (*services.ReplicationStatus, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) TriggerReplication(services.TriggerReplicationParams) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) TriggerReplicationAndWait(services.TriggerReplicationParams) (*services.ReplicationStatus, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) GetVersion() (string, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -409,6 +534,10 @@ func (esm *EmptyArtifactoryServicesManager) UnlockUser(string) error {
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) SyncIdentities(services.IdentitiesSyncParams) (*services.IdentitiesSyncReport, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) GetGroup(services.GroupParams) (*services.Group, error) {
 	panic("Failed: Method is not implemented")
 }
@@ -441,6 +570,22 @@ func (esm *EmptyArtifactoryServicesManager) TriggerFederatedRepositoryFullSyncMi
 	panic("Failed: Method is not implemented")
 }
 
+func (esm *EmptyArtifactoryServicesManager) AddFederatedRepositoryMembers(string, ...services.FederatedRepositoryMember) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) RemoveFederatedRepositoryMembers(string, ...string) error {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) GetFederatedRepositoryStatus(string) (*services.FederationStatus, error) {
+	panic("Failed: Method is not implemented")
+}
+
+func (esm *EmptyArtifactoryServicesManager) WaitForFederatedRepositoryFullSync(services.FederatedFullSyncWaitParams) (*services.FederationStatus, error) {
+	panic("Failed: Method is not implemented")
+}
+
 func (esm *EmptyArtifactoryServicesManager) Export(services.ExportParams) error {
 	panic("Failed: Method is not implemented")
 }
@@ -18,9 +18,10 @@ import (
 )
 
 type ArtifactoryServicesManagerImp struct {
-	client   *jfroghttpclient.JfrogHttpClient
-	config   config.Config
-	progress ioutils.ProgressMgr
+	client           *jfroghttpclient.JfrogHttpClient
+	config           config.Config
+	progress         ioutils.ProgressMgr
+	transferObserver ioutils.TransferObserver
 }
 
 func New(config config.Config) (ArtifactoryServicesManager, error) {
@@ -28,6 +29,12 @@ func New(config config.Config) (ArtifactoryServicesManager, error) {
 }
 
 func NewWithProgress(config config.Config, progress ioutils.ProgressMgr) (ArtifactoryServicesManager, error) {
+	return NewWithTransferObserver(config, progress, nil)
+}
+
+// The observer receives the events of the files transferred by uploads, downloads, moves, copies, deletions and syncs.
+// Both progress and observer may be nil.
+func NewWithTransferObserver(config config.Config, progress ioutils.ProgressMgr, observer ioutils.TransferObserver) (ArtifactoryServicesManager, error) {
 	artDetails := config.GetServiceDetails()
 	err := artDetails.InitSsh()
 	if err != nil {
@@ -57,6 +64,7 @@ func NewWithProgress(config config.Config, progress ioutils.ProgressMgr) (Artifa
 		return nil, err
 	}
 	manager.progress = progress
+	manager.transferObserver = observer
 	return manager, err
 }
 
@@ -180,6 +188,36 @@ func (sm *ArtifactoryServicesManagerImp) IsRepoExists(repoKey string) (bool, err
 	return repositoriesService.IsExists(repoKey)
 }
 
+func (sm *ArtifactoryServicesManagerImp) SimulateVirtualResolution(params services.VirtualResolutionParams) (*services.VirtualResolutionReport, error) {
+	virtualResolutionService := services.NewVirtualResolutionService(sm.client)
+	virtualResolutionService.ArtDetails = sm.config.GetServiceDetails()
+	return virtualResolutionService.Simulate(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) PlanRepositoriesConfig(params services.RepositoriesConfigParams) (*services.RepositoriesPlan, error) {
+	repositoriesConfigService := services.NewRepositoriesConfigService(sm.client)
+	repositoriesConfigService.ArtDetails = sm.config.GetServiceDetails()
+	return repositoriesConfigService.Plan(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) ApplyRepositoriesPlan(plan *services.RepositoriesPlan) error {
+	repositoriesConfigService := services.NewRepositoriesConfigService(sm.client)
+	repositoriesConfigService.ArtDetails = sm.config.GetServiceDetails()
+	return repositoriesConfigService.Apply(plan)
+}
+
+func (sm *ArtifactoryServicesManagerImp) ExportRepositories(params services.RepositoriesExportParams) ([]string, error) {
+	repositoriesMigrationService := services.NewRepositoriesMigrationService(sm.client)
+	repositoriesMigrationService.ArtDetails = sm.config.GetServiceDetails()
+	return repositoriesMigrationService.Export(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) ImportRepositories(params services.RepositoriesImportParams) (*services.RepositoriesImportReport, error) {
+	repositoriesMigrationService := services.NewRepositoriesMigrationService(sm.client)
+	repositoriesMigrationService.ArtDetails = sm.config.GetServiceDetails()
+	return repositoriesMigrationService.Import(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) CreatePermissionTarget(params services.PermissionTargetParams) error {
 	permissionTargetService := services.NewPermissionTargetService(sm.client)
 	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
@@ -198,12 +236,42 @@ func (sm *ArtifactoryServicesManagerImp) DeletePermissionTarget(permissionTarget
 	return permissionTargetService.Delete(permissionTargetName)
 }
 
+func (sm *ArtifactoryServicesManagerImp) DeletePermissionTargetFromProject(permissionTargetName, projectKey string) error {
+	permissionTargetService := services.NewPermissionTargetService(sm.client)
+	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
+	return permissionTargetService.DeleteFromProject(permissionTargetName, projectKey)
+}
+
 func (sm *ArtifactoryServicesManagerImp) GetPermissionTarget(permissionTargetName string) (*services.PermissionTargetParams, error) {
 	permissionTargetService := services.NewPermissionTargetService(sm.client)
 	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
 	return permissionTargetService.Get(permissionTargetName)
 }
 
+func (sm *ArtifactoryServicesManagerImp) GetAllPermissionTargets() ([]*services.PermissionTargetParams, error) {
+	permissionTargetService := services.NewPermissionTargetService(sm.client)
+	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
+	return permissionTargetService.GetAll()
+}
+
+func (sm *ArtifactoryServicesManagerImp) ListPermissionTargets(params services.PermissionTargetsListParams) (*services.PermissionTargetsPage, error) {
+	permissionTargetService := services.NewPermissionTargetService(sm.client)
+	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
+	return permissionTargetService.List(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) PatchPermissionTarget(params services.PermissionTargetPatchParams) error {
+	permissionTargetService := services.NewPermissionTargetService(sm.client)
+	permissionTargetService.ArtDetails = sm.config.GetServiceDetails()
+	return permissionTargetService.Patch(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) GetEffectivePermissions(params services.EffectivePermissionsParams) (*services.EffectivePermissions, error) {
+	effectivePermissionsService := services.NewEffectivePermissionsService(sm.client)
+	effectivePermissionsService.ArtDetails = sm.config.GetServiceDetails()
+	return effectivePermissionsService.Get(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) PublishBuildInfo(build *buildinfo.BuildInfo, projectKey string) (*clientutils.Sha256Summary, error) {
 	buildInfoService := services.NewBuildInfoService(sm.config.GetServiceDetails(), sm.client)
 	buildInfoService.DryRun = sm.config.IsDryRun()
@@ -246,9 +314,16 @@ func (sm *ArtifactoryServicesManagerImp) DeleteFiles(reader *content.ContentRead
 	deleteService := services.NewDeleteService(sm.config.GetServiceDetails(), sm.client)
 	deleteService.DryRun = sm.config.IsDryRun()
 	deleteService.Threads = sm.config.GetThreads()
+	deleteService.Observer = sm.transferObserver
 	return deleteService.DeleteFiles(reader)
 }
 
+func (sm *ArtifactoryServicesManagerImp) PlanDelete(reader *content.ContentReader) (*content.ContentReader, error) {
+	deleteService := services.NewDeleteService(sm.config.GetServiceDetails(), sm.client)
+	deleteService.Threads = sm.config.GetThreads()
+	return deleteService.PlanDelete(reader)
+}
+
 func (sm *ArtifactoryServicesManagerImp) ReadRemoteFile(readPath string) (io.ReadCloser, error) {
 	readFileService := services.NewReadFileService(sm.config.GetServiceDetails(), sm.client)
 	readFileService.DryRun = sm.config.IsDryRun()
@@ -260,6 +335,7 @@ func (sm *ArtifactoryServicesManagerImp) initDownloadService() *services.Downloa
 	downloadService.DryRun = sm.config.IsDryRun()
 	downloadService.Threads = sm.config.GetThreads()
 	downloadService.Progress = sm.progress
+	downloadService.Observer = sm.transferObserver
 	return downloadService
 }
 
@@ -278,6 +354,19 @@ func (sm *ArtifactoryServicesManagerImp) DownloadFilesWithSummary(params ...serv
 	return downloadService.DownloadFiles(params...)
 }
 
+func (sm *ArtifactoryServicesManagerImp) PlanDownload(params ...services.DownloadParams) (*content.ContentReader, error) {
+	return sm.initDownloadService().PlanDownload(params...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) SyncFiles(params services.SyncParams) (*services.SyncSummary, error) {
+	syncService := services.NewSyncService(sm.config.GetServiceDetails(), sm.client)
+	syncService.DryRun = sm.config.IsDryRun()
+	syncService.Threads = sm.config.GetThreads()
+	syncService.Progress = sm.progress
+	syncService.Observer = sm.transferObserver
+	return syncService.SyncFiles(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) GetUnreferencedGitLfsFiles(params services.GitLfsCleanParams) (*content.ContentReader, error) {
 	gitLfsCleanService := services.NewGitLfsCleanService(sm.config.GetServiceDetails(), sm.client)
 	gitLfsCleanService.DryRun = sm.config.IsDryRun()
@@ -294,6 +383,11 @@ func (sm *ArtifactoryServicesManagerImp) Aql(aql string) (io.ReadCloser, error)
 	return aqlService.ExecAql(aql)
 }
 
+func (sm *ArtifactoryServicesManagerImp) SearchAql(aql string) (*content.ContentReader, error) {
+	aqlService := services.NewAqlService(sm.config.GetServiceDetails(), sm.client)
+	return aqlService.SearchAql(aql)
+}
+
 func (sm *ArtifactoryServicesManagerImp) SetProps(params services.PropsParams) (int, error) {
 	setPropsService := services.NewPropsService(sm.client)
 	setPropsService.ArtDetails = sm.config.GetServiceDetails()
@@ -320,6 +414,7 @@ func (sm *ArtifactoryServicesManagerImp) initUploadService() *services.UploadSer
 	uploadService.ArtDetails = sm.config.GetServiceDetails()
 	uploadService.DryRun = sm.config.IsDryRun()
 	uploadService.Progress = sm.progress
+	uploadService.Observer = sm.transferObserver
 	return uploadService
 }
 
@@ -338,18 +433,32 @@ func (sm *ArtifactoryServicesManagerImp) UploadFilesWithSummary(params ...servic
 	return uploadService.UploadFiles(params...)
 }
 
+func (sm *ArtifactoryServicesManagerImp) PlanUpload(params ...services.UploadParams) (*content.ContentReader, error) {
+	return sm.initUploadService().PlanUpload(params...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) initMoveCopyService(moveType services.MoveType) *services.MoveCopyService {
+	moveCopyService := services.NewMoveCopyService(sm.config.GetServiceDetails(), sm.client, moveType)
+	moveCopyService.DryRun = sm.config.IsDryRun()
+	moveCopyService.Threads = sm.config.GetThreads()
+	moveCopyService.Observer = sm.transferObserver
+	return moveCopyService
+}
+
 func (sm *ArtifactoryServicesManagerImp) Copy(params ...services.MoveCopyParams) (successCount, failedCount int, err error) {
-	copyService := services.NewMoveCopyService(sm.config.GetServiceDetails(), sm.client, services.COPY)
-	copyService.DryRun = sm.config.IsDryRun()
-	copyService.Threads = sm.config.GetThreads()
-	return copyService.MoveCopyServiceMoveFilesWrapper(params...)
+	return sm.initMoveCopyService(services.COPY).MoveCopyServiceMoveFilesWrapper(params...)
 }
 
 func (sm *ArtifactoryServicesManagerImp) Move(params ...services.MoveCopyParams) (successCount, failedCount int, err error) {
-	moveService := services.NewMoveCopyService(sm.config.GetServiceDetails(), sm.client, services.MOVE)
-	moveService.DryRun = sm.config.IsDryRun()
-	moveService.Threads = sm.config.GetThreads()
-	return moveService.MoveCopyServiceMoveFilesWrapper(params...)
+	return sm.initMoveCopyService(services.MOVE).MoveCopyServiceMoveFilesWrapper(params...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) PlanCopy(params ...services.MoveCopyParams) (*content.ContentReader, error) {
+	return sm.initMoveCopyService(services.COPY).PlanMoveCopy(params...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) PlanMove(params ...services.MoveCopyParams) (*content.ContentReader, error) {
+	return sm.initMoveCopyService(services.MOVE).PlanMoveCopy(params...)
 }
 
 func (sm *ArtifactoryServicesManagerImp) PublishGoProject(params _go.GoParams) (*utils.OperationSummary, error) {
@@ -444,6 +553,48 @@ func (sm *ArtifactoryServicesManagerImp) GetReplication(repoKey string) ([]utils
 	return getPushReplicationService.GetReplication(repoKey)
 }
 
+func (sm *ArtifactoryServicesManagerImp) SetMultiPushReplication(params services.MultiPushReplicationParams) error {
+	multiPushReplicationService := services.NewMultiPushReplicationService(sm.client)
+	multiPushReplicationService.ArtDetails = sm.config.GetServiceDetails()
+	return multiPushReplicationService.SetReplications(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) AddReplicationTarget(repoKey string, target utils.ReplicationParams) error {
+	multiPushReplicationService := services.NewMultiPushReplicationService(sm.client)
+	multiPushReplicationService.ArtDetails = sm.config.GetServiceDetails()
+	return multiPushReplicationService.AddTarget(repoKey, target)
+}
+
+func (sm *ArtifactoryServicesManagerImp) UpdateReplicationTarget(repoKey string, target utils.ReplicationParams) error {
+	multiPushReplicationService := services.NewMultiPushReplicationService(sm.client)
+	multiPushReplicationService.ArtDetails = sm.config.GetServiceDetails()
+	return multiPushReplicationService.UpdateTarget(repoKey, target)
+}
+
+func (sm *ArtifactoryServicesManagerImp) RemoveReplicationTarget(repoKey, targetUrl string) error {
+	multiPushReplicationService := services.NewMultiPushReplicationService(sm.client)
+	multiPushReplicationService.ArtDetails = sm.config.GetServiceDetails()
+	return multiPushReplicationService.RemoveTarget(repoKey, targetUrl)
+}
+
+func (sm *ArtifactoryServicesManagerImp) GetReplicationStatus(repoKey string) (*services.ReplicationStatus, error) {
+	replicationStatusService := services.NewReplicationStatusService(sm.client)
+	replicationStatusService.ArtDetails = sm.config.GetServiceDetails()
+	return replicationStatusService.GetStatus(repoKey)
+}
+
+func (sm *ArtifactoryServicesManagerImp) TriggerReplication(params services.TriggerReplicationParams) error {
+	replicationStatusService := services.NewReplicationStatusService(sm.client)
+	replicationStatusService.ArtDetails = sm.config.GetServiceDetails()
+	return replicationStatusService.Trigger(params)
+}
+
+func (sm *ArtifactoryServicesManagerImp) TriggerReplicationAndWait(params services.TriggerReplicationParams) (*services.ReplicationStatus, error) {
+	replicationStatusService := services.NewReplicationStatusService(sm.client)
+	replicationStatusService.ArtDetails = sm.config.GetServiceDetails()
+	return replicationStatusService.TriggerAndWait(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) ConvertLocalToFederatedRepository(repoKey string) error {
 	getFederationService := services.NewFederationService(sm.client)
 	getFederationService.ArtDetails = sm.config.GetServiceDetails()
@@ -462,6 +613,30 @@ func (sm *ArtifactoryServicesManagerImp) TriggerFederatedRepositoryFullSyncMirro
 	return getFederationService.TriggerFederatedFullSyncMirror(repoKey, mirrorUrl)
 }
 
+func (sm *ArtifactoryServicesManagerImp) AddFederatedRepositoryMembers(repoKey string, members ...services.FederatedRepositoryMember) error {
+	federationService := services.NewFederationService(sm.client)
+	federationService.ArtDetails = sm.config.GetServiceDetails()
+	return federationService.AddMembers(repoKey, members...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) RemoveFederatedRepositoryMembers(repoKey string, memberUrls ...string) error {
+	federationService := services.NewFederationService(sm.client)
+	federationService.ArtDetails = sm.config.GetServiceDetails()
+	return federationService.RemoveMembers(repoKey, memberUrls...)
+}
+
+func (sm *ArtifactoryServicesManagerImp) GetFederatedRepositoryStatus(repoKey string) (*services.FederationStatus, error) {
+	federationService := services.NewFederationService(sm.client)
+	federationService.ArtDetails = sm.config.GetServiceDetails()
+	return federationService.GetStatus(repoKey)
+}
+
+func (sm *ArtifactoryServicesManagerImp) WaitForFederatedRepositoryFullSync(params services.FederatedFullSyncWaitParams) (*services.FederationStatus, error) {
+	federationService := services.NewFederationService(sm.client)
+	federationService.ArtDetails = sm.config.GetServiceDetails()
+	return federationService.WaitForFullSync(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) GetVersion() (string, error) {
 	systemService := services.NewSystemService(sm.config.GetServiceDetails(), sm.client)
 	return systemService.GetVersion()
@@ -564,6 +739,14 @@ func (sm *ArtifactoryServicesManagerImp) UnlockUser(name string) error {
 	return userService.UnlockUser(name)
 }
 
+func (sm *ArtifactoryServicesManagerImp) SyncIdentities(params services.IdentitiesSyncParams) (*services.IdentitiesSyncReport, error) {
+	identitiesSyncService := services.NewIdentitiesSyncService(sm.client)
+	identitiesSyncService.ArtDetails = sm.config.GetServiceDetails()
+	identitiesSyncService.DryRun = sm.config.IsDryRun()
+	identitiesSyncService.Threads = sm.config.GetThreads()
+	return identitiesSyncService.Sync(params)
+}
+
 func (sm *ArtifactoryServicesManagerImp) PromoteDocker(params services.DockerPromoteParams) error {
 	systemService := services.NewDockerPromoteService(sm.config.GetServiceDetails(), sm.client)
 	return systemService.PromoteDocker(params)
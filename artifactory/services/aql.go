@@ -6,6 +6,7 @@ import (
 	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
 	"github.com/madotis/jfrog-client-go/auth"
 	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
 )
 
 type AqlService struct {
@@ -33,6 +34,12 @@ func (s *AqlService) ExecAql(aql string) (io.ReadCloser, error) {
 	return s.exec(aql)
 }
 
+// Returns a ContentReader of the query's results, which can be read into the result struct of the query's domain,
+// like utils.ResultItem for items.find or utils.BuildResult for builds.find.
+func (s *AqlService) SearchAql(aql string) (*content.ContentReader, error) {
+	return utils.ExecAqlSaveToFile(aql, s)
+}
+
 func (s *AqlService) exec(aql string) (io.ReadCloser, error) {
 	return utils.ExecAql(aql, s)
 }
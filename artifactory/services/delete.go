@@ -10,6 +10,7 @@ import (
 	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
 	clientutils "github.com/madotis/jfrog-client-go/utils"
 	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
 	"github.com/madotis/jfrog-client-go/utils/io/content"
 	"github.com/madotis/jfrog-client-go/utils/log"
 )
@@ -19,6 +20,10 @@ type DeleteService struct {
 	artDetails *auth.ServiceDetails
 	DryRun     bool
 	Threads    int
+	// If set, receives an event for each step of each artifact's deletion.
+	Observer ioutils.TransferObserver
+	// When set, the deletions are written to it instead of being performed.
+	planWriter *content.ContentWriter
 }
 
 func NewDeleteService(artDetails auth.ServiceDetails, client *jfroghttpclient.JfrogHttpClient) *DeleteService {
@@ -41,6 +46,17 @@ func (ds *DeleteService) SetThreads(threads int) {
 	ds.Threads = threads
 }
 
+func (ds *DeleteService) SetTransferObserver(observer ioutils.TransferObserver) {
+	ds.Observer = observer
+}
+
+func (ds *DeleteService) getTransferObserver() ioutils.TransferObserver {
+	if ds.planWriter != nil {
+		return nil
+	}
+	return ds.Observer
+}
+
 func (ds *DeleteService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
 	return ds.client
 }
@@ -55,7 +71,8 @@ func (ds *DeleteService) GetPathsToDelete(deleteParams DeleteParams) (resultItem
 			return
 		}
 	case utils.WILDCARD:
-		deleteParams.SetIncludeDirs(true)
+		// Folders are deleted with all of their files, so they are deleted only if no files are filtered out by the item filters.
+		deleteParams.SetIncludeDirs(!deleteParams.HasItemFilters())
 		tempResultItems, err = utils.SearchBySpecWithPattern(deleteParams.GetFile(), ds, utils.NONE)
 		if err != nil {
 			return
@@ -100,13 +117,24 @@ type fileDeleteHandlerFunc func(utils.ResultItem) parallel.TaskFunc
 
 func (ds *DeleteService) createFileHandlerFunc(result *utils.Result) fileDeleteHandlerFunc {
 	return func(resultItem utils.ResultItem) parallel.TaskFunc {
-		return func(threadId int) error {
+		events := newResultItemTransferEvents(ds.getTransferObserver(), ioutils.DeleteOperation, resultItem, "")
+		events.queued()
+		return func(threadId int) (err error) {
 			result.TotalCount[threadId]++
+			events.started()
+			defer func() {
+				events.finished(err == nil, err)
+			}()
 			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, ds.DryRun)
 			deletePath, e := utils.BuildArtifactoryUrl(ds.GetArtifactoryDetails().GetUrl(), resultItem.GetItemRelativePath(), make(map[string]string))
 			if e != nil {
 				return e
 			}
+			if ds.planWriter != nil {
+				ds.planDelete(resultItem)
+				result.SuccessCount[threadId]++
+				return nil
+			}
 			log.Info(logMsgPrefix+"Deleting", resultItem.GetItemRelativePath())
 			if ds.DryRun {
 				return nil
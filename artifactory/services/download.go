@@ -28,8 +28,10 @@ import (
 )
 
 type DownloadService struct {
-	client      *jfroghttpclient.JfrogHttpClient
-	Progress    clientio.ProgressMgr
+	client   *jfroghttpclient.JfrogHttpClient
+	Progress clientio.ProgressMgr
+	// If set, receives an event for each step of each file's download.
+	Observer    clientio.TransferObserver
 	artDetails  *auth.ServiceDetails
 	DryRun      bool
 	Threads     int
@@ -38,6 +40,10 @@ type DownloadService struct {
 	filesTransfersWriter *content.ContentWriter
 	// A ContentWriter of ArtifactDetails structs. Used only if saveSummary is set to true.
 	artifactsDetailsWriter *content.ContentWriter
+	// Records the details of the files that failed to be downloaded. Used only if saveSummary is set to true.
+	failedTransfers *failedTransfersRecorder
+	// When set, the actions of the download are written to it instead of being performed.
+	planWriter *content.ContentWriter
 	// This map is used for validating that a downloaded release bundle is signed with a given GPG public key. This is done for security reasons.
 	// The key is the release bundle name and version separated by "/" and the value is it's RbGpgValidator.
 	rbGpgValidationMap map[string]*utils.RbGpgValidator
@@ -68,6 +74,10 @@ func (ds *DownloadService) SetThreads(threads int) {
 	ds.Threads = threads
 }
 
+func (ds *DownloadService) SetTransferObserver(observer clientio.TransferObserver) {
+	ds.Observer = observer
+}
+
 func (ds *DownloadService) SetDryRun(isDryRun bool) {
 	ds.DryRun = isDryRun
 }
@@ -84,10 +94,22 @@ func (ds *DownloadService) getOperationSummary(totalSucceeded, totalFailed int)
 	if ds.saveSummary {
 		operationSummary.TransferDetailsReader = content.NewContentReader(ds.filesTransfersWriter.GetFilePath(), content.DefaultKey)
 		operationSummary.ArtifactsDetailsReader = content.NewContentReader(ds.artifactsDetailsWriter.GetFilePath(), content.DefaultKey)
+		operationSummary.FailedDetailsReader = ds.failedTransfers.getReader()
 	}
 	return operationSummary
 }
 
+// Returns the observer of the transfers' events, which also records the failed transfers when a summary is saved.
+func (ds *DownloadService) getTransferObserver() clientio.TransferObserver {
+	if ds.planWriter != nil {
+		return nil
+	}
+	if ds.saveSummary && ds.failedTransfers != nil {
+		return ds.failedTransfers
+	}
+	return ds.Observer
+}
+
 func (ds *DownloadService) DownloadFiles(downloadParams ...DownloadParams) (*utils.OperationSummary, error) {
 	var e error
 	producerConsumer := parallel.NewRunner(ds.GetThreads(), 20000, false)
@@ -105,6 +127,15 @@ func (ds *DownloadService) DownloadFiles(downloadParams ...DownloadParams) (*uti
 			return nil, e
 		}
 		defer ds.artifactsDetailsWriter.Close()
+		ds.failedTransfers, e = newFailedTransfersRecorder(ds.Observer)
+		if e != nil {
+			return nil, e
+		}
+		defer ds.failedTransfers.close()
+	}
+	downloadParams, e = expandFailedDownloads(downloadParams)
+	if e != nil {
+		return nil, e
 	}
 	ds.prepareTasks(producerConsumer, expectedChan, successCounters, errorsQueue, downloadParams...)
 
@@ -368,7 +399,7 @@ func createDownloadFileDetails(downloadPath, localPath, localFileName string, do
 	return
 }
 
-func (ds *DownloadService) downloadFile(downloadFileDetails *httpclient.DownloadFileDetails, logMsgPrefix string, downloadParams DownloadParams) error {
+func (ds *DownloadService) downloadFile(downloadFileDetails *httpclient.DownloadFileDetails, logMsgPrefix string, downloadParams DownloadParams, events *fileTransferEvents) error {
 	httpClientsDetails := ds.GetArtifactoryDetails().CreateHttpClientDetails()
 	bulkDownload := downloadParams.SplitCount == 0 || downloadParams.MinSplitSize < 0 || downloadParams.MinSplitSize*1000 > downloadFileDetails.Size
 	if !bulkDownload {
@@ -381,7 +412,7 @@ func (ds *DownloadService) downloadFile(downloadFileDetails *httpclient.Download
 	if bulkDownload {
 		var resp *http.Response
 		resp, err := ds.client.DownloadFileWithProgress(downloadFileDetails, logMsgPrefix, &httpClientsDetails,
-			downloadParams.IsExplode(), downloadParams.IsBypassArchiveInspection(), ds.Progress)
+			downloadParams.IsExplode(), downloadParams.IsBypassArchiveInspection(), events.progressMgr(ds.Progress))
 		if err != nil {
 			return err
 		}
@@ -401,7 +432,7 @@ func (ds *DownloadService) downloadFile(downloadFileDetails *httpclient.Download
 		Explode:       downloadParams.IsExplode(),
 		SkipChecksum:  downloadParams.SkipChecksum}
 
-	resp, err := ds.client.DownloadFileConcurrently(concurrentDownloadFlags, logMsgPrefix, &httpClientsDetails, ds.Progress)
+	resp, err := ds.client.DownloadFileConcurrently(concurrentDownloadFlags, logMsgPrefix, &httpClientsDetails, events.progressMgr(ds.Progress))
 	if err != nil {
 		return err
 	}
@@ -499,14 +530,20 @@ type fileHandlerFunc func(DownloadData) parallel.TaskFunc
 
 func (ds *DownloadService) createFileHandlerFunc(downloadParams DownloadParams, successCounters []int) fileHandlerFunc {
 	return func(downloadData DownloadData) parallel.TaskFunc {
-		return func(threadId int) error {
+		events := newResultItemTransferEvents(ds.getTransferObserver(), clientio.DownloadOperation, downloadData.Dependency, "")
+		events.queued()
+		return func(threadId int) (err error) {
+			events.started()
+			defer func() {
+				events.finished(err == nil, err)
+			}()
 			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, ds.DryRun)
 			downloadPath, e := utils.BuildArtifactoryUrl(ds.GetArtifactoryDetails().GetUrl(), downloadData.Dependency.GetItemRelativePath(), make(map[string]string))
 			if e != nil {
 				return e
 			}
 			log.Info(logMsgPrefix+"Downloading", downloadData.Dependency.GetItemRelativePath())
-			if ds.DryRun {
+			if ds.DryRun && ds.planWriter == nil {
 				successCounters[threadId]++
 				return nil
 			}
@@ -515,6 +552,13 @@ func (ds *DownloadService) createFileHandlerFunc(downloadParams DownloadParams,
 				return e
 			}
 			localPath, localFileName := fileutils.GetLocalPathAndFile(downloadData.Dependency.Name, downloadData.Dependency.Path, target, downloadData.Flat, placeholdersUsed)
+			events.setTargetPath(filepath.Join(localPath, localFileName))
+			if ds.planWriter != nil {
+				if e = ds.planDownload(downloadData, localPath, localFileName); e == nil {
+					successCounters[threadId]++
+				}
+				return e
+			}
 			if downloadData.Dependency.Type == "folder" {
 				return createDir(localPath, localFileName, logMsgPrefix)
 			}
@@ -527,7 +571,7 @@ func (ds *DownloadService) createFileHandlerFunc(downloadParams DownloadParams,
 					return e
 				}
 			}
-			e = ds.downloadFileIfNeeded(downloadPath, localPath, localFileName, logMsgPrefix, downloadData, downloadParams)
+			e = ds.downloadFileIfNeeded(downloadPath, localPath, localFileName, logMsgPrefix, downloadData, downloadParams, events)
 			if e != nil {
 				log.Error(logMsgPrefix, "Received an error: "+e.Error())
 				return e
@@ -539,20 +583,21 @@ func (ds *DownloadService) createFileHandlerFunc(downloadParams DownloadParams,
 	}
 }
 
-func (ds *DownloadService) downloadFileIfNeeded(downloadPath, localPath, localFileName, logMsgPrefix string, downloadData DownloadData, downloadParams DownloadParams) error {
+func (ds *DownloadService) downloadFileIfNeeded(downloadPath, localPath, localFileName, logMsgPrefix string, downloadData DownloadData, downloadParams DownloadParams, events *fileTransferEvents) error {
 	isEqual, e := fileutils.IsEqualToLocalFile(filepath.Join(localPath, localFileName), downloadData.Dependency.Actual_Md5, downloadData.Dependency.Actual_Sha1)
 	if e != nil {
 		return e
 	}
 	if isEqual {
 		log.Debug(logMsgPrefix, "File already exists locally.")
+		events.skipped("the file already exists locally")
 		if downloadParams.IsExplode() {
 			e = clientutils.ExtractArchive(localPath, localFileName, downloadData.Dependency.Name, logMsgPrefix, downloadParams.IsBypassArchiveInspection())
 		}
 		return e
 	}
 	downloadFileDetails := createDownloadFileDetails(downloadPath, localPath, localFileName, downloadData, downloadParams.IsSkipChecksum())
-	return ds.downloadFile(downloadFileDetails, logMsgPrefix, downloadParams)
+	return ds.downloadFile(downloadFileDetails, logMsgPrefix, downloadParams, events)
 }
 
 func createDir(localPath, localFileName, logMsgPrefix string) error {
@@ -598,6 +643,9 @@ type DownloadParams struct {
 	SplitCount              int
 	PublicGpgKey            string
 	SkipChecksum            bool
+	// A ContentReader of FailedTransferDetails structs, such as the FailedDetailsReader of a previous download's summary.
+	// When set, each of its files is downloaded to the local path it failed to be downloaded to, and the pattern and target are ignored.
+	FailedTransfers *content.ContentReader
 }
 
 func (ds *DownloadParams) IsFlat() bool {
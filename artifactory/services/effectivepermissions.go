@@ -0,0 +1,270 @@
+package services
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+// The actions a permission target can grant on repositories.
+const (
+	PermissionActionRead            = "read"
+	PermissionActionWrite           = "write"
+	PermissionActionAnnotate        = "annotate"
+	PermissionActionDelete          = "delete"
+	PermissionActionManage          = "manage"
+	PermissionActionManagedXrayMeta = "managedXrayMeta"
+	PermissionActionDistribute      = "distribute"
+)
+
+// The principal types of permission grants.
+const (
+	PermissionPrincipalUser  = "user"
+	PermissionPrincipalGroup = "group"
+)
+
+var allPermissionActions = []string{
+	PermissionActionRead,
+	PermissionActionWrite,
+	PermissionActionAnnotate,
+	PermissionActionDelete,
+	PermissionActionManage,
+	PermissionActionManagedXrayMeta,
+	PermissionActionDistribute,
+}
+
+// The actions which each action implies.
+var impliedPermissionActions = map[string][]string{
+	PermissionActionManage: allPermissionActions,
+	PermissionActionDelete: {PermissionActionWrite, PermissionActionAnnotate, PermissionActionRead},
+	PermissionActionWrite:  {PermissionActionAnnotate, PermissionActionRead},
+}
+
+// The special values of the repositories of permission targets, mapped to the repository class they apply to.
+// "ANY" applies to all the repositories.
+var anyRepositoryOfClass = map[string]string{
+	"ANY LOCAL":        "local",
+	"ANY REMOTE":       "remote",
+	"ANY FEDERATED":    "federated",
+	"ANY DISTRIBUTION": "distribution",
+}
+
+// Set either Username or GroupName.
+type EffectivePermissionsParams struct {
+	Username  string
+	GroupName string
+	RepoKey   string
+	// The path inside the repository. The repository's root by default.
+	Path string
+}
+
+func NewEffectivePermissionsParams() EffectivePermissionsParams {
+	return EffectivePermissionsParams{}
+}
+
+// A permission target which grants actions to the user, or to one of its groups.
+type PermissionGrant struct {
+	PermissionTarget string
+	// PermissionPrincipalUser or PermissionPrincipalGroup.
+	PrincipalType string
+	Principal     string
+	// The granted actions, and the actions they imply, like read by write. Sorted by name.
+	Actions []string
+}
+
+type EffectivePermissions struct {
+	RepoKey string
+	Path    string
+	// Admins are allowed to perform all the actions, regardless of the permission targets.
+	Admin bool
+	// Sorted by name.
+	Actions []string
+	// The permission targets which grant the actions.
+	Grants []PermissionGrant
+	// Permission targets which grant actions on the repository, but whose include or exclude patterns don't match the path.
+	ExcludedGrants []PermissionGrant
+}
+
+func (ep *EffectivePermissions) Can(action string) bool {
+	return slices.Contains(ep.Actions, action)
+}
+
+// Returns the grants of the action. Empty for admins, which don't need any.
+func (ep *EffectivePermissions) GrantedBy(action string) []PermissionGrant {
+	var grants []PermissionGrant
+	for _, grant := range ep.Grants {
+		if slices.Contains(grant.Actions, action) {
+			grants = append(grants, grant)
+		}
+	}
+	return grants
+}
+
+type EffectivePermissionsService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewEffectivePermissionsService(client *jfroghttpclient.JfrogHttpClient) *EffectivePermissionsService {
+	return &EffectivePermissionsService{client: client}
+}
+
+func (eps *EffectivePermissionsService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return eps.client
+}
+
+// Computes the actions the user or group may perform on the path, from all the permission targets and the groups the
+// user belongs to.
+func (eps *EffectivePermissionsService) Get(params EffectivePermissionsParams) (*EffectivePermissions, error) {
+	if (params.Username == "") == (params.GroupName == "") {
+		return nil, errorutils.CheckErrorf("either a username or a group name is required to compute effective permissions")
+	}
+	if params.RepoKey == "" {
+		return nil, errorutils.CheckErrorf("a repository key is required to compute effective permissions")
+	}
+	principal, err := eps.getPrincipal(params)
+	if err != nil {
+		return nil, err
+	}
+	if principal.admin {
+		return &EffectivePermissions{RepoKey: params.RepoKey, Path: params.Path, Admin: true, Actions: slices.Sorted(slices.Values(allPermissionActions))}, nil
+	}
+
+	repoDetails := RepositoryDetails{}
+	repositoriesService := &RepositoriesService{client: eps.client, ArtDetails: eps.ArtDetails}
+	if err = repositoriesService.Get(params.RepoKey, &repoDetails); err != nil {
+		return nil, err
+	}
+	permissionTargetService := &PermissionTargetService{client: eps.client, ArtDetails: eps.ArtDetails}
+	permissionTargets, err := permissionTargetService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return computeEffectivePermissions(principal, params.RepoKey, repoDetails.GetRepoType(), params.Path, permissionTargets), nil
+}
+
+// The user, or the group, whose permissions are computed.
+type permissionsPrincipal struct {
+	user   string
+	groups []string
+	admin  bool
+}
+
+func (eps *EffectivePermissionsService) getPrincipal(params EffectivePermissionsParams) (*permissionsPrincipal, error) {
+	principal := &permissionsPrincipal{}
+	if params.Username != "" {
+		userService := &UserService{client: eps.client, ArtDetails: eps.ArtDetails}
+		user, err := userService.GetUser(UserParams{UserDetails: User{Name: params.Username}})
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, errorutils.CheckErrorf("user '%s' does not exist", params.Username)
+		}
+		principal.user = params.Username
+		principal.admin = isTrue(user.Admin)
+		if user.Groups != nil {
+			principal.groups = *user.Groups
+		}
+	} else {
+		principal.groups = []string{params.GroupName}
+	}
+
+	groupService := &GroupService{client: eps.client, ArtDetails: eps.ArtDetails}
+	for _, groupName := range principal.groups {
+		if principal.admin {
+			break
+		}
+		group, err := groupService.GetGroup(GroupParams{GroupDetails: Group{Name: groupName}})
+		if err != nil {
+			return nil, err
+		}
+		if group == nil {
+			if params.GroupName != "" {
+				return nil, errorutils.CheckErrorf("group '%s' does not exist", groupName)
+			}
+			continue
+		}
+		principal.admin = isTrue(group.AdminPrivileges)
+	}
+	return principal, nil
+}
+
+func computeEffectivePermissions(principal *permissionsPrincipal, repoKey, rclass, itemPath string, permissionTargets []*PermissionTargetParams) *EffectivePermissions {
+	effectivePermissions := &EffectivePermissions{RepoKey: repoKey, Path: itemPath}
+	for _, permissionTarget := range permissionTargets {
+		section := permissionTarget.Repo
+		if section == nil || section.Actions == nil || !appliesToRepository(section.Repositories, repoKey, rclass) {
+			continue
+		}
+		grants := getPermissionGrants(permissionTarget.Name, section.Actions, principal)
+		if matchesPermissionPatterns(section, itemPath) {
+			effectivePermissions.Grants = append(effectivePermissions.Grants, grants...)
+		} else {
+			effectivePermissions.ExcludedGrants = append(effectivePermissions.ExcludedGrants, grants...)
+		}
+	}
+
+	for _, grant := range effectivePermissions.Grants {
+		for _, action := range grant.Actions {
+			if !slices.Contains(effectivePermissions.Actions, action) {
+				effectivePermissions.Actions = append(effectivePermissions.Actions, action)
+			}
+		}
+	}
+	slices.Sort(effectivePermissions.Actions)
+	return effectivePermissions
+}
+
+func getPermissionGrants(permissionTargetName string, actions *Actions, principal *permissionsPrincipal) []PermissionGrant {
+	var grants []PermissionGrant
+	if userActions := actions.Users[principal.user]; principal.user != "" && len(userActions) > 0 {
+		grants = append(grants, PermissionGrant{PermissionTarget: permissionTargetName, PrincipalType: PermissionPrincipalUser, Principal: principal.user, Actions: expandPermissionActions(userActions)})
+	}
+	for _, group := range principal.groups {
+		if groupActions := actions.Groups[group]; len(groupActions) > 0 {
+			grants = append(grants, PermissionGrant{PermissionTarget: permissionTargetName, PrincipalType: PermissionPrincipalGroup, Principal: group, Actions: expandPermissionActions(groupActions)})
+		}
+	}
+	return grants
+}
+
+// Returns the actions and the actions they imply, sorted by name.
+func expandPermissionActions(actions []string) []string {
+	var expanded []string
+	for _, action := range actions {
+		for _, expandedAction := range append([]string{action}, impliedPermissionActions[action]...) {
+			if !slices.Contains(expanded, expandedAction) {
+				expanded = append(expanded, expandedAction)
+			}
+		}
+	}
+	slices.Sort(expanded)
+	return expanded
+}
+
+func appliesToRepository(repositories []string, repoKey, rclass string) bool {
+	for _, repository := range repositories {
+		if repository == repoKey || repository == "ANY" || (rclass != "" && anyRepositoryOfClass[repository] == rclass) {
+			return true
+		}
+	}
+	return false
+}
+
+// A path matches the permission target if it matches one of its include patterns, "**" by default, and none of its
+// exclude patterns.
+func matchesPermissionPatterns(section *PermissionTargetSection, itemPath string) bool {
+	itemPath = strings.Trim(itemPath, "/")
+	includePatterns := section.IncludePatterns
+	if len(includePatterns) == 0 {
+		includePatterns = []string{"**"}
+	}
+	matches := func(pattern string) bool {
+		return antPatternToRegexp(pattern).MatchString(itemPath)
+	}
+	return slices.ContainsFunc(includePatterns, matches) && !slices.ContainsFunc(section.ExcludePatterns, matches)
+}
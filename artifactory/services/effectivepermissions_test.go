@@ -0,0 +1,84 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeEffectivePermissions(t *testing.T) {
+	permissionTargets := []*PermissionTargetParams{
+		{Name: "deployers", Repo: &PermissionTargetSection{
+			Repositories: []string{"libs-release-local"},
+			Actions:      &Actions{Groups: map[string][]string{"deployers": {"read", "write"}}},
+		}},
+		{Name: "any-local-readers", Repo: &PermissionTargetSection{
+			Repositories: []string{"ANY LOCAL"},
+			Actions:      &Actions{Users: map[string][]string{"alice": {"read"}}, Groups: map[string][]string{"readers": {"read"}}},
+		}},
+		{Name: "any-remote", Repo: &PermissionTargetSection{
+			Repositories: []string{"ANY REMOTE"},
+			Actions:      &Actions{Users: map[string][]string{"alice": {"delete"}}},
+		}},
+		{Name: "snapshots-only", Repo: &PermissionTargetSection{
+			Repositories:    []string{"ANY"},
+			IncludePatterns: []string{"**/*-SNAPSHOT/**"},
+			Actions:         &Actions{Users: map[string][]string{"alice": {"delete"}}},
+		}},
+		{Name: "secrets-excluded", Repo: &PermissionTargetSection{
+			Repositories:    []string{"libs-release-local"},
+			ExcludePatterns: []string{"secrets/**"},
+			Actions:         &Actions{Users: map[string][]string{"alice": {"annotate"}}},
+		}},
+		{Name: "builds-only", Build: &PermissionTargetSection{
+			Repositories: []string{"artifactory-build-info"},
+			Actions:      &Actions{Users: map[string][]string{"alice": {"manage"}}},
+		}},
+	}
+	principal := &permissionsPrincipal{user: "alice", groups: []string{"deployers"}}
+
+	permissions := computeEffectivePermissions(principal, "libs-release-local", "local", "org/acme/lib/1.0/lib-1.0.jar", permissionTargets)
+	assert.Equal(t, []string{"annotate", "read", "write"}, permissions.Actions)
+	assert.True(t, permissions.Can(PermissionActionWrite))
+	assert.False(t, permissions.Can(PermissionActionDelete))
+	assert.Equal(t, []PermissionGrant{{PermissionTarget: "deployers", PrincipalType: PermissionPrincipalGroup, Principal: "deployers", Actions: []string{"annotate", "read", "write"}}},
+		permissions.GrantedBy(PermissionActionWrite))
+	assert.Len(t, permissions.GrantedBy(PermissionActionRead), 2)
+	// The actions implied by write are granted by the permission target which grants write.
+	assert.Equal(t, "deployers", permissions.GrantedBy(PermissionActionAnnotate)[0].PermissionTarget)
+	assert.Equal(t, "snapshots-only", permissions.ExcludedGrants[0].PermissionTarget)
+
+	permissions = computeEffectivePermissions(principal, "libs-release-local", "local", "/secrets/key.pem", permissionTargets)
+	// Annotate is still implied by the write of the deployers, but isn't granted by the permission target which excludes the path.
+	if assert.Len(t, permissions.GrantedBy(PermissionActionAnnotate), 1) {
+		assert.Equal(t, "deployers", permissions.GrantedBy(PermissionActionAnnotate)[0].PermissionTarget)
+	}
+	assert.Len(t, permissions.ExcludedGrants, 2)
+
+	permissions = computeEffectivePermissions(principal, "libs-release-local", "local", "org/acme/lib/1.0-SNAPSHOT/lib.jar", permissionTargets)
+	assert.Equal(t, []string{"annotate", "delete", "read", "write"}, permissions.Actions)
+
+	permissions = computeEffectivePermissions(principal, "maven-remote", "remote", "", permissionTargets)
+	assert.Equal(t, []string{"annotate", "delete", "read", "write"}, permissions.Actions)
+	assert.Equal(t, "any-remote", permissions.GrantedBy(PermissionActionRead)[0].PermissionTarget)
+
+	permissions = computeEffectivePermissions(&permissionsPrincipal{groups: []string{"readers"}}, "libs-release-local", "local", "", permissionTargets)
+	assert.Equal(t, []string{"read"}, permissions.Actions)
+	assert.Equal(t, PermissionGrant{PermissionTarget: "any-local-readers", PrincipalType: PermissionPrincipalGroup, Principal: "readers", Actions: []string{"read"}}, permissions.Grants[0])
+}
+
+func TestExpandPermissionActions(t *testing.T) {
+	assert.Equal(t, []string{"annotate", "read", "write"}, expandPermissionActions([]string{"write"}))
+	assert.Equal(t, []string{"annotate", "delete", "read", "write"}, expandPermissionActions([]string{"read", "delete"}))
+	assert.Equal(t, slices.Sorted(slices.Values(allPermissionActions)), expandPermissionActions([]string{"manage"}))
+	assert.Equal(t, []string{"distribute", "read"}, expandPermissionActions([]string{"read", "distribute", "read"}))
+}
+
+func TestAppliesToRepository(t *testing.T) {
+	assert.True(t, appliesToRepository([]string{"a", "b"}, "b", "local"))
+	assert.True(t, appliesToRepository([]string{"ANY"}, "b", "virtual"))
+	assert.True(t, appliesToRepository([]string{"ANY FEDERATED"}, "b", "federated"))
+	assert.False(t, appliesToRepository([]string{"ANY LOCAL"}, "b", "remote"))
+	assert.False(t, appliesToRepository(nil, "b", "local"))
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/fspatterns"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils/aql"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+// Writes the details of each failed transfer it observes, and passes all the events on to the service's observer.
+type failedTransfersRecorder struct {
+	writer   *content.ContentWriter
+	observer ioutils.TransferObserver
+}
+
+func newFailedTransfersRecorder(observer ioutils.TransferObserver) (*failedTransfersRecorder, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return &failedTransfersRecorder{writer: writer, observer: observer}, nil
+}
+
+func (ftr *failedTransfersRecorder) OnTransferEvent(event ioutils.TransferEvent) {
+	if event.Type == ioutils.TransferFailed {
+		ftr.writer.Write(utils.FailedTransferDetails{
+			SourcePath: event.SourcePath,
+			TargetPath: event.TargetPath,
+			Error:      event.Err.Error(),
+			Attempts:   event.Attempt,
+		})
+	}
+	if ftr.observer != nil {
+		ftr.observer.OnTransferEvent(event)
+	}
+}
+
+// Returns a ContentReader of the recorded FailedTransferDetails. The recorder should be closed before the reader is used.
+func (ftr *failedTransfersRecorder) getReader() *content.ContentReader {
+	return content.NewContentReader(ftr.writer.GetFilePath(), content.DefaultKey)
+}
+
+func (ftr *failedTransfersRecorder) close() error {
+	return ftr.writer.Close()
+}
+
+// Collects the files of uploadParams.FailedTransfers, each with the target it failed to be uploaded to.
+func collectFailedTransfersForUpload(uploadParams UploadParams, progressMgr ioutils.ProgressMgr, vcsCache *clientutils.VcsCache, dataHandlerFunc UploadDataHandlerFunc) error {
+	if uploadParams.Archive != "" {
+		return errorutils.CheckErrorf("failed transfers cannot be uploaded as an archive")
+	}
+	reader := uploadParams.FailedTransfers
+	for failed := new(utils.FailedTransferDetails); reader.NextRecord(failed) == nil; failed = new(utils.FailedTransferDetails) {
+		if failed.SourcePath == "" {
+			log.Warn("The failed upload to", failed.TargetPath, "was of an archive, and cannot be retried by itself.")
+			continue
+		}
+		artifact := clientutils.Artifact{LocalPath: failed.SourcePath, TargetPath: failed.TargetPath}
+		buildProps := uploadParams.BuildProps
+		if uploadParams.SourceFS == nil {
+			var err error
+			if artifact.SymlinkTargetPath, err = fspatterns.GetFileSymlinkPath(failed.SourcePath); err != nil {
+				return err
+			}
+			if uploadParams.IsAddVcsProps() {
+				vcsProps, err := getVcsProps(failed.SourcePath, vcsCache)
+				if err != nil {
+					return err
+				}
+				buildProps += vcsProps
+			}
+		}
+		props, err := createProperties(artifact, uploadParams)
+		if err != nil {
+			return err
+		}
+		incGeneralProgressTotal(progressMgr, uploadParams)
+		dataHandlerFunc(UploadData{Artifact: artifact, TargetProps: props, BuildProps: buildProps})
+	}
+	return reader.GetError()
+}
+
+// The maximum number of failed downloads which are searched by a single query.
+const failedDownloadsBatchSize = 500
+
+// Replaces each of the params which have FailedTransfers with params that download its files to the local paths they
+// failed to be downloaded to. The files are searched in batches, by one query for each repository and local directory.
+// Files which were to be saved under another name are downloaded by params of their own, and records without a local
+// path are skipped. The other params are returned as is.
+func expandFailedDownloads(downloadParamsSlice []DownloadParams) ([]DownloadParams, error) {
+	var expanded []DownloadParams
+	for _, downloadParams := range downloadParamsSlice {
+		if downloadParams.FailedTransfers == nil {
+			expanded = append(expanded, downloadParams)
+			continue
+		}
+		var batches []*failedDownloadsBatch
+		openBatches := map[string]*failedDownloadsBatch{}
+		reader := downloadParams.FailedTransfers
+		for failed := new(utils.FailedTransferDetails); reader.NextRecord(failed) == nil; failed = new(utils.FailedTransferDetails) {
+			repo, itemPath, _ := strings.Cut(failed.SourcePath, "/")
+			itemDir, itemName := path.Split(itemPath)
+			if failed.TargetPath == "" || repo == "" || itemName == "" {
+				log.Warn("The failed download of '" + failed.SourcePath + "' to '" + failed.TargetPath + "' has no source or local path, and cannot be retried.")
+				continue
+			}
+			targetDir, targetName := filepath.Split(failed.TargetPath)
+			if targetName != itemName {
+				fileParams := newFailedDownloadParams(downloadParams)
+				fileParams.CommonParams = &utils.CommonParams{Pattern: failed.SourcePath, Target: failed.TargetPath}
+				expanded = append(expanded, fileParams)
+				continue
+			}
+			key := repo + "\x00" + targetDir
+			batch := openBatches[key]
+			if batch == nil {
+				batch = &failedDownloadsBatch{repo: repo, targetDir: targetDir}
+				openBatches[key] = batch
+				batches = append(batches, batch)
+			}
+			itemDir = strings.TrimSuffix(itemDir, "/")
+			if itemDir == "" {
+				itemDir = "."
+			}
+			batch.items = append(batch.items, aql.Criteria{aql.Field("path").Equal(itemDir), aql.Field("name").Equal(itemName)})
+			if len(batch.items) == failedDownloadsBatchSize {
+				delete(openBatches, key)
+			}
+		}
+		if err := reader.GetError(); err != nil {
+			return nil, err
+		}
+		for _, batch := range batches {
+			batchParams := newFailedDownloadParams(downloadParams)
+			batchParams.CommonParams = &utils.CommonParams{
+				Aql:    utils.Aql{ItemsFind: aql.Criteria{aql.Field("repo").Equal(batch.repo), aql.Or(batch.items...)}.String()},
+				Target: batch.targetDir,
+			}
+			expanded = append(expanded, batchParams)
+		}
+	}
+	return expanded, nil
+}
+
+// Failed downloads from a repository to a local directory. The directory ends with a separator.
+type failedDownloadsBatch struct {
+	repo      string
+	targetDir string
+	items     []aql.Object
+}
+
+func newFailedDownloadParams(downloadParams DownloadParams) DownloadParams {
+	downloadParams.Flat = true
+	downloadParams.FailedTransfers = nil
+	return downloadParams
+}
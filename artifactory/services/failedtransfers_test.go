@@ -0,0 +1,121 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailedTransfersRecorder(t *testing.T) {
+	observer := &testTransferObserver{}
+	recorder, err := newFailedTransfersRecorder(observer)
+	assert.NoError(t, err)
+
+	failed := newFileTransferEvents(recorder, ioutils.UploadOperation, "a.bin", "repo/a.bin")
+	failed.progressMgr(nil).NewProgressReader(1, "Uploading", "repo/a.bin")
+	failed.progressMgr(nil).NewProgressReader(1, "Uploading", "repo/a.bin")
+	failed.finished(false, errors.New("connection reset"))
+	newFileTransferEvents(recorder, ioutils.UploadOperation, "b.bin", "repo/b.bin").finished(true, nil)
+	assert.NoError(t, recorder.close())
+	// All the events are passed on to the observer.
+	assert.Len(t, observer.events, 3)
+
+	reader := recorder.getReader()
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	var records []utils.FailedTransferDetails
+	for record := new(utils.FailedTransferDetails); reader.NextRecord(record) == nil; record = new(utils.FailedTransferDetails) {
+		records = append(records, *record)
+	}
+	assert.NoError(t, reader.GetError())
+	assert.Equal(t, []utils.FailedTransferDetails{{SourcePath: "a.bin", TargetPath: "repo/a.bin", Error: "connection reset", Attempts: 2}}, records)
+}
+
+func TestCollectFailedTransfersForUpload(t *testing.T) {
+	tempDir := t.TempDir()
+	localPath := filepath.Join(tempDir, "a.txt")
+	assert.NoError(t, os.WriteFile(localPath, []byte("a"), 0600))
+	manifest := createTestFailedTransfersManifest(t,
+		utils.FailedTransferDetails{SourcePath: localPath, TargetPath: "repo/dir/a.txt", Error: "failure", Attempts: 3},
+		utils.FailedTransferDetails{TargetPath: "repo/archive.zip", Error: "failure"},
+	)
+	defer func() {
+		assert.NoError(t, manifest.Close())
+	}()
+
+	uploadParams := NewUploadParams()
+	uploadParams.Pattern = "ignored/*"
+	uploadParams.Target = "ignored/"
+	uploadParams.FailedTransfers = manifest
+	uploadParams.TargetProps = utils.NewProperties()
+	uploadParams.TargetProps.AddProperty("key", "value")
+	var collected []UploadData
+	assert.NoError(t, CollectFilesForUpload(uploadParams, nil, nil, func(data UploadData) {
+		collected = append(collected, data)
+	}))
+	if assert.Len(t, collected, 1) {
+		assert.Equal(t, localPath, collected[0].Artifact.LocalPath)
+		assert.Equal(t, "repo/dir/a.txt", collected[0].Artifact.TargetPath)
+		assert.Equal(t, "key=value", collected[0].TargetProps.ToEncodedString(false))
+	}
+
+	manifest.Reset()
+	uploadParams.Archive = ZipArchive
+	assert.Error(t, CollectFilesForUpload(uploadParams, nil, nil, func(UploadData) {}))
+}
+
+func TestExpandFailedDownloads(t *testing.T) {
+	manifest := createTestFailedTransfersManifest(t,
+		utils.FailedTransferDetails{SourcePath: "repo/dir/a.txt", TargetPath: filepath.Join("out", "a.txt")},
+		utils.FailedTransferDetails{SourcePath: "repo/b.txt", TargetPath: filepath.Join("out", "renamed.txt")},
+		utils.FailedTransferDetails{SourcePath: "repo/c.txt", TargetPath: filepath.Join("out", "c.txt")},
+		utils.FailedTransferDetails{SourcePath: "other-repo/d.txt", TargetPath: filepath.Join("out", "d.txt")},
+		// Failed before its local path was set.
+		utils.FailedTransferDetails{SourcePath: "repo/e.txt"},
+	)
+	defer func() {
+		assert.NoError(t, manifest.Close())
+	}()
+	retryParams := NewDownloadParams()
+	retryParams.Pattern = "ignored/*"
+	retryParams.SplitCount = 3
+	retryParams.FailedTransfers = manifest
+	otherParams := NewDownloadParams()
+	otherParams.Pattern = "repo/other/*"
+
+	expanded, err := expandFailedDownloads([]DownloadParams{retryParams, otherParams})
+	assert.NoError(t, err)
+	if assert.Len(t, expanded, 4) {
+		// Renamed files are downloaded by themselves.
+		assert.Equal(t, "repo/b.txt", expanded[0].Pattern)
+		assert.Equal(t, filepath.Join("out", "renamed.txt"), expanded[0].Target)
+		assert.True(t, expanded[0].Flat)
+		// The other files are searched by a single query for each repository and local directory.
+		assert.Equal(t, `{"repo":"repo","$or":[{"path":"dir","name":"a.txt"},{"path":".","name":"c.txt"}]}`, expanded[1].Aql.ItemsFind)
+		assert.Equal(t, "out"+string(filepath.Separator), expanded[1].Target)
+		assert.True(t, expanded[1].Flat)
+		assert.Equal(t, 3, expanded[1].SplitCount)
+		assert.Nil(t, expanded[1].FailedTransfers)
+		assert.Equal(t, `{"repo":"other-repo","$or":[{"path":".","name":"d.txt"}]}`, expanded[2].Aql.ItemsFind)
+		assert.Equal(t, "repo/other/*", expanded[3].Pattern)
+	}
+	// The params of the failed transfers don't share the original params.
+	assert.Equal(t, "ignored/*", retryParams.Pattern)
+}
+
+func createTestFailedTransfersManifest(t *testing.T, records ...utils.FailedTransferDetails) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, record := range records {
+		writer.Write(record)
+	}
+	assert.NoError(t, writer.Close())
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
@@ -1,16 +1,74 @@
 package services
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
 	"github.com/madotis/jfrog-client-go/auth"
 	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
 	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/httputils"
 	"github.com/madotis/jfrog-client-go/utils/log"
 )
 
+const (
+	// The status of a federation member which received all the events of the repository.
+	FederationMemberSynchronized = "SYNCHRONIZED"
+
+	defaultFullSyncTimeout         = 30 * time.Minute
+	defaultFullSyncPollingInterval = 10 * time.Second
+)
+
+// The synchronization status of a federated repository, with the status of each of its other members.
+type FederationStatus struct {
+	LocalKey          string                      `json:"localKey,omitempty"`
+	BinariesTasksInfo FederationBinariesTasksInfo `json:"binariesTasksInfo,omitempty"`
+	Mirrors           []FederationMirrorStatus    `json:"mirrors,omitempty"`
+}
+
+type FederationBinariesTasksInfo struct {
+	InProgressTasks int `json:"inProgressTasks,omitempty"`
+	FailingTasks    int `json:"failingTasks,omitempty"`
+}
+
+type FederationMirrorStatus struct {
+	LocalRepoKey  string `json:"localRepoKey,omitempty"`
+	RemoteUrl     string `json:"remoteUrl,omitempty"`
+	RemoteRepoKey string `json:"remoteRepoKey,omitempty"`
+	Status        string `json:"status,omitempty"`
+	// The time of the last event which was sent to the member, in milliseconds since the epoch.
+	LastEventTime int64 `json:"lastEventTime,omitempty"`
+	// How far behind the repository the member is, in milliseconds.
+	LagInMS int64 `json:"lagInMS,omitempty"`
+}
+
+func (fms *FederationMirrorStatus) IsSynchronized() bool {
+	return fms.Status == FederationMemberSynchronized
+}
+
+func (fms *FederationMirrorStatus) GetLag() time.Duration {
+	return time.Duration(fms.LagInMS) * time.Millisecond
+}
+
+type FederatedFullSyncWaitParams struct {
+	RepoKey string
+	// Wait for the full sync of this member only. All the members by default.
+	MirrorUrl string
+	// 30 minutes by default.
+	Timeout time.Duration
+	// 10 seconds by default.
+	PollingInterval time.Duration
+}
+
+func NewFederatedFullSyncWaitParams() FederatedFullSyncWaitParams {
+	return FederatedFullSyncWaitParams{}
+}
+
 type FederationService struct {
 	client     *jfroghttpclient.JfrogHttpClient
 	ArtDetails auth.ServiceDetails
@@ -74,3 +132,180 @@ func (fs *FederationService) TriggerFederatedFullSyncMirror(repoKey string, mirr
 	log.Info("Done triggering federated repository synchronisation.")
 	return nil
 }
+
+// Adds members to the federated repository, or changes whether existing members are enabled.
+// The other members, and the rest of the repository's configuration, are kept.
+func (fs *FederationService) AddMembers(repoKey string, members ...FederatedRepositoryMember) error {
+	currentMembers, err := fs.getMembers(repoKey)
+	if err != nil {
+		return err
+	}
+	updatedMembers, changed := addFederationMembers(currentMembers, members)
+	if !changed {
+		log.Info("The members are already members of the federated repository '" + repoKey + "'.")
+		return nil
+	}
+	return fs.updateMembers(repoKey, updatedMembers)
+}
+
+// Removes members from the federated repository, by their URLs. Fails if any of the URLs isn't of a member.
+func (fs *FederationService) RemoveMembers(repoKey string, memberUrls ...string) error {
+	currentMembers, err := fs.getMembers(repoKey)
+	if err != nil {
+		return err
+	}
+	updatedMembers, err := removeFederationMembers(repoKey, currentMembers, memberUrls)
+	if err != nil {
+		return err
+	}
+	return fs.updateMembers(repoKey, updatedMembers)
+}
+
+// The params which update the members of a federated repository. The members are sent even if there are none,
+// so that removing the last member clears them.
+type federationMembersParams struct {
+	Rclass  string                      `json:"rclass"`
+	Members []FederatedRepositoryMember `json:"members"`
+}
+
+func (fs *FederationService) getRepositoriesService() *RepositoriesService {
+	return &RepositoriesService{client: fs.client, ArtDetails: fs.ArtDetails}
+}
+
+func (fs *FederationService) getMembers(repoKey string) ([]FederatedRepositoryMember, error) {
+	current := &federationMembersParams{}
+	if err := fs.getRepositoriesService().Get(repoKey, current); err != nil {
+		return nil, err
+	}
+	if current.Rclass != FederatedRepositoryRepoType {
+		return nil, errorutils.CheckErrorf("'%s' isn't a federated repository", repoKey)
+	}
+	return current.Members, nil
+}
+
+func (fs *FederationService) updateMembers(repoKey string, members []FederatedRepositoryMember) error {
+	if members == nil {
+		members = []FederatedRepositoryMember{}
+	}
+	return fs.getRepositoriesService().Update(federationMembersParams{Rclass: FederatedRepositoryRepoType, Members: members}, repoKey)
+}
+
+func addFederationMembers(current, added []FederatedRepositoryMember) ([]FederatedRepositoryMember, bool) {
+	updated := append([]FederatedRepositoryMember{}, current...)
+	changed := false
+	for _, member := range added {
+		index := findFederationMember(updated, member.Url)
+		switch {
+		case index < 0:
+			updated = append(updated, member)
+			changed = true
+		case member.Enabled != nil && !equalBoolPointers(updated[index].Enabled, member.Enabled):
+			updated[index].Enabled = member.Enabled
+			changed = true
+		}
+	}
+	return updated, changed
+}
+
+func removeFederationMembers(repoKey string, current []FederatedRepositoryMember, memberUrls []string) ([]FederatedRepositoryMember, error) {
+	var missing []string
+	removed := map[int]bool{}
+	for _, memberUrl := range memberUrls {
+		index := findFederationMember(current, memberUrl)
+		if index < 0 {
+			missing = append(missing, memberUrl)
+			continue
+		}
+		removed[index] = true
+	}
+	if len(missing) > 0 {
+		return nil, errorutils.CheckErrorf("these URLs aren't of members of the federated repository '%s': %s", repoKey, strings.Join(missing, ", "))
+	}
+	var updated []FederatedRepositoryMember
+	for i, member := range current {
+		if !removed[i] {
+			updated = append(updated, member)
+		}
+	}
+	return updated, nil
+}
+
+// Returns the index of the member with the URL, ignoring trailing slashes, or -1 if there is no such member.
+func findFederationMember(members []FederatedRepositoryMember, memberUrl string) int {
+	for i, member := range members {
+		if strings.TrimSuffix(member.Url, "/") == strings.TrimSuffix(memberUrl, "/") {
+			return i
+		}
+	}
+	return -1
+}
+
+func equalBoolPointers(a, b *bool) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func (fs *FederationService) GetStatus(repoKey string) (*FederationStatus, error) {
+	httpClientsDetails := fs.ArtDetails.CreateHttpClientDetails()
+	resp, body, _, err := fs.client.SendGet(fs.ArtDetails.GetUrl()+"api/federation/status/repo/"+url.PathEscape(repoKey), true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	status := &FederationStatus{}
+	return status, errorutils.CheckError(json.Unmarshal(body, status))
+}
+
+// Waits until the members of the federated repository are synchronized, after a full sync was triggered,
+// and returns their status. The first status is read after one polling interval, so that Artifactory starts the sync.
+func (fs *FederationService) WaitForFullSync(params FederatedFullSyncWaitParams) (*FederationStatus, error) {
+	timeout, pollingInterval := params.Timeout, params.PollingInterval
+	if timeout <= 0 {
+		timeout = defaultFullSyncTimeout
+	}
+	if pollingInterval <= 0 {
+		pollingInterval = defaultFullSyncPollingInterval
+	}
+	var status *FederationStatus
+	pollingAction := func() (shouldStop bool, responseBody []byte, err error) {
+		status, err = fs.GetStatus(params.RepoKey)
+		if err != nil {
+			return true, nil, err
+		}
+		complete, err := isFullSyncComplete(status, params.MirrorUrl)
+		return complete || err != nil, nil, err
+	}
+	pollingExecutor := &httputils.PollingExecutor{
+		Timeout:         timeout,
+		PollingInterval: pollingInterval,
+		PollingAction:   pollingAction,
+		MsgPrefix:       fmt.Sprintf("Waiting for the full sync of the federated repository '%s'...", params.RepoKey),
+	}
+	// Right after the sync is triggered, the status may still be the status from before it.
+	time.Sleep(pollingInterval)
+	_, err := pollingExecutor.Execute()
+	return status, err
+}
+
+// The full sync is complete when the members are synchronized, and no binaries are being transferred.
+func isFullSyncComplete(status *FederationStatus, mirrorUrl string) (bool, error) {
+	found := false
+	for _, mirror := range status.Mirrors {
+		if mirrorUrl != "" && strings.TrimSuffix(mirror.RemoteUrl, "/") != strings.TrimSuffix(mirrorUrl, "/") {
+			continue
+		}
+		found = true
+		if !mirror.IsSynchronized() {
+			return false, nil
+		}
+	}
+	if !found {
+		if mirrorUrl != "" {
+			return false, errorutils.CheckErrorf("'%s' isn't a member of the federated repository '%s'", mirrorUrl, status.LocalKey)
+		}
+		return false, errorutils.CheckErrorf("the federated repository '%s' has no other members", status.LocalKey)
+	}
+	return status.BinariesTasksInfo.InProgressTasks == 0, nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddFederationMembers(t *testing.T) {
+	enabled, disabled := true, false
+	current := []FederatedRepositoryMember{{Url: "https://a.example.com/artifactory/fed", Enabled: &enabled}}
+
+	updated, changed := addFederationMembers(current, []FederatedRepositoryMember{{Url: "https://a.example.com/artifactory/fed/"}})
+	assert.False(t, changed)
+	assert.Equal(t, current, updated)
+
+	updated, changed = addFederationMembers(current, []FederatedRepositoryMember{
+		{Url: "https://a.example.com/artifactory/fed", Enabled: &disabled},
+		{Url: "https://b.example.com/artifactory/fed", Enabled: &enabled},
+	})
+	assert.True(t, changed)
+	assert.Equal(t, []FederatedRepositoryMember{
+		{Url: "https://a.example.com/artifactory/fed", Enabled: &disabled},
+		{Url: "https://b.example.com/artifactory/fed", Enabled: &enabled},
+	}, updated)
+	// The current members aren't changed.
+	assert.True(t, *current[0].Enabled)
+}
+
+func TestRemoveFederationMembers(t *testing.T) {
+	current := []FederatedRepositoryMember{{Url: "https://a.example.com/artifactory/fed"}, {Url: "https://b.example.com/artifactory/fed"}}
+	updated, err := removeFederationMembers("fed", current, []string{"https://a.example.com/artifactory/fed/"})
+	assert.NoError(t, err)
+	assert.Equal(t, []FederatedRepositoryMember{{Url: "https://b.example.com/artifactory/fed"}}, updated)
+
+	_, err = removeFederationMembers("fed", current, []string{"https://b.example.com/artifactory/fed", "https://c.example.com/artifactory/fed"})
+	assert.EqualError(t, err, "these URLs aren't of members of the federated repository 'fed': https://c.example.com/artifactory/fed")
+}
+
+func TestIsFullSyncComplete(t *testing.T) {
+	status := &FederationStatus{LocalKey: "fed", Mirrors: []FederationMirrorStatus{
+		{RemoteUrl: "https://a.example.com/artifactory/fed", Status: FederationMemberSynchronized},
+		{RemoteUrl: "https://b.example.com/artifactory/fed", Status: "SYNCHRONIZING", LagInMS: 1500},
+	}}
+	complete, err := isFullSyncComplete(status, "")
+	assert.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, "1.5s", status.Mirrors[1].GetLag().String())
+
+	complete, err = isFullSyncComplete(status, "https://a.example.com/artifactory/fed")
+	assert.NoError(t, err)
+	assert.True(t, complete)
+
+	// Binaries which are still being transferred.
+	status.BinariesTasksInfo.InProgressTasks = 2
+	complete, err = isFullSyncComplete(status, "https://a.example.com/artifactory/fed")
+	assert.NoError(t, err)
+	assert.False(t, complete)
+
+	_, err = isFullSyncComplete(status, "https://c.example.com/artifactory/fed")
+	assert.Error(t, err)
+	_, err = isFullSyncComplete(&FederationStatus{LocalKey: "fed"}, "")
+	assert.Error(t, err)
+}
@@ -23,13 +23,13 @@ func NewGroupParams() GroupParams {
 }
 
 type Group struct {
-	Name            string   `json:"name,omitempty"`
-	Description     string   `json:"description,omitempty"`
-	AutoJoin        *bool    `json:"autoJoin,omitempty"`
-	AdminPrivileges *bool    `json:"adminPrivileges,omitempty"`
-	Realm           string   `json:"realm,omitempty"`
-	RealmAttributes string   `json:"realmAttributes,omitempty"`
-	UsersNames      []string `json:"userNames,omitempty"`
+	Name            string   `json:"name,omitempty" csv:"name,omitempty"`
+	Description     string   `json:"description,omitempty" csv:"description,omitempty"`
+	AutoJoin        *bool    `json:"autoJoin,omitempty" csv:"autoJoin,omitempty"`
+	AdminPrivileges *bool    `json:"adminPrivileges,omitempty" csv:"adminPrivileges,omitempty"`
+	Realm           string   `json:"realm,omitempty" csv:"realm,omitempty"`
+	RealmAttributes string   `json:"realmAttributes,omitempty" csv:"realmAttributes,omitempty"`
+	UsersNames      []string `json:"userNames,omitempty" csv:"userNames,omitempty"`
 }
 
 type groupName struct {
@@ -0,0 +1,527 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+type IdentitySyncActionType string
+
+const (
+	IdentitySyncCreateGroup IdentitySyncActionType = "create-group"
+	IdentitySyncUpdateGroup IdentitySyncActionType = "update-group"
+	IdentitySyncCreateUser  IdentitySyncActionType = "create-user"
+	IdentitySyncUpdateUser  IdentitySyncActionType = "update-user"
+	IdentitySyncDeleteUser  IdentitySyncActionType = "delete-user"
+	IdentitySyncDeleteGroup IdentitySyncActionType = "delete-group"
+)
+
+// The actions are applied in phases, so that groups exist before users join them, and users leave groups before the
+// groups are deleted. The actions of each phase are applied concurrently.
+var identitySyncPhases = [][]IdentitySyncActionType{
+	{IdentitySyncCreateGroup, IdentitySyncUpdateGroup},
+	{IdentitySyncCreateUser, IdentitySyncUpdateUser},
+	{IdentitySyncDeleteUser},
+	{IdentitySyncDeleteGroup},
+}
+
+// Users which are never changed or deleted by a sync, in addition to IdentitiesSyncParams.ProtectedUsers.
+var defaultProtectedUsers = []string{"admin", "anonymous"}
+
+// Groups which are never changed or deleted by a sync, in addition to IdentitiesSyncParams.ProtectedGroups.
+// "readers" is Artifactory's default group, which new users join automatically.
+var defaultProtectedGroups = []string{"readers"}
+
+// Fields which are either write-only or maintained by Artifactory, and are therefore not compared.
+var unsyncedUserFields = []string{"password", "groups", "lastLoggedIn", "shouldInvite"}
+
+// The desired users, groups and memberships. The groups of a user are the union of its Groups and of the groups which
+// list it in their UsersNames. Groups which are referenced only by memberships are created with their name only.
+type Identities struct {
+	Users  []User  `json:"users,omitempty"`
+	Groups []Group `json:"groups,omitempty"`
+}
+
+// Provides the desired identities, for example from an HR system.
+type IdentitiesProvider func() (*Identities, error)
+
+// Reads the identities from a JSON file, in the structure of Identities.
+func IdentitiesFromJsonFile(filePath string) IdentitiesProvider {
+	return func() (*Identities, error) {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		identities := &Identities{}
+		return identities, errorutils.CheckError(json.Unmarshal(content, identities))
+	}
+}
+
+// Reads the users, and optionally the groups, from CSV files. The columns are named by the csv tags of User and Group.
+// Multiple groups of a user, or users of a group, are separated by commas.
+func IdentitiesFromCsvFiles(usersFilePath, groupsFilePath string) IdentitiesProvider {
+	return func() (identities *Identities, err error) {
+		identities = &Identities{}
+		if identities.Users, err = readCsvFile[User](usersFilePath); err != nil {
+			return nil, err
+		}
+		if groupsFilePath != "" {
+			if identities.Groups, err = readCsvFile[Group](groupsFilePath); err != nil {
+				return nil, err
+			}
+		}
+		return identities, nil
+	}
+}
+
+type IdentitiesSyncParams struct {
+	Provider IdentitiesProvider
+	// Delete users and groups which the provider doesn't list.
+	DeleteUnlisted bool
+	// Wildcard patterns of users which are never created, changed or deleted. "admin" and "anonymous" are always protected.
+	ProtectedUsers []string
+	// Wildcard patterns of groups which are never created, changed or deleted, and whose memberships aren't changed.
+	// "readers" is always protected.
+	ProtectedGroups []string
+}
+
+func NewIdentitiesSyncParams() IdentitiesSyncParams {
+	return IdentitiesSyncParams{}
+}
+
+type IdentitySyncAction struct {
+	Action IdentitySyncActionType
+	Name   string
+	// The fields which are changed by updates. "groups" for users whose memberships change.
+	Changes []string
+	Error   error
+	// The details which are sent to Artifactory.
+	user  *User
+	group *Group
+}
+
+type IdentitiesSyncReport struct {
+	// In a dry run, the actions are planned but not applied.
+	DryRun  bool
+	Actions []IdentitySyncAction
+}
+
+func (isr *IdentitiesSyncReport) HasFailures() bool {
+	return slices.ContainsFunc(isr.Actions, func(action IdentitySyncAction) bool {
+		return action.Error != nil
+	})
+}
+
+type IdentitiesSyncService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+	DryRun     bool
+	Threads    int
+}
+
+func NewIdentitiesSyncService(client *jfroghttpclient.JfrogHttpClient) *IdentitiesSyncService {
+	return &IdentitiesSyncService{client: client}
+}
+
+func (iss *IdentitiesSyncService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return iss.client
+}
+
+// Makes the users, groups and memberships in Artifactory match the provided ones. Failing actions don't stop the sync,
+// and are reported with their errors.
+func (iss *IdentitiesSyncService) Sync(params IdentitiesSyncParams) (*IdentitiesSyncReport, error) {
+	if params.Provider == nil {
+		return nil, errorutils.CheckErrorf("an identities provider is required to sync users and groups")
+	}
+	desired, err := params.Provider()
+	if err != nil {
+		return nil, err
+	}
+	current, err := iss.getCurrentIdentities(desired)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := planIdentitiesSync(desired, current, params)
+	if err != nil {
+		return nil, err
+	}
+	report := &IdentitiesSyncReport{DryRun: iss.DryRun, Actions: actions}
+	if iss.DryRun {
+		for _, action := range actions {
+			log.Info("[Dry run]", action.Action, action.Name, strings.Join(action.Changes, ", "))
+		}
+		return report, nil
+	}
+	iss.apply(report.Actions)
+	return report, nil
+}
+
+// The users and groups in Artifactory. Only the desired ones are fetched with their details.
+type currentIdentities struct {
+	users  map[string]*User
+	groups map[string]*Group
+}
+
+func (iss *IdentitiesSyncService) getCurrentIdentities(desired *Identities) (*currentIdentities, error) {
+	userService := &UserService{client: iss.client, ArtDetails: iss.ArtDetails}
+	groupService := &GroupService{client: iss.client, ArtDetails: iss.ArtDetails}
+	users, err := userService.GetAllUsers()
+	if err != nil {
+		return nil, err
+	}
+	groupNames, err := groupService.GetAllGroups()
+	if err != nil {
+		return nil, err
+	}
+	current := &currentIdentities{users: map[string]*User{}, groups: map[string]*Group{}}
+	for _, user := range users {
+		current.users[user.Name] = user
+	}
+	for _, groupName := range *groupNames {
+		current.groups[groupName] = &Group{Name: groupName}
+	}
+
+	// Each task writes to its own index, and the results are put in the maps once all of them are done.
+	var tasks []func() error
+	userDetails := make([]*User, len(desired.Users))
+	for i, user := range desired.Users {
+		if _, exists := current.users[user.Name]; exists {
+			tasks = append(tasks, func() (err error) {
+				userDetails[i], err = userService.GetUser(UserParams{UserDetails: User{Name: user.Name}})
+				return
+			})
+		}
+	}
+	groupDetails := make([]*Group, len(desired.Groups))
+	for i, group := range desired.Groups {
+		if _, exists := current.groups[group.Name]; exists {
+			tasks = append(tasks, func() (err error) {
+				groupDetails[i], err = groupService.GetGroup(GroupParams{GroupDetails: Group{Name: group.Name}})
+				return
+			})
+		}
+	}
+	if err = runIdentitiesTasks(iss.Threads, tasks); err != nil {
+		return nil, err
+	}
+	for _, user := range userDetails {
+		if user != nil {
+			current.users[user.Name] = user
+		}
+	}
+	for _, group := range groupDetails {
+		if group != nil {
+			current.groups[group.Name] = group
+		}
+	}
+	return current, nil
+}
+
+func (iss *IdentitiesSyncService) apply(actions []IdentitySyncAction) {
+	for _, phase := range identitySyncPhases {
+		var tasks []func() error
+		for i := range actions {
+			if !slices.Contains(phase, actions[i].Action) {
+				continue
+			}
+			action := &actions[i]
+			tasks = append(tasks, func() error {
+				if action.Error = iss.perform(action); action.Error != nil {
+					log.Error("Failed to", action.Action, action.Name+":", action.Error.Error())
+				} else {
+					log.Info("Done", action.Action, action.Name)
+				}
+				return nil
+			})
+		}
+		// The errors are reported on the actions.
+		_ = runIdentitiesTasks(iss.Threads, tasks)
+	}
+}
+
+func (iss *IdentitiesSyncService) perform(action *IdentitySyncAction) error {
+	userService := &UserService{client: iss.client, ArtDetails: iss.ArtDetails}
+	groupService := &GroupService{client: iss.client, ArtDetails: iss.ArtDetails}
+	switch action.Action {
+	case IdentitySyncCreateGroup:
+		return groupService.CreateGroup(GroupParams{GroupDetails: *action.group, ReplaceIfExists: true})
+	case IdentitySyncUpdateGroup:
+		return groupService.UpdateGroup(GroupParams{GroupDetails: *action.group})
+	case IdentitySyncCreateUser:
+		return userService.CreateUser(UserParams{UserDetails: *action.user, ReplaceIfExists: true})
+	case IdentitySyncUpdateUser:
+		return userService.UpdateUser(UserParams{UserDetails: *action.user})
+	case IdentitySyncDeleteUser:
+		return userService.DeleteUser(action.Name)
+	case IdentitySyncDeleteGroup:
+		return groupService.DeleteGroup(action.Name)
+	}
+	return errorutils.CheckErrorf("unknown identity sync action '%s'", action.Action)
+}
+
+// Runs the tasks concurrently, and returns the first error.
+func runIdentitiesTasks(threads int, tasks []func() error) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	if threads <= 0 {
+		threads = 3
+	}
+	runner := parallel.NewRunner(threads, uint(len(tasks)), false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	go func() {
+		defer runner.Done()
+		for _, task := range tasks {
+			_, _ = runner.AddTaskWithError(func(int) error { return task() }, errorsQueue.AddError)
+		}
+	}()
+	runner.Run()
+	return errorsQueue.GetError()
+}
+
+func planIdentitiesSync(desired *Identities, current *currentIdentities, params IdentitiesSyncParams) ([]IdentitySyncAction, error) {
+	protectedUsers := append(slices.Clone(defaultProtectedUsers), params.ProtectedUsers...)
+	protectedGroups := append(slices.Clone(defaultProtectedGroups), params.ProtectedGroups...)
+	isProtectedGroup := func(name string) bool {
+		return matchesWildcards(protectedGroups, name)
+	}
+
+	// Collect the desired groups, including the ones which are referenced by memberships only, and the memberships.
+	desiredGroups := map[string]Group{}
+	memberships := map[string][]string{}
+	desiredUsers := map[string]bool{}
+	for _, user := range desired.Users {
+		desiredUsers[user.Name] = true
+		if user.Groups != nil {
+			for _, groupName := range *user.Groups {
+				desiredGroups[groupName] = Group{Name: groupName}
+				memberships[user.Name] = appendIfMissing(memberships[user.Name], groupName)
+			}
+		}
+	}
+	for _, group := range desired.Groups {
+		for _, userName := range group.UsersNames {
+			if !desiredUsers[userName] {
+				return nil, errorutils.CheckErrorf("group '%s' lists user '%s', which is not in the users list", group.Name, userName)
+			}
+			memberships[userName] = appendIfMissing(memberships[userName], group.Name)
+		}
+		group.UsersNames = nil
+		desiredGroups[group.Name] = group
+	}
+
+	var actions []IdentitySyncAction
+	for _, groupName := range sortedKeys(desiredGroups) {
+		if isProtectedGroup(groupName) {
+			continue
+		}
+		group := desiredGroups[groupName]
+		currentGroup, exists := current.groups[groupName]
+		if !exists {
+			actions = append(actions, IdentitySyncAction{Action: IdentitySyncCreateGroup, Name: groupName, group: &group})
+			continue
+		}
+		changes, err := diffIdentityFields(group, currentGroup, "name", "userNames")
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			actions = append(actions, IdentitySyncAction{Action: IdentitySyncUpdateGroup, Name: groupName, Changes: changes, group: &group})
+		}
+	}
+
+	for _, user := range desired.Users {
+		if matchesWildcards(protectedUsers, user.Name) {
+			continue
+		}
+		// Memberships in protected groups, and in groups which aren't desired, are managed elsewhere and are kept.
+		var groups []string
+		for _, groupName := range memberships[user.Name] {
+			if !isProtectedGroup(groupName) {
+				groups = append(groups, groupName)
+			}
+		}
+		currentUser, exists := current.users[user.Name]
+		if !exists {
+			sort.Strings(groups)
+			user.Groups = &groups
+			actions = append(actions, IdentitySyncAction{Action: IdentitySyncCreateUser, Name: user.Name, user: &user})
+			continue
+		}
+		changes, err := diffIdentityFields(user, currentUser, unsyncedUserFields...)
+		if err != nil {
+			return nil, err
+		}
+		if currentUser.Groups != nil {
+			for _, groupName := range *currentUser.Groups {
+				if _, isDesired := desiredGroups[groupName]; !isDesired || isProtectedGroup(groupName) {
+					groups = appendIfMissing(groups, groupName)
+				}
+			}
+		}
+		sort.Strings(groups)
+		var currentGroups []string
+		if currentUser.Groups != nil {
+			currentGroups = slices.Sorted(slices.Values(*currentUser.Groups))
+		}
+		if !slices.Equal(groups, currentGroups) {
+			changes = append(changes, "groups")
+		}
+		if len(changes) > 0 {
+			user.Password = ""
+			if groups == nil {
+				groups = []string{}
+			}
+			user.Groups = &groups
+			actions = append(actions, IdentitySyncAction{Action: IdentitySyncUpdateUser, Name: user.Name, Changes: changes, user: &user})
+		}
+	}
+
+	if params.DeleteUnlisted {
+		for _, userName := range sortedKeys(current.users) {
+			if !desiredUsers[userName] && !matchesWildcards(protectedUsers, userName) {
+				actions = append(actions, IdentitySyncAction{Action: IdentitySyncDeleteUser, Name: userName})
+			}
+		}
+		for _, groupName := range sortedKeys(current.groups) {
+			if _, isDesired := desiredGroups[groupName]; !isDesired && !isProtectedGroup(groupName) {
+				actions = append(actions, IdentitySyncAction{Action: IdentitySyncDeleteGroup, Name: groupName})
+			}
+		}
+	}
+	return actions, nil
+}
+
+// Returns the sorted JSON fields which are set in desired, and have different values in current.
+func diffIdentityFields(desired, current interface{}, ignoredFields ...string) ([]string, error) {
+	desiredFields, err := toJsonMap(desired)
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := toJsonMap(current)
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for field, value := range desiredFields {
+		if !slices.Contains(ignoredFields, field) && !reflect.DeepEqual(value, currentFields[field]) {
+			changes = append(changes, field)
+		}
+	}
+	sort.Strings(changes)
+	return changes, nil
+}
+
+func matchesWildcards(patterns []string, name string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, err := path.Match(pattern, name)
+		return err == nil && matched
+	})
+}
+
+func appendIfMissing(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Reads the records of a CSV file into structs, by the columns in its header row and the csv tags of the struct's fields.
+func readCsvFile[T any](filePath string) ([]T, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	var items []T
+	header := records[0]
+	for line, record := range records[1:] {
+		var item T
+		itemValue := reflect.ValueOf(&item).Elem()
+		for column, columnName := range header {
+			columnName = strings.TrimSpace(columnName)
+			field := getCsvField(itemValue, columnName)
+			if !field.IsValid() {
+				return nil, errorutils.CheckErrorf("unknown column '%s' in %s", columnName, filePath)
+			}
+			if err = setCsvFieldValue(field, strings.TrimSpace(record[column])); err != nil {
+				return nil, errorutils.CheckErrorf("invalid value of column '%s' in line %d of %s: %s", columnName, line+2, filePath, err.Error())
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func getCsvField(structValue reflect.Value, columnName string) reflect.Value {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		if name, _, _ := strings.Cut(structType.Field(i).Tag.Get("csv"), ","); name == columnName {
+			return structValue.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// Sets the field from a CSV value. Empty values leave the field unset.
+func setCsvFieldValue(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case *bool:
+		boolValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&boolValue))
+	case []string:
+		field.Set(reflect.ValueOf(list))
+	case *[]string:
+		field.Set(reflect.ValueOf(&list))
+	default:
+		return errorutils.CheckErrorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanIdentitiesSync(t *testing.T) {
+	falseValue := false
+	trueValue := true
+	desired := &Identities{
+		Users: []User{
+			{Name: "alice", Email: "alice@acme.io", Password: "secret", Groups: &[]string{"developers"}},
+			{Name: "bob", Email: "bob@acme.io", Admin: &falseValue},
+			{Name: "carol", Email: "carol@acme.io"},
+			{Name: "admin", Email: "root@acme.io"},
+		},
+		Groups: []Group{
+			{Name: "developers", Description: "Developers"},
+			{Name: "release-managers", Description: "Release managers", UsersNames: []string{"bob"}},
+		},
+	}
+	current := &currentIdentities{
+		users: map[string]*User{
+			"bob":     {Name: "bob", Email: "bob@acme.io", Admin: &trueValue, Groups: &[]string{"readers", "legacy"}},
+			"carol":   {Name: "carol", Email: "carol@acme.io", Groups: &[]string{"readers"}},
+			"admin":   {Name: "admin"},
+			"dave":    {Name: "dave"},
+			"ci-bot1": {Name: "ci-bot1"},
+		},
+		groups: map[string]*Group{
+			"developers": {Name: "developers", Description: "Devs", AutoJoin: &falseValue},
+			"readers":    {Name: "readers"},
+			"legacy":     {Name: "legacy"},
+		},
+	}
+	// The "readers" group is protected by default.
+	params := IdentitiesSyncParams{DeleteUnlisted: true, ProtectedUsers: []string{"ci-*"}}
+
+	actions, err := planIdentitiesSync(desired, current, params)
+	assert.NoError(t, err)
+	var summary []IdentitySyncAction
+	for _, action := range actions {
+		summary = append(summary, IdentitySyncAction{Action: action.Action, Name: action.Name, Changes: action.Changes})
+	}
+	assert.Equal(t, []IdentitySyncAction{
+		{Action: IdentitySyncUpdateGroup, Name: "developers", Changes: []string{"description"}},
+		{Action: IdentitySyncCreateGroup, Name: "release-managers"},
+		{Action: IdentitySyncCreateUser, Name: "alice"},
+		{Action: IdentitySyncUpdateUser, Name: "bob", Changes: []string{"admin", "groups"}},
+		{Action: IdentitySyncDeleteUser, Name: "dave"},
+		{Action: IdentitySyncDeleteGroup, Name: "legacy"},
+	}, summary)
+
+	// New users are created with their password and groups.
+	assert.Equal(t, "secret", actions[2].user.Password)
+	assert.Equal(t, []string{"developers"}, *actions[2].user.Groups)
+	// Memberships in protected groups, and in groups which are not part of the desired identities, are kept.
+	assert.Equal(t, []string{"legacy", "readers", "release-managers"}, *actions[3].user.Groups)
+	assert.Empty(t, actions[1].group.UsersNames)
+
+	// Additional protected groups.
+	params.ProtectedGroups = []string{"leg*"}
+	actions, err = planIdentitiesSync(desired, current, params)
+	assert.NoError(t, err)
+	assert.Len(t, actions, 5)
+	assert.Equal(t, []string{"legacy", "readers", "release-managers"}, *actions[3].user.Groups)
+	params.ProtectedGroups = nil
+
+	// Without deleting unlisted identities.
+	params.DeleteUnlisted = false
+	actions, err = planIdentitiesSync(desired, current, params)
+	assert.NoError(t, err)
+	assert.Len(t, actions, 4)
+
+	// Memberships of unlisted users.
+	desired.Groups = append(desired.Groups, Group{Name: "qa", UsersNames: []string{"erin"}})
+	_, err = planIdentitiesSync(desired, current, params)
+	assert.ErrorContains(t, err, "user 'erin'")
+}
+
+func TestIdentitiesFromCsvFiles(t *testing.T) {
+	trueValue := true
+	tempDir := t.TempDir()
+	usersFile := filepath.Join(tempDir, "users.csv")
+	groupsFile := filepath.Join(tempDir, "groups.csv")
+	assert.NoError(t, os.WriteFile(usersFile, []byte("username,email,admin,groups\nalice,alice@acme.io,false,\"developers, readers\"\nbob,bob@acme.io,,\n"), 0600))
+	assert.NoError(t, os.WriteFile(groupsFile, []byte("name,description,autoJoin\ndevelopers,Developers,true\n"), 0600))
+
+	identities, err := IdentitiesFromCsvFiles(usersFile, groupsFile)()
+	assert.NoError(t, err)
+	assert.Len(t, identities.Users, 2)
+	assert.Equal(t, "alice@acme.io", identities.Users[0].Email)
+	assert.False(t, *identities.Users[0].Admin)
+	assert.Equal(t, []string{"developers", "readers"}, *identities.Users[0].Groups)
+	assert.Nil(t, identities.Users[1].Admin)
+	assert.Nil(t, identities.Users[1].Groups)
+	assert.Equal(t, []Group{{Name: "developers", Description: "Developers", AutoJoin: &trueValue}}, identities.Groups)
+
+	assert.NoError(t, os.WriteFile(usersFile, []byte("username,nickname\nalice,al\n"), 0600))
+	_, err = IdentitiesFromCsvFiles(usersFile, "")()
+	assert.ErrorContains(t, err, "unknown column 'nickname'")
+	assert.NoError(t, os.WriteFile(usersFile, []byte("username,admin\nalice,maybe\n"), 0600))
+	_, err = IdentitiesFromCsvFiles(usersFile, "")()
+	assert.ErrorContains(t, err, "line 2")
+}
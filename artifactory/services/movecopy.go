@@ -12,6 +12,7 @@ import (
 	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
 	clientutils "github.com/madotis/jfrog-client-go/utils"
 	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
 	"github.com/madotis/jfrog-client-go/utils/io/content"
 	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
 	"github.com/madotis/jfrog-client-go/utils/log"
@@ -28,6 +29,10 @@ type MoveCopyService struct {
 	DryRun     bool
 	artDetails *auth.ServiceDetails
 	Threads    int
+	// If set, receives an event for each step of each artifact's move or copy.
+	Observer ioutils.TransferObserver
+	// When set, the moves or copies are written to it instead of being performed.
+	planWriter *content.ContentWriter
 }
 
 func NewMoveCopyService(artDetails auth.ServiceDetails, client *jfroghttpclient.JfrogHttpClient, moveType MoveType) *MoveCopyService {
@@ -46,6 +51,17 @@ func (mc *MoveCopyService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient
 	return mc.client
 }
 
+func (mc *MoveCopyService) SetTransferObserver(observer ioutils.TransferObserver) {
+	mc.Observer = observer
+}
+
+func (mc *MoveCopyService) getTransferObserver() ioutils.TransferObserver {
+	if mc.planWriter != nil {
+		return nil
+	}
+	return mc.Observer
+}
+
 func (mc *MoveCopyService) MoveCopyServiceMoveFilesWrapper(moveSpecs ...MoveCopyParams) (successCount, failedCount int, err error) {
 	moveReaders := []*ReaderSpecTuple{}
 	defer func() {
@@ -113,7 +129,8 @@ func (mc *MoveCopyService) getPathsToMove(moveSpec MoveCopyParams) (resultItems
 	case utils.AQL:
 		resultItems, err = utils.SearchBySpecWithAql(moveSpec.GetFile(), mc, utils.NONE)
 	case utils.WILDCARD:
-		moveSpec.SetIncludeDir(true)
+		// Folders are moved with all of their files, so they are moved only if no files are filtered out by the item filters.
+		moveSpec.SetIncludeDir(!moveSpec.HasItemFilters())
 		tempResultItems, err = utils.SearchBySpecWithPattern(moveSpec.GetFile(), mc, utils.NONE)
 		if err != nil {
 			return
@@ -178,8 +195,19 @@ type fileMoveCopyHandlerFunc func(utils.ResultItem, *MoveCopyParams) parallel.Ta
 
 func (mc *MoveCopyService) createMoveCopyFileHandlerFunc(result *utils.Result) fileMoveCopyHandlerFunc {
 	return func(resultItem utils.ResultItem, params *MoveCopyParams) parallel.TaskFunc {
-		return func(threadId int) error {
+		operation := ioutils.CopyOperation
+		if mc.moveType == MOVE {
+			operation = ioutils.MoveOperation
+		}
+		events := newResultItemTransferEvents(mc.getTransferObserver(), operation, resultItem, "")
+		events.queued()
+		return func(threadId int) (err error) {
 			result.TotalCount[threadId]++
+			var success bool
+			events.started()
+			defer func() {
+				events.finished(success, err)
+			}()
 			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, mc.DryRun)
 
 			// Get destination path.
@@ -191,7 +219,7 @@ func (mc *MoveCopyService) createMoveCopyFileHandlerFunc(result *utils.Result) f
 			if strings.HasSuffix(destFile, "/") {
 				if resultItem.Type != "folder" {
 					destFile += resultItem.Name
-				} else {
+				} else if mc.planWriter == nil {
 					_, err = mc.createPathForMoveAction(destFile, logMsgPrefix)
 					if err != nil {
 						return err
@@ -199,8 +227,16 @@ func (mc *MoveCopyService) createMoveCopyFileHandlerFunc(result *utils.Result) f
 				}
 			}
 
+			if mc.planWriter != nil {
+				if err = mc.planMoveCopy(resultItem, destFile); err == nil {
+					result.SuccessCount[threadId]++
+				}
+				return
+			}
+
 			// Perform move/copy.
-			success, err := mc.moveOrCopyFile(resultItem.GetItemRelativePath(), destFile, logMsgPrefix)
+			events.setTargetPath(destFile)
+			success, err = mc.moveOrCopyFile(resultItem.GetItemRelativePath(), destFile, logMsgPrefix)
 			if err != nil {
 				log.Error(err)
 				return err
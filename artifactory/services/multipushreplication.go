@@ -0,0 +1,162 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+const apiMultiPushReplications = "api/replications/multiple/"
+
+type MultiPushReplicationService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewMultiPushReplicationService(client *jfroghttpclient.JfrogHttpClient) *MultiPushReplicationService {
+	return &MultiPushReplicationService{client: client}
+}
+
+func (mrs *MultiPushReplicationService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return mrs.client
+}
+
+type MultiPushReplicationParams struct {
+	// The replicated local repository.
+	RepoKey                string
+	CronExp                string
+	EnableEventReplication bool
+	// The targets of the replications, by their URLs.
+	Replications []utils.ReplicationParams
+}
+
+func NewMultiPushReplicationParams() MultiPushReplicationParams {
+	return MultiPushReplicationParams{}
+}
+
+// Replaces the push replications of the repository with the replications of the params.
+func (mrs *MultiPushReplicationService) SetReplications(params MultiPushReplicationParams) error {
+	log.Info("Setting the push replications of '" + params.RepoKey + "'...")
+	body := utils.CreateMultiPushReplicationBody(params.CronExp, params.EnableEventReplication, params.Replications)
+	return mrs.performRequest(params.RepoKey, body, false)
+}
+
+// Adds a push replication target to the repository. The other targets are kept.
+func (mrs *MultiPushReplicationService) AddTarget(repoKey string, target utils.ReplicationParams) error {
+	current, err := mrs.getReplications(repoKey)
+	if err != nil {
+		return err
+	}
+	if findReplicationTarget(current, target.Url) >= 0 {
+		return errorutils.CheckErrorf("the repository '%s' is already replicated to %s", repoKey, target.Url)
+	}
+	log.Info("Adding a push replication of '" + repoKey + "' to " + target.Url + "...")
+	// The replications of a repository without any are created, rather than updated.
+	return mrs.sendReplications(repoKey, append(current, target), len(current) > 0)
+}
+
+// Updates the push replication of the repository to the target's URL. The other targets are kept.
+// The cron expression and event replication are shared by all the targets of the repository. An empty cron expression
+// and a disabled event replication keep the shared ones, and changing them fails if there are other targets.
+// Use SetReplications to change them.
+func (mrs *MultiPushReplicationService) UpdateTarget(repoKey string, target utils.ReplicationParams) error {
+	current, err := mrs.getReplications(repoKey)
+	if err != nil {
+		return err
+	}
+	replications, err := updateReplicationTarget(repoKey, current, target)
+	if err != nil {
+		return err
+	}
+	log.Info("Updating the push replication of '" + repoKey + "' to " + target.Url + "...")
+	return mrs.sendReplications(repoKey, replications, true)
+}
+
+func updateReplicationTarget(repoKey string, current []utils.ReplicationParams, target utils.ReplicationParams) ([]utils.ReplicationParams, error) {
+	index := findReplicationTarget(current, target.Url)
+	if index < 0 {
+		return nil, errorutils.CheckErrorf("the repository '%s' isn't replicated to %s", repoKey, target.Url)
+	}
+	if target.CronExp == "" {
+		target.CronExp = current[0].CronExp
+	}
+	target.EnableEventReplication = target.EnableEventReplication || current[0].EnableEventReplication
+	if len(current) > 1 && (target.CronExp != current[0].CronExp || target.EnableEventReplication != current[0].EnableEventReplication) {
+		return nil, errorutils.CheckErrorf("the cron expression and event replication of '%s' are shared by all its push replications, and can't be changed for %s only", repoKey, target.Url)
+	}
+	current[index] = target
+	return current, nil
+}
+
+// Removes the push replication of the repository to the target's URL. The other targets are kept.
+func (mrs *MultiPushReplicationService) RemoveTarget(repoKey, targetUrl string) error {
+	httpClientsDetails := mrs.ArtDetails.CreateHttpClientDetails()
+	log.Info("Removing the push replication of '" + repoKey + "' to " + targetUrl + "...")
+	resp, body, err := mrs.client.SendDelete(mrs.ArtDetails.GetUrl()+"api/replications/"+url.PathEscape(repoKey)+"?url="+url.QueryEscape(targetUrl), nil, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusNoContent); err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	log.Info("Done removing the push replication.")
+	return nil
+}
+
+func (mrs *MultiPushReplicationService) getReplications(repoKey string) ([]utils.ReplicationParams, error) {
+	getReplicationService := &GetReplicationService{client: mrs.client, ArtDetails: mrs.ArtDetails}
+	return getReplicationService.GetReplication(repoKey)
+}
+
+// Sends all the replications of the repository. The cron expression and event replication of all of them are taken from the first one.
+func (mrs *MultiPushReplicationService) sendReplications(repoKey string, replications []utils.ReplicationParams, isUpdate bool) error {
+	cronExp, enableEventReplication := "", false
+	if len(replications) > 0 {
+		cronExp, enableEventReplication = replications[0].CronExp, replications[0].EnableEventReplication
+	}
+	return mrs.performRequest(repoKey, utils.CreateMultiPushReplicationBody(cronExp, enableEventReplication, replications), isUpdate)
+}
+
+func (mrs *MultiPushReplicationService) performRequest(repoKey string, body *utils.MultiPushReplicationBody, isUpdate bool) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpClientsDetails := mrs.ArtDetails.CreateHttpClientDetails()
+	utils.SetContentType("application/vnd.org.jfrog.artifactory.replications.MultipleReplicationConfigRequest+json", &httpClientsDetails.Headers)
+	requestUrl := mrs.ArtDetails.GetUrl() + apiMultiPushReplications + url.PathEscape(repoKey)
+	var resp *http.Response
+	var respBody []byte
+	if isUpdate {
+		resp, respBody, err = mrs.client.SendPost(requestUrl, content, &httpClientsDetails)
+	} else {
+		resp, respBody, err = mrs.client.SendPut(requestUrl, content, &httpClientsDetails)
+	}
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, respBody, http.StatusOK, http.StatusCreated); err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	log.Info("Done setting the push replications.")
+	return nil
+}
+
+// Returns the index of the replication to the URL, ignoring trailing slashes, or -1 if there is no such replication.
+func findReplicationTarget(replications []utils.ReplicationParams, targetUrl string) int {
+	for i, replication := range replications {
+		if strings.TrimSuffix(replication.Url, "/") == strings.TrimSuffix(targetUrl, "/") {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMultiPushReplicationBody(t *testing.T) {
+	replications := []utils.ReplicationParams{
+		{Url: "https://a.example.com/artifactory/libs", Username: "admin", RepoKey: "libs", Enabled: true},
+		{Url: "https://b.example.com/artifactory/libs", Proxy: "proxy", RepoKey: "libs"},
+	}
+	body := utils.CreateMultiPushReplicationBody("0 0 12 * * ?", true, replications)
+	content, err := json.Marshal(body)
+	assert.NoError(t, err)
+	fields := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(content, &fields))
+	assert.Equal(t, "0 0 12 * * ?", fields["cronExp"])
+	assert.Equal(t, true, fields["enableEventReplication"])
+	if assert.Len(t, fields["replications"], 2) {
+		second := fields["replications"].([]interface{})[1].(map[string]interface{})
+		assert.Equal(t, "https://b.example.com/artifactory/libs", second["url"])
+		assert.Equal(t, "proxy", second["proxy"])
+	}
+
+	// Removing all the targets sends an empty list.
+	content, err = json.Marshal(utils.CreateMultiPushReplicationBody("", false, nil))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"cronExp":"","enableEventReplication":false,"replications":[]}`, string(content))
+}
+
+func TestFindReplicationTarget(t *testing.T) {
+	replications := []utils.ReplicationParams{{Url: "https://a.example.com/artifactory/libs"}, {Url: "https://b.example.com/artifactory/libs/"}}
+	assert.Equal(t, 1, findReplicationTarget(replications, "https://b.example.com/artifactory/libs"))
+	assert.Equal(t, 0, findReplicationTarget(replications, "https://a.example.com/artifactory/libs/"))
+	assert.Equal(t, -1, findReplicationTarget(replications, "https://c.example.com/artifactory/libs"))
+}
+
+func TestUpdateReplicationTarget(t *testing.T) {
+	current := func() []utils.ReplicationParams {
+		return []utils.ReplicationParams{
+			{Url: "https://a.example.com/artifactory/libs", CronExp: "0 0 * * * ?"},
+			{Url: "https://b.example.com/artifactory/libs", CronExp: "0 0 * * * ?"},
+		}
+	}
+	replications, err := updateReplicationTarget("libs", current(), utils.ReplicationParams{Url: "https://b.example.com/artifactory/libs", Username: "admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, utils.ReplicationParams{Url: "https://b.example.com/artifactory/libs", Username: "admin", CronExp: "0 0 * * * ?"}, replications[1])
+
+	// The cron expression is shared by all the targets.
+	_, err = updateReplicationTarget("libs", current(), utils.ReplicationParams{Url: "https://b.example.com/artifactory/libs", CronExp: "0 30 * * * ?"})
+	assert.ErrorContains(t, err, "shared by all its push replications")
+	replications, err = updateReplicationTarget("libs", current()[1:], utils.ReplicationParams{Url: "https://b.example.com/artifactory/libs", CronExp: "0 30 * * * ?"})
+	assert.NoError(t, err)
+	assert.Equal(t, "0 30 * * * ?", replications[0].CronExp)
+
+	_, err = updateReplicationTarget("libs", current(), utils.ReplicationParams{Url: "https://a.example.com/artifactory/libs", EnableEventReplication: true})
+	assert.Error(t, err)
+	_, err = updateReplicationTarget("libs", current(), utils.ReplicationParams{Url: "https://c.example.com/artifactory/libs"})
+	assert.ErrorContains(t, err, "isn't replicated to")
+}
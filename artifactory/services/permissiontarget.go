@@ -1,8 +1,11 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
+	"slices"
+	"sort"
 
 	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
 
@@ -26,9 +29,14 @@ func (pts *PermissionTargetService) GetJfrogHttpClient() *jfroghttpclient.JfrogH
 }
 
 func (pts *PermissionTargetService) Delete(permissionTargetName string) error {
+	return pts.DeleteFromProject(permissionTargetName, "")
+}
+
+// Deletes a project-scoped permission target. The project is passed like it is when the permission target is created.
+func (pts *PermissionTargetService) DeleteFromProject(permissionTargetName, projectKey string) error {
 	httpClientsDetails := pts.ArtDetails.CreateHttpClientDetails()
 	log.Info("Deleting permission target...")
-	resp, body, err := pts.client.SendDelete(pts.ArtDetails.GetUrl()+"api/v2/security/permissions/"+permissionTargetName, nil, &httpClientsDetails)
+	resp, body, err := pts.client.SendDelete(pts.ArtDetails.GetUrl()+"api/v2/security/permissions/"+permissionTargetName+utils.GetProjectQueryParam(projectKey), nil, &httpClientsDetails)
 	if err != nil {
 		return err
 	}
@@ -42,9 +50,13 @@ func (pts *PermissionTargetService) Delete(permissionTargetName string) error {
 }
 
 func (pts *PermissionTargetService) Get(permissionTargetName string) (*PermissionTargetParams, error) {
+	return pts.get(permissionTargetName, "")
+}
+
+func (pts *PermissionTargetService) get(permissionTargetName, projectKey string) (*PermissionTargetParams, error) {
 	httpClientsDetails := pts.ArtDetails.CreateHttpClientDetails()
 	log.Info("Getting permission target...")
-	resp, body, _, err := pts.client.SendGet(pts.ArtDetails.GetUrl()+"api/v2/security/permissions/"+permissionTargetName, true, &httpClientsDetails)
+	resp, body, _, err := pts.client.SendGet(pts.ArtDetails.GetUrl()+"api/v2/security/permissions/"+permissionTargetName+utils.GetProjectQueryParam(projectKey), true, &httpClientsDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +69,195 @@ func (pts *PermissionTargetService) Get(permissionTargetName string) (*Permissio
 	}
 
 	log.Debug("Artifactory response:", resp.Status)
-	permissionTarget := &PermissionTargetParams{}
+	permissionTarget := &PermissionTargetParams{ProjectKey: projectKey}
 	if err := json.Unmarshal(body, permissionTarget); err != nil {
 		return nil, err
 	}
 	return permissionTarget, nil
 }
 
+// Returns all the permission targets. Artifactory lists only their names, so each of them is fetched separately.
+func (pts *PermissionTargetService) GetAll() ([]*PermissionTargetParams, error) {
+	page, err := pts.List(PermissionTargetsListParams{})
+	if err != nil {
+		return nil, err
+	}
+	return page.PermissionTargets, nil
+}
+
+// Returns a page of the permission targets, ordered by name. Artifactory doesn't page the permission targets, so the
+// names of all of them are fetched for every page, and then each permission target of the page is fetched separately.
+// Paging bounds the number of permission targets which are fetched, not the size of the names list.
+func (pts *PermissionTargetService) List(params PermissionTargetsListParams) (*PermissionTargetsPage, error) {
+	if params.Offset < 0 || params.Limit < 0 {
+		return nil, errorutils.CheckErrorf("the offset and limit of a permission targets page must not be negative")
+	}
+	names, err := pts.getNames(params.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	pageNames, nextOffset := getPermissionTargetsPage(names, params.Offset, params.Limit)
+	page := &PermissionTargetsPage{Total: len(names), NextOffset: nextOffset}
+	for _, name := range pageNames {
+		permissionTarget, err := pts.get(name, params.ProjectKey)
+		if err != nil {
+			return nil, err
+		}
+		// The permission target was deleted after it was listed.
+		if permissionTarget == nil {
+			continue
+		}
+		page.PermissionTargets = append(page.PermissionTargets, permissionTarget)
+	}
+	return page, nil
+}
+
+// Returns the sorted names of the permission targets.
+func (pts *PermissionTargetService) getNames(projectKey string) ([]string, error) {
+	httpClientsDetails := pts.ArtDetails.CreateHttpClientDetails()
+	log.Info("Getting all permission targets...")
+	resp, body, _, err := pts.client.SendGet(pts.ArtDetails.GetUrl()+"api/v2/security/permissions"+utils.GetProjectQueryParam(projectKey), true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	var permissionTargetNames []permissionTargetName
+	if err = json.Unmarshal(body, &permissionTargetNames); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	names := make([]string, 0, len(permissionTargetNames))
+	for _, name := range permissionTargetNames {
+		names = append(names, name.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Returns the names of the page, and the offset of the next page, or -1 if it's the last one. A zero limit means no limit.
+func getPermissionTargetsPage(names []string, offset, limit int) ([]string, int) {
+	if offset >= len(names) {
+		return nil, -1
+	}
+	end := len(names)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+	if end == len(names) {
+		return names[offset:end], -1
+	}
+	return names[offset:end], end
+}
+
+// Changes the actions of users and groups in a section of a permission target. The other principals and sections of
+// the permission target are kept.
+// The patch is optimistic, and NOT atomic. The permissions API has no partial update, so the permission target is
+// fetched, patched and replaced as a whole. Patch only detects concurrent changes: the permission target is fetched
+// again right before it's replaced, and if it changed, Patch fails with a *PermissionTargetConflictError, which
+// callers should handle by retrying. A change made between that second fetch and the replacement is overwritten.
+func (pts *PermissionTargetService) Patch(params PermissionTargetPatchParams) error {
+	permissionTarget, err := pts.get(params.Name, params.ProjectKey)
+	if err != nil {
+		return err
+	}
+	if permissionTarget == nil {
+		return errorutils.CheckErrorf("permission target '%s' does not exist", params.Name)
+	}
+	fetched, err := json.Marshal(permissionTarget)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	changed, err := patchPermissionTarget(permissionTarget, params)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		log.Info("Permission target '" + params.Name + "' is already up to date.")
+		return nil
+	}
+	current, err := pts.get(params.Name, params.ProjectKey)
+	if err != nil {
+		return err
+	}
+	if changed, err = isPermissionTargetChanged(fetched, current); err != nil {
+		return err
+	}
+	if changed {
+		return errorutils.CheckError(&PermissionTargetConflictError{Name: params.Name})
+	}
+	return pts.performRequest(*permissionTarget, true)
+}
+
+// Returns whether the permission target differs from its fetched JSON, or was deleted.
+func isPermissionTargetChanged(fetched []byte, current *PermissionTargetParams) (bool, error) {
+	if current == nil {
+		return true, nil
+	}
+	content, err := json.Marshal(current)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return !bytes.Equal(fetched, content), nil
+}
+
+// Applies the patch to the permission target, and returns whether it changed.
+func patchPermissionTarget(permissionTarget *PermissionTargetParams, patch PermissionTargetPatchParams) (bool, error) {
+	var section **PermissionTargetSection
+	switch patch.Section {
+	case PermissionTargetSectionRepo, "":
+		section = &permissionTarget.Repo
+	case PermissionTargetSectionBuild:
+		section = &permissionTarget.Build
+	case PermissionTargetSectionReleaseBundle:
+		section = &permissionTarget.ReleaseBundle
+	default:
+		return false, errorutils.CheckErrorf("unknown permission target section '%s'", patch.Section)
+	}
+	if *section == nil {
+		if len(patch.AddUsers) > 0 || len(patch.AddGroups) > 0 {
+			return false, errorutils.CheckErrorf("permission target '%s' has no '%s' section to add principals to", permissionTarget.Name, getPatchSectionName(patch))
+		}
+		return false, nil
+	}
+	if (*section).Actions == nil {
+		(*section).Actions = &Actions{}
+	}
+	actions := (*section).Actions
+	changed := patchPrincipalActions(&actions.Users, patch.AddUsers, patch.RemoveUsers)
+	changed = patchPrincipalActions(&actions.Groups, patch.AddGroups, patch.RemoveGroups) || changed
+	return changed, nil
+}
+
+func getPatchSectionName(patch PermissionTargetPatchParams) string {
+	if patch.Section == "" {
+		return PermissionTargetSectionRepo
+	}
+	return patch.Section
+}
+
+func patchPrincipalActions(principals *map[string][]string, add map[string][]string, remove []string) bool {
+	changed := false
+	for _, name := range remove {
+		if _, exists := (*principals)[name]; exists {
+			delete(*principals, name)
+			changed = true
+		}
+	}
+	for name, actions := range add {
+		if current, exists := (*principals)[name]; exists && slices.Equal(slices.Sorted(slices.Values(current)), slices.Sorted(slices.Values(actions))) {
+			continue
+		}
+		if *principals == nil {
+			*principals = map[string][]string{}
+		}
+		(*principals)[name] = actions
+		changed = true
+	}
+	return changed
+}
+
 func (pts *PermissionTargetService) Create(params PermissionTargetParams) error {
 	return pts.performRequest(params, false)
 }
@@ -79,7 +273,7 @@ func (pts *PermissionTargetService) performRequest(params PermissionTargetParams
 	}
 	httpClientsDetails := pts.ArtDetails.CreateHttpClientDetails()
 	utils.SetContentType("application/json", &httpClientsDetails.Headers)
-	var url = pts.ArtDetails.GetUrl() + "api/v2/security/permissions/" + params.Name
+	var url = pts.ArtDetails.GetUrl() + "api/v2/security/permissions/" + params.Name + utils.GetProjectQueryParam(params.ProjectKey)
 	var operationString string
 	var resp *http.Response
 	var body []byte
@@ -114,7 +308,9 @@ func NewPermissionTargetParams() PermissionTargetParams {
 // Using struct pointers to keep the fields null if they are empty.
 // Artifactory evaluates inner struct typed fields if they are not null, which can lead to failures in the request.
 type PermissionTargetParams struct {
-	Name          string                   `json:"name"`
+	Name string `json:"name"`
+	// The project of a project-scoped permission target. Not part of the permission target's body.
+	ProjectKey    string                   `json:"-"`
 	Repo          *PermissionTargetSection `json:"repo,omitempty"`
 	Build         *PermissionTargetSection `json:"build,omitempty"`
 	ReleaseBundle *PermissionTargetSection `json:"releaseBundle,omitempty"`
@@ -127,6 +323,56 @@ type PermissionTargetSection struct {
 	Actions         *Actions `json:"actions,omitempty"`
 }
 
+// The sections of a permission target.
+const (
+	PermissionTargetSectionRepo          = "repo"
+	PermissionTargetSectionBuild         = "build"
+	PermissionTargetSectionReleaseBundle = "releaseBundle"
+)
+
+type PermissionTargetsListParams struct {
+	// List the permission targets of this project only.
+	ProjectKey string
+	// The number of permission targets to skip.
+	Offset int
+	// The maximum number of permission targets to return. All of them by default.
+	Limit int
+}
+
+func NewPermissionTargetsListParams() PermissionTargetsListParams {
+	return PermissionTargetsListParams{}
+}
+
+type PermissionTargetsPage struct {
+	PermissionTargets []*PermissionTargetParams
+	// The number of permission targets in all the pages.
+	Total int
+	// The offset of the next page, or -1 if this is the last page.
+	NextOffset int
+}
+
+type PermissionTargetPatchParams struct {
+	Name       string
+	ProjectKey string
+	// PermissionTargetSectionRepo by default.
+	Section string
+	// The actions of users and groups to add. The current actions of a user or group which is already in the section are
+	// replaced.
+	AddUsers  map[string][]string
+	AddGroups map[string][]string
+	// The users and groups to remove from the section.
+	RemoveUsers  []string
+	RemoveGroups []string
+}
+
+func NewPermissionTargetPatchParams() PermissionTargetPatchParams {
+	return PermissionTargetPatchParams{}
+}
+
+type permissionTargetName struct {
+	Name string `json:"name"`
+}
+
 type Actions struct {
 	Users  map[string][]string `json:"users,omitempty"`
 	Groups map[string][]string `json:"groups,omitempty"`
@@ -139,3 +385,12 @@ type PermissionTargetAlreadyExistsError struct {
 func (*PermissionTargetAlreadyExistsError) Error() string {
 	return "Artifactory: Permission target already exists."
 }
+
+// The permission target was changed by someone else while it was patched, and wasn't replaced. The patch may be retried.
+type PermissionTargetConflictError struct {
+	Name string
+}
+
+func (ptce *PermissionTargetConflictError) Error() string {
+	return "permission target '" + ptce.Name + "' was changed while it was patched"
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPermissionTargetsPage(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e"}
+	tests := []struct {
+		offset, limit      int
+		expectedNames      []string
+		expectedNextOffset int
+	}{
+		{0, 0, names, -1},
+		{0, 2, []string{"a", "b"}, 2},
+		{2, 2, []string{"c", "d"}, 4},
+		{4, 2, []string{"e"}, -1},
+		{3, 2, []string{"d", "e"}, -1},
+		{5, 2, nil, -1},
+	}
+	for _, test := range tests {
+		pageNames, nextOffset := getPermissionTargetsPage(names, test.offset, test.limit)
+		assert.Equal(t, test.expectedNames, pageNames)
+		assert.Equal(t, test.expectedNextOffset, nextOffset)
+	}
+}
+
+func TestPatchPermissionTarget(t *testing.T) {
+	permissionTarget := &PermissionTargetParams{
+		Name: "java-developers",
+		Repo: &PermissionTargetSection{
+			Repositories: []string{"ANY LOCAL"},
+			Actions: &Actions{
+				Users:  map[string][]string{"alice": {"read"}, "bob": {"read", "write"}},
+				Groups: map[string][]string{"readers": {"read"}},
+			},
+		},
+		Build: &PermissionTargetSection{Repositories: []string{"artifactory-build-info"}},
+	}
+
+	patch := PermissionTargetPatchParams{
+		AddUsers:     map[string][]string{"alice": {"read", "write"}, "carol": {"read"}},
+		RemoveUsers:  []string{"bob", "dave"},
+		RemoveGroups: []string{"readers"},
+	}
+	changed, err := patchPermissionTarget(permissionTarget, patch)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string][]string{"alice": {"read", "write"}, "carol": {"read"}}, permissionTarget.Repo.Actions.Users)
+	assert.Empty(t, permissionTarget.Repo.Actions.Groups)
+	assert.Equal(t, []string{"ANY LOCAL"}, permissionTarget.Repo.Repositories)
+
+	// Applying the same patch again changes nothing, even if the actions are listed in a different order.
+	patch.AddUsers["alice"] = []string{"write", "read"}
+	changed, err = patchPermissionTarget(permissionTarget, patch)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = patchPermissionTarget(permissionTarget, PermissionTargetPatchParams{Section: PermissionTargetSectionBuild, AddGroups: map[string][]string{"ci": {"read", "manage"}}})
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string][]string{"ci": {"read", "manage"}}, permissionTarget.Build.Actions.Groups)
+
+	_, err = patchPermissionTarget(permissionTarget, PermissionTargetPatchParams{Section: PermissionTargetSectionReleaseBundle, AddUsers: map[string][]string{"alice": {"read"}}})
+	assert.ErrorContains(t, err, "no 'releaseBundle' section")
+	changed, err = patchPermissionTarget(permissionTarget, PermissionTargetPatchParams{Section: PermissionTargetSectionReleaseBundle, RemoveUsers: []string{"alice"}})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	_, err = patchPermissionTarget(permissionTarget, PermissionTargetPatchParams{Section: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestIsPermissionTargetChanged(t *testing.T) {
+	fetched := &PermissionTargetParams{Name: "deployers", Repo: &PermissionTargetSection{
+		Repositories: []string{"libs-local"},
+		Actions:      &Actions{Users: map[string][]string{"alice": {"read"}, "bob": {"write"}}},
+	}}
+	content, err := json.Marshal(fetched)
+	assert.NoError(t, err)
+
+	changed, err := isPermissionTargetChanged(content, fetched)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	fetched.Repo.Actions.Groups = map[string][]string{"readers": {"read"}}
+	changed, err = isPermissionTargetChanged(content, fetched)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+
+	// A deleted permission target changed too.
+	changed, err = isPermissionTargetChanged(content, nil)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+}
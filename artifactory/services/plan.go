@@ -0,0 +1,278 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+)
+
+type PlannedActionType string
+
+const (
+	PlannedUpload    PlannedActionType = "upload"
+	PlannedDownload  PlannedActionType = "download"
+	PlannedMove      PlannedActionType = "move"
+	PlannedCopy      PlannedActionType = "copy"
+	PlannedDelete    PlannedActionType = "delete"
+	PlannedCreateDir PlannedActionType = "create-dir"
+	// The target is already up-to-date, so the file would not be transferred.
+	PlannedSkip PlannedActionType = "skip"
+)
+
+// An action an operation would perform. Plans are created by the same code that performs the operation,
+// except that each action is written to the plan instead of being performed.
+type PlannedAction struct {
+	Action PlannedActionType `json:"action,omitempty"`
+	// The local path of an uploaded file, or the path in Artifactory of the file for the other actions.
+	// Empty for archives uploaded from multiple files.
+	SourcePath string `json:"sourcePath,omitempty"`
+	// The path in Artifactory for uploads, moves and copies, or the local path of a downloaded file. Empty for deletions.
+	TargetPath string            `json:"targetPath,omitempty"`
+	Size       int64             `json:"size,omitempty"`
+	Checksum   entities.Checksum `json:"checksum,omitempty"`
+	// True if the upload would be first attempted by checksum, which deploys the file without sending its content,
+	// if Artifactory already stores content with the same checksum.
+	ChecksumDeploy bool `json:"checksumDeploy,omitempty"`
+	// True if a file already exists in the target, and would be overwritten.
+	Overwrite bool   `json:"overwrite,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func getResultItemChecksum(resultItem utils.ResultItem) entities.Checksum {
+	return entities.Checksum{Md5: resultItem.Actual_Md5, Sha1: resultItem.Actual_Sha1, Sha256: resultItem.Sha256}
+}
+
+// Runs the operation while planWriter is set, and returns a ContentReader of the PlannedAction structs it wrote.
+func runPlan(setPlanWriter func(*content.ContentWriter), operation func() error) (*content.ContentReader, error) {
+	planWriter, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	setPlanWriter(planWriter)
+	defer setPlanWriter(nil)
+	err = operation()
+	if closeErr := planWriter.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if planWriter.GetFilePath() != "" {
+			_ = planWriter.RemoveOutputFilePath()
+		}
+		return nil, err
+	}
+	return content.NewContentReader(planWriter.GetFilePath(), content.DefaultKey), nil
+}
+
+// Returns true if a file or a folder exists in the path in Artifactory.
+func isPathExistsInArtifactory(client *jfroghttpclient.JfrogHttpClient, artDetails auth.ServiceDetails, path string) (bool, error) {
+	url, err := utils.BuildArtifactoryUrl(artDetails.GetUrl(), path, make(map[string]string))
+	if err != nil {
+		return false, err
+	}
+	httpClientsDetails := artDetails.CreateHttpClientDetails()
+	resp, body, err := client.SendHead(url, &httpClientsDetails)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Returns the upload actions without uploading anything. The upload params are handled exactly as in UploadFiles.
+func (us *UploadService) PlanUpload(uploadParams ...UploadParams) (*content.ContentReader, error) {
+	return runPlan(func(planWriter *content.ContentWriter) { us.planWriter = planWriter }, func() error {
+		_, err := us.UploadFiles(uploadParams...)
+		return err
+	})
+}
+
+func (us *UploadService) planUpload(artifact UploadData, uploadParams UploadParams) error {
+	action := PlannedAction{Action: PlannedUpload, SourcePath: artifact.Artifact.LocalPath, TargetPath: artifact.Artifact.TargetPath}
+	if artifact.IsDir {
+		action.Action = PlannedCreateDir
+		us.planWriter.Write(action)
+		return nil
+	}
+	var details *fileutils.FileDetails
+	var err error
+	if uploadParams.SourceFS != nil {
+		if details, err = getFSFileDetails(uploadParams.SourceFS, artifact.Artifact.LocalPath, uploadParams.ChecksumsCalcEnabled); err != nil {
+			return err
+		}
+		action.ChecksumDeploy = us.shouldTryChecksumDeploy(details.Size, uploadParams)
+	} else {
+		fileInfo, err := os.Lstat(artifact.Artifact.LocalPath)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		if uploadParams.IsSymlink() && fileutils.IsFileSymlink(fileInfo) {
+			details = &fileutils.FileDetails{}
+		} else {
+			if uploadParams.UploadIndex != nil {
+				targetUrlWithProps, err := buildUploadUrls(us.ArtDetails.GetUrl(), artifact.Artifact.TargetPath, artifact.BuildProps, uploadParams.GetDebian(), artifact.TargetProps)
+				if err != nil {
+					return err
+				}
+				if entry := uploadParams.UploadIndex.getDeployedEntry(artifact.Artifact.LocalPath, targetUrlWithProps, fileInfo); entry != nil {
+					action.Action = PlannedSkip
+					action.Size, action.Checksum = entry.Size, entry.Checksum
+					action.Reason = "the file was not modified since it was last uploaded"
+					us.planWriter.Write(action)
+					return nil
+				}
+				details = uploadParams.UploadIndex.getUnchangedFileDetails(artifact.Artifact.LocalPath, fileInfo)
+			}
+			if details == nil {
+				if details, err = fileutils.GetFileDetails(artifact.Artifact.LocalPath, uploadParams.ChecksumsCalcEnabled); err != nil {
+					return err
+				}
+			}
+			action.ChecksumDeploy = us.shouldTryChecksumDeploy(fileInfo.Size(), uploadParams)
+		}
+	}
+	action.Size, action.Checksum = details.Size, details.Checksum
+	if action.Overwrite, err = isPathExistsInArtifactory(us.client, us.ArtDetails, action.TargetPath); err != nil {
+		return err
+	}
+	us.planWriter.Write(action)
+	return nil
+}
+
+// Plans the upload of an archive. The archive is built in memory, without being stored, to calculate its size and checksums.
+func (us *UploadService) createPlanArchiveFunc(uploadResult *utils.Result, targetPath string, archiveData *ArchiveUploadData, errorsQueue *clientutils.ErrorsQueue) parallel.TaskFunc {
+	return func(threadId int) (err error) {
+		uploadResult.TotalCount[threadId]++
+		archiveDataReader := content.NewContentReader(archiveData.writer.GetFilePath(), archiveData.writer.GetArrayKey())
+		defer func() {
+			deferErr := archiveDataReader.Close()
+			if err == nil {
+				err = deferErr
+			}
+		}()
+		var details *fileutils.FileDetails
+		if archiveData.uploadParams.Archive == ZipArchive {
+			var zipReadersWg sync.WaitGroup
+			zipReader := us.readFilesAsZip(archiveDataReader, "Calculating size / checksums",
+				archiveData.uploadParams.Flat, archiveData.uploadParams.Symlink, nil, errorsQueue, &zipReadersWg)
+			details, err = fileutils.GetFileDetailsFromReader(zipReader, archiveData.uploadParams.ChecksumsCalcEnabled)
+			zipReadersWg.Wait()
+		} else {
+			var entries []tarEntry
+			if entries, err = us.collectTarEntries(archiveDataReader, archiveData.uploadParams); err == nil {
+				details, err = us.writeTarArchive(io.Discard, entries, archiveData.uploadParams)
+			}
+		}
+		if err != nil {
+			return
+		}
+		action := PlannedAction{
+			Action:         PlannedUpload,
+			TargetPath:     targetPath,
+			Size:           details.Size,
+			Checksum:       details.Checksum,
+			ChecksumDeploy: us.shouldTryChecksumDeploy(details.Size, archiveData.uploadParams),
+		}
+		if action.Overwrite, err = isPathExistsInArtifactory(us.client, us.ArtDetails, targetPath); err != nil {
+			return
+		}
+		us.planWriter.Write(action)
+		uploadResult.SuccessCount[threadId]++
+		return
+	}
+}
+
+// Returns the download actions without downloading anything. The download params are handled exactly as in DownloadFiles.
+func (ds *DownloadService) PlanDownload(downloadParams ...DownloadParams) (*content.ContentReader, error) {
+	return runPlan(func(planWriter *content.ContentWriter) { ds.planWriter = planWriter }, func() error {
+		_, err := ds.DownloadFiles(downloadParams...)
+		return err
+	})
+}
+
+func (ds *DownloadService) planDownload(downloadData DownloadData, localPath, localFileName string) error {
+	localFilePath := filepath.Join(localPath, localFileName)
+	action := PlannedAction{
+		Action:     PlannedDownload,
+		SourcePath: downloadData.Dependency.GetItemRelativePath(),
+		TargetPath: localFilePath,
+		Size:       downloadData.Dependency.Size,
+		Checksum:   getResultItemChecksum(downloadData.Dependency),
+	}
+	if downloadData.Dependency.Type == "folder" {
+		action.Action = PlannedCreateDir
+		ds.planWriter.Write(action)
+		return nil
+	}
+	isEqual, err := fileutils.IsEqualToLocalFile(localFilePath, downloadData.Dependency.Actual_Md5, downloadData.Dependency.Actual_Sha1)
+	if err != nil {
+		return err
+	}
+	if isEqual {
+		action.Action = PlannedSkip
+		action.Reason = "the file already exists locally"
+	} else {
+		action.Overwrite = fileutils.IsPathExists(localFilePath, false)
+	}
+	ds.planWriter.Write(action)
+	return nil
+}
+
+// Returns the move or copy actions without moving or copying anything. The params are handled exactly as in MoveCopyServiceMoveFilesWrapper.
+func (mc *MoveCopyService) PlanMoveCopy(moveSpecs ...MoveCopyParams) (*content.ContentReader, error) {
+	return runPlan(func(planWriter *content.ContentWriter) { mc.planWriter = planWriter }, func() error {
+		_, _, err := mc.MoveCopyServiceMoveFilesWrapper(moveSpecs...)
+		return err
+	})
+}
+
+func (mc *MoveCopyService) planMoveCopy(resultItem utils.ResultItem, destPath string) (err error) {
+	action := PlannedAction{
+		Action:     PlannedCopy,
+		SourcePath: resultItem.GetItemRelativePath(),
+		TargetPath: destPath,
+		Size:       resultItem.Size,
+		Checksum:   getResultItemChecksum(resultItem),
+	}
+	if mc.moveType == MOVE {
+		action.Action = PlannedMove
+	}
+	if action.Overwrite, err = isPathExistsInArtifactory(mc.client, mc.GetArtifactoryDetails(), destPath); err != nil {
+		return
+	}
+	mc.planWriter.Write(action)
+	return
+}
+
+// Returns the delete actions of the items, without deleting anything.
+func (ds *DeleteService) PlanDelete(deleteItems *content.ContentReader) (*content.ContentReader, error) {
+	return runPlan(func(planWriter *content.ContentWriter) { ds.planWriter = planWriter }, func() error {
+		_, err := ds.DeleteFiles(deleteItems)
+		return err
+	})
+}
+
+func (ds *DeleteService) planDelete(resultItem utils.ResultItem) {
+	ds.planWriter.Write(PlannedAction{
+		Action:     PlannedDelete,
+		SourcePath: resultItem.GetItemRelativePath(),
+		Size:       resultItem.Size,
+		Checksum:   getResultItemChecksum(resultItem),
+	})
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanDownloadActions(t *testing.T) {
+	tempDir := t.TempDir()
+	// The sha1 and md5 of "a".
+	existing := utils.ResultItem{Repo: "repo", Path: "dir", Name: "a.txt", Size: 1, Type: "file",
+		Actual_Sha1: "86f7e437faa5a7fce15d1ddcb9eaeaea377667b8", Actual_Md5: "0cc175b9c0f1b6a831c399e269772661"}
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.txt"), []byte("a"), 0600))
+	modified := utils.ResultItem{Repo: "repo", Path: "dir", Name: "b.txt", Size: 2, Type: "file", Actual_Sha1: "sha1", Actual_Md5: "md5"}
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "b.txt"), []byte("b"), 0600))
+	missing := utils.ResultItem{Repo: "repo", Path: "dir", Name: "c.txt", Size: 3, Type: "file", Actual_Sha1: "sha1"}
+	folder := utils.ResultItem{Repo: "repo", Path: "dir", Name: "sub", Type: "folder"}
+
+	ds := NewDownloadService(nil, nil)
+	reader, err := runPlan(func(planWriter *content.ContentWriter) { ds.planWriter = planWriter }, func() error {
+		for _, item := range []utils.ResultItem{existing, modified, missing, folder} {
+			if err := ds.planDownload(DownloadData{Dependency: item}, tempDir, item.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, ds.planWriter)
+	actions := readTestPlan(t, reader)
+	if assert.Len(t, actions, 4) {
+		assert.Equal(t, PlannedSkip, actions[0].Action)
+		assert.Equal(t, "repo/dir/a.txt", actions[0].SourcePath)
+		assert.Equal(t, PlannedAction{Action: PlannedDownload, SourcePath: "repo/dir/b.txt", TargetPath: filepath.Join(tempDir, "b.txt"),
+			Size: 2, Checksum: getResultItemChecksum(modified), Overwrite: true}, actions[1])
+		assert.Equal(t, PlannedDownload, actions[2].Action)
+		assert.False(t, actions[2].Overwrite)
+		assert.Equal(t, PlannedCreateDir, actions[3].Action)
+	}
+}
+
+func TestPlanDeleteActions(t *testing.T) {
+	ds := NewDeleteService(nil, nil)
+	reader, err := runPlan(func(planWriter *content.ContentWriter) { ds.planWriter = planWriter }, func() error {
+		ds.planDelete(utils.ResultItem{Repo: "repo", Path: ".", Name: "a.txt", Size: 1, Actual_Sha1: "sha1"})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []PlannedAction{{Action: PlannedDelete, SourcePath: "repo/a.txt", Size: 1, Checksum: getResultItemChecksum(utils.ResultItem{Actual_Sha1: "sha1"})}},
+		readTestPlan(t, reader))
+}
+
+func TestRunPlanError(t *testing.T) {
+	var writer *content.ContentWriter
+	reader, err := runPlan(func(planWriter *content.ContentWriter) {
+		if planWriter != nil {
+			writer = planWriter
+		}
+	}, func() error {
+		writer.Write(PlannedAction{Action: PlannedDelete, SourcePath: "repo/a.txt"})
+		return errors.New("failure")
+	})
+	assert.Error(t, err)
+	assert.Nil(t, reader)
+	// The partial plan is removed.
+	_, err = os.Stat(writer.GetFilePath())
+	assert.True(t, os.IsNotExist(err))
+}
+
+func readTestPlan(t *testing.T, reader *content.ContentReader) (actions []PlannedAction) {
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	for action := new(PlannedAction); reader.NextRecord(action) == nil; action = new(PlannedAction) {
+		actions = append(actions, *action)
+	}
+	assert.NoError(t, reader.GetError())
+	return
+}
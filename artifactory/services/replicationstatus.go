@@ -0,0 +1,201 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/httputils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+// The statuses of replications, and of their targets.
+const (
+	ReplicationNeverRun     = "never_run"
+	ReplicationIncomplete   = "incomplete"
+	ReplicationError        = "error"
+	ReplicationWarn         = "warn"
+	ReplicationOk           = "ok"
+	ReplicationInconsistent = "inconsistent"
+
+	defaultReplicationTimeout         = 60 * time.Minute
+	defaultReplicationPollingInterval = 10 * time.Second
+)
+
+type ReplicationStatus struct {
+	Status string `json:"status,omitempty"`
+	// The time the last replication completed, in ISO 8601 format.
+	LastCompleted string `json:"lastCompleted,omitempty"`
+	// The targets of push replications.
+	Targets []ReplicationTargetStatus `json:"targets,omitempty"`
+	// The statuses of the replications of the repositories, by their keys.
+	Repositories map[string]ReplicationTargetStatus `json:"repositories,omitempty"`
+}
+
+type ReplicationTargetStatus struct {
+	Url           string `json:"url,omitempty"`
+	RepoKey       string `json:"repoKey,omitempty"`
+	Status        string `json:"status,omitempty"`
+	LastCompleted string `json:"lastCompleted,omitempty"`
+}
+
+// Returns the targets whose last replication failed.
+func (rs *ReplicationStatus) GetFailedTargets() []ReplicationTargetStatus {
+	var failed []ReplicationTargetStatus
+	for _, target := range rs.Targets {
+		if target.Status == ReplicationError {
+			failed = append(failed, target)
+		}
+	}
+	return failed
+}
+
+// A target of a triggered replication. Push replications need the credentials of their targets.
+type ReplicationTarget struct {
+	Url        string `json:"url"`
+	Username   string `json:"username,omitempty"`
+	Password   string `json:"password,omitempty"`
+	Properties *bool  `json:"properties,omitempty"`
+	Delete     *bool  `json:"delete,omitempty"`
+}
+
+type TriggerReplicationParams struct {
+	RepoKey string
+	// Replicate only this path of the repository. The whole repository by default.
+	Path string
+	// The targets of push replications. Pull replications of remote repositories have no targets.
+	Targets []ReplicationTarget
+	// 60 minutes by default.
+	Timeout time.Duration
+	// 10 seconds by default.
+	PollingInterval time.Duration
+}
+
+func NewTriggerReplicationParams() TriggerReplicationParams {
+	return TriggerReplicationParams{}
+}
+
+type ReplicationStatusService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewReplicationStatusService(client *jfroghttpclient.JfrogHttpClient) *ReplicationStatusService {
+	return &ReplicationStatusService{client: client}
+}
+
+func (rss *ReplicationStatusService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return rss.client
+}
+
+func (rss *ReplicationStatusService) GetStatus(repoKey string) (*ReplicationStatus, error) {
+	httpClientsDetails := rss.ArtDetails.CreateHttpClientDetails()
+	resp, body, _, err := rss.client.SendGet(rss.ArtDetails.GetUrl()+"api/replication/"+url.PathEscape(repoKey), true, &httpClientsDetails)
+	if err != nil {
+		return nil, err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK); err != nil {
+		return nil, err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	status := &ReplicationStatus{}
+	return status, errorutils.CheckError(json.Unmarshal(body, status))
+}
+
+// Triggers the replication of the repository, without waiting for it.
+func (rss *ReplicationStatusService) Trigger(params TriggerReplicationParams) error {
+	var content []byte
+	if len(params.Targets) > 0 {
+		var err error
+		if content, err = json.Marshal(params.Targets); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	httpClientsDetails := rss.ArtDetails.CreateHttpClientDetails()
+	utils.SetContentType("application/json", &httpClientsDetails.Headers)
+	repoPath := strings.TrimSuffix(params.RepoKey+"/"+strings.TrimPrefix(params.Path, "/"), "/")
+	log.Info("Triggering the replication of '" + repoPath + "'...")
+	resp, body, err := rss.client.SendPost(rss.ArtDetails.GetUrl()+"api/replication/execute/"+escapeRepoPath(repoPath), content, &httpClientsDetails)
+	if err != nil {
+		return err
+	}
+	if err = errorutils.CheckResponseStatusWithBody(resp, body, http.StatusOK, http.StatusAccepted); err != nil {
+		return err
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	log.Info("Done triggering the replication.")
+	return nil
+}
+
+// Triggers the replication of the repository, and waits until it finishes. Returns the status of the finished replication,
+// and an error if it failed.
+func (rss *ReplicationStatusService) TriggerAndWait(params TriggerReplicationParams) (*ReplicationStatus, error) {
+	timeout, pollingInterval := params.Timeout, params.PollingInterval
+	if timeout <= 0 {
+		timeout = defaultReplicationTimeout
+	}
+	if pollingInterval <= 0 {
+		pollingInterval = defaultReplicationPollingInterval
+	}
+	previous, err := rss.GetStatus(params.RepoKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = rss.Trigger(params); err != nil {
+		return nil, err
+	}
+	var status *ReplicationStatus
+	pollingAction := func() (shouldStop bool, responseBody []byte, err error) {
+		status, err = rss.GetStatus(params.RepoKey)
+		if err != nil {
+			return true, nil, err
+		}
+		return isReplicationFinished(status, previous), nil, nil
+	}
+	pollingExecutor := &httputils.PollingExecutor{
+		Timeout:         timeout,
+		PollingInterval: pollingInterval,
+		PollingAction:   pollingAction,
+		MsgPrefix:       fmt.Sprintf("Waiting for the replication of '%s'...", params.RepoKey),
+	}
+	if _, err = pollingExecutor.Execute(); err != nil {
+		return status, err
+	}
+	return status, getReplicationStatusError(params.RepoKey, status)
+}
+
+// The replication is finished when it's no longer incomplete, and it completed after the previous replication.
+func isReplicationFinished(status, previous *ReplicationStatus) bool {
+	return status.Status != ReplicationIncomplete && status.Status != ReplicationNeverRun && status.LastCompleted != previous.LastCompleted
+}
+
+func getReplicationStatusError(repoKey string, status *ReplicationStatus) error {
+	if status.Status != ReplicationError {
+		return nil
+	}
+	failedTargets := status.GetFailedTargets()
+	if len(failedTargets) == 0 {
+		return errorutils.CheckErrorf("the replication of '%s' failed", repoKey)
+	}
+	var urls []string
+	for _, target := range failedTargets {
+		urls = append(urls, target.Url)
+	}
+	return errorutils.CheckErrorf("the replication of '%s' failed to: %s", repoKey, strings.Join(urls, ", "))
+}
+
+// Escapes the segments of the repository path, keeping the slashes between them.
+func escapeRepoPath(repoPath string) string {
+	segments := strings.Split(repoPath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
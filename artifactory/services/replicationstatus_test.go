@@ -0,0 +1,36 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsReplicationFinished(t *testing.T) {
+	previous := &ReplicationStatus{Status: ReplicationOk, LastCompleted: "2023-06-01T10:00:00.000Z"}
+	assert.False(t, isReplicationFinished(&ReplicationStatus{Status: ReplicationOk, LastCompleted: "2023-06-01T10:00:00.000Z"}, previous))
+	assert.False(t, isReplicationFinished(&ReplicationStatus{Status: ReplicationIncomplete, LastCompleted: "2023-06-01T11:00:00.000Z"}, previous))
+	assert.True(t, isReplicationFinished(&ReplicationStatus{Status: ReplicationWarn, LastCompleted: "2023-06-01T11:00:00.000Z"}, previous))
+	assert.True(t, isReplicationFinished(&ReplicationStatus{Status: ReplicationOk, LastCompleted: "2023-06-01T11:00:00.000Z"}, &ReplicationStatus{Status: ReplicationNeverRun}))
+}
+
+func TestReplicationStatusErrors(t *testing.T) {
+	status := &ReplicationStatus{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"status":"error","lastCompleted":"2023-06-01T11:00:00.000Z","targets":[`+
+		`{"url":"https://a.example.com/artifactory/libs","repoKey":"libs","status":"ok","lastCompleted":"2023-06-01T11:00:00.000Z"},`+
+		`{"url":"https://b.example.com/artifactory/libs","repoKey":"libs","status":"error","lastCompleted":"2023-06-01T11:00:00.000Z"}],`+
+		`"repositories":{"libs":{"status":"error","lastCompleted":"2023-06-01T11:00:00.000Z"}}}`), status))
+	assert.Equal(t, []ReplicationTargetStatus{{Url: "https://b.example.com/artifactory/libs", RepoKey: "libs", Status: ReplicationError, LastCompleted: "2023-06-01T11:00:00.000Z"}},
+		status.GetFailedTargets())
+	assert.Equal(t, ReplicationError, status.Repositories["libs"].Status)
+	assert.EqualError(t, getReplicationStatusError("libs", status), "the replication of 'libs' failed to: https://b.example.com/artifactory/libs")
+
+	assert.NoError(t, getReplicationStatusError("libs", &ReplicationStatus{Status: ReplicationWarn}))
+	assert.EqualError(t, getReplicationStatusError("libs", &ReplicationStatus{Status: ReplicationError}), "the replication of 'libs' failed")
+}
+
+func TestEscapeRepoPath(t *testing.T) {
+	assert.Equal(t, "libs/org/my%20lib/1.0%231", escapeRepoPath("libs/org/my lib/1.0#1"))
+	assert.Equal(t, "libs%3Fx", escapeRepoPath("libs?x"))
+}
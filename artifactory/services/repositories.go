@@ -16,6 +16,8 @@ const apiRepositories = "api/repositories"
 type RepositoriesService struct {
 	client     *jfroghttpclient.JfrogHttpClient
 	ArtDetails auth.ServiceDetails
+	// Create and update repositories without validating their params.
+	SkipValidation bool
 }
 
 func NewRepositoriesService(client *jfroghttpclient.JfrogHttpClient) *RepositoriesService {
@@ -76,18 +78,20 @@ func (rs *RepositoriesService) sendGet(api string) ([]byte, error) {
 
 func (rs *RepositoriesService) Create(params interface{}, repoName string) error {
 	repositoryService := &RepositoryService{
-		ArtDetails: rs.ArtDetails,
-		client:     rs.client,
-		isUpdate:   false,
+		ArtDetails:     rs.ArtDetails,
+		client:         rs.client,
+		isUpdate:       false,
+		SkipValidation: rs.SkipValidation,
 	}
 	return repositoryService.performRequest(params, repoName)
 }
 
 func (rs *RepositoriesService) Update(params interface{}, repoName string) error {
 	repositoryService := &RepositoryService{
-		ArtDetails: rs.ArtDetails,
-		client:     rs.client,
-		isUpdate:   true,
+		ArtDetails:     rs.ArtDetails,
+		client:         rs.client,
+		isUpdate:       true,
+		SkipValidation: rs.SkipValidation,
 	}
 	return repositoryService.performRequest(params, repoName)
 }
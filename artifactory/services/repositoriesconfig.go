@@ -0,0 +1,471 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v3"
+)
+
+type RepositoryPlanAction string
+
+const (
+	CreateRepositoryAction RepositoryPlanAction = "create"
+	UpdateRepositoryAction RepositoryPlanAction = "update"
+	DeleteRepositoryAction RepositoryPlanAction = "delete"
+)
+
+// Artifactory doesn't return the passwords of repositories, so they are not compared.
+var uncomparedRepositoryFields = map[string]bool{"password": true}
+
+// The desired repositories, by their classes. The fields are named like in the Artifactory REST API, in both YAML and JSON:
+//
+//	local:
+//	  - key: libs-release-local
+//	    packageType: maven
+//	    description: Release artifacts
+//	virtual:
+//	  - key: libs-release
+//	    packageType: maven
+//	    repositories: [libs-release-local, maven-remote]
+//
+// The class of each repository is set by its section, so rclass may be omitted.
+// The fields of each repository are the fields of the typed params of its class and package type, like
+// MavenLocalRepositoryParams, and parsing fails on any other field.
+type RepositoriesConfig struct {
+	Local     []LocalRepositoryBaseParams     `json:"local,omitempty"`
+	Remote    []RemoteRepositoryBaseParams    `json:"remote,omitempty"`
+	Virtual   []VirtualRepositoryBaseParams   `json:"virtual,omitempty"`
+	Federated []FederatedRepositoryBaseParams `json:"federated,omitempty"`
+	// The typed params of the parsed repositories, by their keys. Repositories which are added to the lists
+	// after parsing are planned by their common fields only.
+	typedParams map[string]interface{}
+}
+
+// The sections of a repositories config, before their repositories are read into their typed params.
+type rawRepositoriesConfig struct {
+	Local     []map[string]interface{} `json:"local,omitempty"`
+	Remote    []map[string]interface{} `json:"remote,omitempty"`
+	Virtual   []map[string]interface{} `json:"virtual,omitempty"`
+	Federated []map[string]interface{} `json:"federated,omitempty"`
+}
+
+// Parses a repositories config from YAML or JSON.
+// Unknown sections, and fields which aren't fields of the typed params of their repositories, fail the parsing.
+func ParseRepositoriesConfig(data []byte) (*RepositoriesConfig, error) {
+	// The YAML is converted to JSON, so that the fields are read by the JSON tags of the params structs.
+	var parsed interface{}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the repositories config: %s", err.Error())
+	}
+	jsonData, err := json.Marshal(parsed)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	raw := &rawRepositoriesConfig{}
+	if err = decodeStrictly(jsonData, raw); err != nil {
+		return nil, errorutils.CheckErrorf("failed to parse the repositories config: %s", err.Error())
+	}
+	config := &RepositoriesConfig{typedParams: map[string]interface{}{}}
+	for _, section := range []struct {
+		rclass       string
+		repositories []map[string]interface{}
+		baseParams   interface{}
+	}{
+		{LocalRepositoryRepoType, raw.Local, &config.Local},
+		{RemoteRepositoryRepoType, raw.Remote, &config.Remote},
+		{VirtualRepositoryRepoType, raw.Virtual, &config.Virtual},
+		{FederatedRepositoryRepoType, raw.Federated, &config.Federated},
+	} {
+		for _, fields := range section.repositories {
+			fields["rclass"] = section.rclass
+			key, typedParams, err := parseConfigRepository(section.rclass, fields)
+			if err != nil {
+				return nil, err
+			}
+			config.typedParams[key] = typedParams
+		}
+		// The common fields of all the repositories are read by the JSON tags of the base params too.
+		sectionData, err := json.Marshal(section.repositories)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if err = json.Unmarshal(sectionData, section.baseParams); err != nil {
+			return nil, errorutils.CheckErrorf("failed to parse the repositories config: %s", err.Error())
+		}
+	}
+	return config, nil
+}
+
+// Reads the fields of a repository in the config into the typed params of its class and package type.
+// Returns the key of the repository, and its typed params.
+func parseConfigRepository(rclass string, fields map[string]interface{}) (string, interface{}, error) {
+	key, _ := fields["key"].(string)
+	packageType, _ := fields["packageType"].(string)
+	newParams, ok := typedRepositoryParams[rclass][strings.ToLower(packageType)]
+	if !ok {
+		newParams = baseRepositoryParams[rclass]
+	}
+	params := newParams()
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return "", nil, errorutils.CheckError(err)
+	}
+	if err = decodeStrictly(data, params); err != nil {
+		return "", nil, errorutils.CheckErrorf("failed to parse the %s repository '%s' in the repositories config: %s", rclass, key, err.Error())
+	}
+	return key, reflect.ValueOf(params).Elem().Interface(), nil
+}
+
+// Decodes JSON, and fails on fields which aren't in the target.
+func decodeStrictly(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
+
+func ReadRepositoriesConfig(path string) (*RepositoriesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ParseRepositoriesConfig(data)
+}
+
+type RepositoriesConfigParams struct {
+	Config *RepositoriesConfig
+	// Delete the local, remote, virtual and federated repositories which aren't in the config.
+	Prune bool
+}
+
+func NewRepositoriesConfigParams() RepositoriesConfigParams {
+	return RepositoriesConfigParams{}
+}
+
+// A field whose current value differs from its desired value. Nested fields are separated by dots.
+type RepositoryFieldChange struct {
+	Field   string      `json:"field,omitempty"`
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+type RepositoryPlanStep struct {
+	Action RepositoryPlanAction `json:"action,omitempty"`
+	Key    string               `json:"key,omitempty"`
+	Rclass string               `json:"rclass,omitempty"`
+	// The changed fields of updated repositories.
+	Changes []RepositoryFieldChange `json:"changes,omitempty"`
+	// The desired params of created and updated repositories. After the plan is read back from JSON, they are a map of
+	// the JSON fields, which is sent as is.
+	Params interface{} `json:"params,omitempty"`
+}
+
+// The steps which make the repositories match a config, in the order they are applied:
+// the local, remote and federated repositories are created and updated first, then the virtual repositories,
+// each after the virtual repositories it includes. Deleted repositories are deleted last, virtual repositories first.
+type RepositoriesPlan struct {
+	Steps []RepositoryPlanStep `json:"steps,omitempty"`
+}
+
+func (rp *RepositoriesPlan) IsEmpty() bool {
+	return len(rp.Steps) == 0
+}
+
+// Returns a line for each step, and for each of the changed fields of updates.
+func (rp *RepositoriesPlan) String() string {
+	var lines []string
+	for _, step := range rp.Steps {
+		lines = append(lines, fmt.Sprintf("%s %s repository '%s'", step.Action, step.Rclass, step.Key))
+		for _, change := range step.Changes {
+			lines = append(lines, fmt.Sprintf("  %s: %s -> %s", change.Field, formatChangeValue(change.Current), formatChangeValue(change.Desired)))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatChangeValue(value interface{}) string {
+	if value == nil {
+		return "<unset>"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+type RepositoriesConfigService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewRepositoriesConfigService(client *jfroghttpclient.JfrogHttpClient) *RepositoriesConfigService {
+	return &RepositoriesConfigService{client: client}
+}
+
+func (rcs *RepositoriesConfigService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return rcs.client
+}
+
+func (rcs *RepositoriesConfigService) getRepositoriesService() *RepositoriesService {
+	return &RepositoriesService{client: rcs.client, ArtDetails: rcs.ArtDetails}
+}
+
+// Compares the config to the current repositories, and returns the steps which would make them match it. Nothing is changed.
+func (rcs *RepositoriesConfigService) Plan(params RepositoriesConfigParams) (*RepositoriesPlan, error) {
+	repositoriesService := rcs.getRepositoriesService()
+	existing, err := repositoriesService.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return createRepositoriesPlan(params, *existing, repositoriesService.Get)
+}
+
+// Applies the steps of the plan in order, and stops at the first failure.
+func (rcs *RepositoriesConfigService) Apply(plan *RepositoriesPlan) error {
+	repositoriesService := rcs.getRepositoriesService()
+	deleteService := &DeleteRepositoryService{client: rcs.client, ArtDetails: rcs.ArtDetails}
+	for _, step := range plan.Steps {
+		var err error
+		if step.Params == nil && step.Action != DeleteRepositoryAction {
+			return errorutils.CheckErrorf("the %s step of repository '%s' has no params", step.Action, step.Key)
+		}
+		switch step.Action {
+		case CreateRepositoryAction:
+			err = repositoriesService.Create(step.Params, step.Key)
+		case UpdateRepositoryAction:
+			err = repositoriesService.Update(step.Params, step.Key)
+		case DeleteRepositoryAction:
+			err = deleteService.Delete(step.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type desiredRepository struct {
+	key    string
+	rclass string
+	params interface{}
+}
+
+func getDesiredRepositories(config *RepositoriesConfig) ([]desiredRepository, error) {
+	var desired []desiredRepository
+	for i := range config.Local {
+		config.Local[i].Rclass = LocalRepositoryRepoType
+		desired = append(desired, desiredRepository{key: config.Local[i].Key, rclass: LocalRepositoryRepoType, params: config.Local[i]})
+	}
+	for i := range config.Remote {
+		config.Remote[i].Rclass = RemoteRepositoryRepoType
+		desired = append(desired, desiredRepository{key: config.Remote[i].Key, rclass: RemoteRepositoryRepoType, params: config.Remote[i]})
+	}
+	for i := range config.Federated {
+		config.Federated[i].Rclass = FederatedRepositoryRepoType
+		desired = append(desired, desiredRepository{key: config.Federated[i].Key, rclass: FederatedRepositoryRepoType, params: config.Federated[i]})
+	}
+	virtual, err := sortVirtualRepositories(config.Virtual)
+	if err != nil {
+		return nil, err
+	}
+	desired = append(desired, virtual...)
+	// The parsed repositories are planned by their typed params, so that the fields of their package types are compared and sent too.
+	for i := range desired {
+		if typedParams, ok := config.typedParams[desired[i].key]; ok {
+			desired[i].params = typedParams
+		}
+	}
+
+	keys := map[string]bool{}
+	for _, repo := range desired {
+		if repo.key == "" {
+			return nil, errorutils.CheckErrorf("a %s repository in the repositories config has no key", repo.rclass)
+		}
+		if keys[repo.key] {
+			return nil, errorutils.CheckErrorf("the repository '%s' appears more than once in the repositories config", repo.key)
+		}
+		keys[repo.key] = true
+	}
+	return desired, nil
+}
+
+// Sorts the virtual repositories so that each of them follows the virtual repositories it includes.
+func sortVirtualRepositories(virtualParams []VirtualRepositoryBaseParams) ([]desiredRepository, error) {
+	byKey := map[string]*VirtualRepositoryBaseParams{}
+	keys := make([]string, 0, len(virtualParams))
+	for i := range virtualParams {
+		if byKey[virtualParams[i].Key] != nil {
+			return nil, errorutils.CheckErrorf("the repository '%s' appears more than once in the repositories config", virtualParams[i].Key)
+		}
+		virtualParams[i].Rclass = VirtualRepositoryRepoType
+		byKey[virtualParams[i].Key] = &virtualParams[i]
+		keys = append(keys, virtualParams[i].Key)
+	}
+	sortedKeys, err := sortByIncludedRepositories(keys, func(key string) []string {
+		return byKey[key].Repositories
+	})
+	if err != nil {
+		return nil, err
+	}
+	sorted := make([]desiredRepository, 0, len(sortedKeys))
+	for _, key := range sortedKeys {
+		sorted = append(sorted, desiredRepository{key: key, rclass: VirtualRepositoryRepoType, params: *byKey[key]})
+	}
+	return sorted, nil
+}
+
+// Sorts the keys of virtual repositories so that each of them follows the keys it includes.
+// Included repositories which aren't in the keys are ignored.
+func sortByIncludedRepositories(keys []string, getIncluded func(key string) []string) ([]string, error) {
+	var sorted []string
+	// 0 for keys which aren't sorted, 1 while a repository's included repositories are added, and 2 after the repository is added.
+	state := make(map[string]int, len(keys))
+	for _, key := range keys {
+		state[key] = 0
+	}
+	var add func(key string) error
+	add = func(key string) error {
+		switch state[key] {
+		case 1:
+			return errorutils.CheckErrorf("the virtual repository '%s' includes itself through other virtual repositories", key)
+		case 2:
+			return nil
+		}
+		state[key] = 1
+		for _, included := range getIncluded(key) {
+			if _, ok := state[included]; ok {
+				if err := add(included); err != nil {
+					return err
+				}
+			}
+		}
+		state[key] = 2
+		sorted = append(sorted, key)
+		return nil
+	}
+	for _, key := range keys {
+		if err := add(key); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// Creates the plan of the config, given the existing repositories. getCurrent reads the current params of an existing repository.
+func createRepositoriesPlan(params RepositoriesConfigParams, existing []RepositoryDetails, getCurrent func(repoKey string, repoDetails interface{}) error) (*RepositoriesPlan, error) {
+	if params.Config == nil {
+		return nil, errorutils.CheckErrorf("no repositories config was given")
+	}
+	desired, err := getDesiredRepositories(params.Config)
+	if err != nil {
+		return nil, err
+	}
+	existingClasses := map[string]string{}
+	for _, repo := range existing {
+		existingClasses[repo.Key] = strings.ToLower(repo.GetRepoType())
+	}
+
+	plan := &RepositoriesPlan{}
+	desiredKeys := map[string]bool{}
+	for _, repo := range desired {
+		desiredKeys[repo.key] = true
+		existingClass, exists := existingClasses[repo.key]
+		if !exists {
+			plan.Steps = append(plan.Steps, RepositoryPlanStep{Action: CreateRepositoryAction, Key: repo.key, Rclass: repo.rclass, Params: repo.params})
+			continue
+		}
+		if existingClass != repo.rclass {
+			return nil, errorutils.CheckErrorf("the repository '%s' is a %s repository, and can't be changed to a %s repository", repo.key, existingClass, repo.rclass)
+		}
+		// The current params are read into the same struct as the desired params, so that the same fields are compared.
+		current := reflect.New(reflect.TypeOf(repo.params))
+		if err = getCurrent(repo.key, current.Interface()); err != nil {
+			return nil, err
+		}
+		changes, err := diffRepositoryParams(repo.params, current.Elem().Interface())
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			plan.Steps = append(plan.Steps, RepositoryPlanStep{Action: UpdateRepositoryAction, Key: repo.key, Rclass: repo.rclass, Changes: changes, Params: repo.params})
+		}
+	}
+
+	if params.Prune {
+		plan.Steps = append(plan.Steps, getPrunedRepositories(existing, desiredKeys)...)
+	}
+	return plan, nil
+}
+
+// Returns the delete steps of the existing repositories which aren't desired, virtual repositories first.
+func getPrunedRepositories(existing []RepositoryDetails, desiredKeys map[string]bool) []RepositoryPlanStep {
+	classOrder := map[string]int{VirtualRepositoryRepoType: 0, LocalRepositoryRepoType: 1, RemoteRepositoryRepoType: 1, FederatedRepositoryRepoType: 1}
+	var steps []RepositoryPlanStep
+	for _, repo := range existing {
+		rclass := strings.ToLower(repo.GetRepoType())
+		if _, ok := classOrder[rclass]; !ok || desiredKeys[repo.Key] {
+			continue
+		}
+		steps = append(steps, RepositoryPlanStep{Action: DeleteRepositoryAction, Key: repo.Key, Rclass: rclass})
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		return classOrder[steps[i].Rclass] < classOrder[steps[j].Rclass]
+	})
+	return steps
+}
+
+// Returns the changes of the fields which are set in the desired params. Fields which are not set in the config are not compared.
+func diffRepositoryParams(desired, current interface{}) ([]RepositoryFieldChange, error) {
+	desiredFields, err := toJsonMap(desired)
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := toJsonMap(current)
+	if err != nil {
+		return nil, err
+	}
+	var changes []RepositoryFieldChange
+	diffJsonMaps("", desiredFields, currentFields, &changes)
+	return changes, nil
+}
+
+func diffJsonMaps(prefix string, desired, current map[string]interface{}, changes *[]RepositoryFieldChange) {
+	fields := make([]string, 0, len(desired))
+	for field := range desired {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if prefix == "" && uncomparedRepositoryFields[field] {
+			continue
+		}
+		desiredValue, currentValue := desired[field], current[field]
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		currentMap, currentIsMap := currentValue.(map[string]interface{})
+		if desiredIsMap && currentIsMap {
+			diffJsonMaps(prefix+field+".", desiredMap, currentMap, changes)
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue, currentValue) {
+			*changes = append(*changes, RepositoryFieldChange{Field: prefix + field, Current: currentValue, Desired: desiredValue})
+		}
+	}
+}
+
+func toJsonMap(params interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	fields := map[string]interface{}{}
+	return fields, errorutils.CheckError(json.Unmarshal(data, &fields))
+}
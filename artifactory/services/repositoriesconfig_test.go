@@ -0,0 +1,161 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testRepositoriesConfig = `
+local:
+  - key: libs-local
+    packageType: maven
+    description: Release artifacts
+    xrayIndex: true
+  - key: new-local
+    packageType: generic
+remote:
+  - key: maven-remote
+    packageType: maven
+    url: https://repo.maven.apache.org/maven2
+    password: secret
+virtual:
+  - key: libs-all
+    packageType: maven
+    repositories: [libs, maven-remote]
+  - key: libs
+    packageType: maven
+    repositories: [libs-local]
+`
+
+// The current params of the existing repositories, as returned by Artifactory.
+var testCurrentRepositories = map[string]string{
+	"libs-local":   `{"key":"libs-local","rclass":"local","packageType":"maven","description":"Old","xrayIndex":true,"notes":"n"}`,
+	"maven-remote": `{"key":"maven-remote","rclass":"remote","packageType":"maven","url":"https://repo.maven.apache.org/maven2"}`,
+	"libs":         `{"key":"libs","rclass":"virtual","packageType":"maven","repositories":["libs-local"]}`,
+}
+
+var testExistingRepositories = []RepositoryDetails{
+	{Key: "libs-local", Type: "LOCAL"},
+	{Key: "maven-remote", Type: "REMOTE"},
+	{Key: "libs", Type: "VIRTUAL"},
+	{Key: "old-local", Type: "LOCAL"},
+	{Key: "old-virtual", Type: "VIRTUAL"},
+	{Key: "release-bundles", Type: "RELEASEBUNDLES"},
+}
+
+func getTestCurrentRepository(repoKey string, repoDetails interface{}) error {
+	return json.Unmarshal([]byte(testCurrentRepositories[repoKey]), repoDetails)
+}
+
+func TestParseRepositoriesConfig(t *testing.T) {
+	config, err := ParseRepositoriesConfig([]byte(testRepositoriesConfig))
+	assert.NoError(t, err)
+	if assert.Len(t, config.Local, 2) {
+		assert.Equal(t, "Release artifacts", config.Local[0].Description)
+		assert.True(t, *config.Local[0].XrayIndex)
+	}
+	if assert.Len(t, config.Virtual, 2) {
+		assert.Equal(t, []string{"libs", "maven-remote"}, config.Virtual[0].Repositories)
+	}
+
+	// JSON is YAML too.
+	config, err = ParseRepositoriesConfig([]byte(`{"remote":[{"key":"r","url":"https://example.com"}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", config.Remote[0].Url)
+
+	_, err = ParseRepositoriesConfig([]byte(`local: {key: a}`))
+	assert.Error(t, err)
+}
+
+func TestParseRepositoriesConfigUnknownFields(t *testing.T) {
+	// The fields of the package types are read into their typed params.
+	config, err := ParseRepositoriesConfig([]byte("local: [{key: libs-local, packageType: maven, handleSnapshots: false}]"))
+	assert.NoError(t, err)
+	mavenParams, ok := config.typedParams["libs-local"].(MavenLocalRepositoryParams)
+	if assert.True(t, ok) {
+		assert.False(t, *mavenParams.HandleSnapshots)
+		assert.Equal(t, LocalRepositoryRepoType, mavenParams.Rclass)
+	}
+
+	_, err = ParseRepositoriesConfig([]byte("local: [{key: libs-local, packageType: maven, descripton: Release artifacts}]"))
+	assert.EqualError(t, err, `failed to parse the local repository 'libs-local' in the repositories config: json: unknown field "descripton"`)
+	// The fields of other package types aren't fields of the repository.
+	_, err = ParseRepositoriesConfig([]byte("local: [{key: docker-local, packageType: generic, maxUniqueTags: 10}]"))
+	assert.EqualError(t, err, `failed to parse the local repository 'docker-local' in the repositories config: json: unknown field "maxUniqueTags"`)
+	_, err = ParseRepositoriesConfig([]byte("locals: [{key: libs-local}]"))
+	assert.EqualError(t, err, `failed to parse the repositories config: json: unknown field "locals"`)
+}
+
+func TestCreateRepositoriesPlan(t *testing.T) {
+	config, err := ParseRepositoriesConfig([]byte(testRepositoriesConfig))
+	assert.NoError(t, err)
+	plan, err := createRepositoriesPlan(RepositoriesConfigParams{Config: config}, testExistingRepositories, getTestCurrentRepository)
+	assert.NoError(t, err)
+	type testStep struct {
+		action RepositoryPlanAction
+		key    string
+	}
+	var steps []testStep
+	for _, step := range plan.Steps {
+		steps = append(steps, testStep{step.Action, step.Key})
+	}
+	// The remote repository's password isn't compared, and the virtual repository which includes another one is created after it.
+	assert.Equal(t, []testStep{{UpdateRepositoryAction, "libs-local"}, {CreateRepositoryAction, "new-local"}, {CreateRepositoryAction, "libs-all"}}, steps)
+	assert.Equal(t, []RepositoryFieldChange{{Field: "description", Current: "Old", Desired: "Release artifacts"}}, plan.Steps[0].Changes)
+	assert.Equal(t, "update local repository 'libs-local'\n  description: \"Old\" -> \"Release artifacts\"\n"+
+		"create local repository 'new-local'\ncreate virtual repository 'libs-all'", plan.String())
+
+	// The params of the steps are kept when the plan is saved as JSON.
+	data, err := json.Marshal(plan)
+	assert.NoError(t, err)
+	readPlan := &RepositoriesPlan{}
+	assert.NoError(t, json.Unmarshal(data, readPlan))
+	assert.Equal(t, "Release artifacts", readPlan.Steps[0].Params.(map[string]interface{})["description"])
+	assert.Equal(t, "new-local", readPlan.Steps[1].Params.(map[string]interface{})["key"])
+
+	// The fields of the package types are compared too.
+	mavenConfig, err := ParseRepositoriesConfig([]byte("local: [{key: libs-local, packageType: maven, description: Old, handleSnapshots: false}]"))
+	assert.NoError(t, err)
+	mavenPlan, err := createRepositoriesPlan(RepositoriesConfigParams{Config: mavenConfig}, testExistingRepositories, getTestCurrentRepository)
+	assert.NoError(t, err)
+	if assert.Len(t, mavenPlan.Steps, 1) {
+		assert.Equal(t, []RepositoryFieldChange{{Field: "handleSnapshots", Desired: false}}, mavenPlan.Steps[0].Changes)
+		assert.IsType(t, MavenLocalRepositoryParams{}, mavenPlan.Steps[0].Params)
+	}
+
+	// Only the local, remote, virtual and federated repositories are pruned, and the virtual repositories are deleted first.
+	plan, err = createRepositoriesPlan(RepositoriesConfigParams{Config: config, Prune: true}, testExistingRepositories, getTestCurrentRepository)
+	assert.NoError(t, err)
+	if assert.Len(t, plan.Steps, 5) {
+		assert.Equal(t, RepositoryPlanStep{Action: DeleteRepositoryAction, Key: "old-virtual", Rclass: VirtualRepositoryRepoType}, plan.Steps[3])
+		assert.Equal(t, RepositoryPlanStep{Action: DeleteRepositoryAction, Key: "old-local", Rclass: LocalRepositoryRepoType}, plan.Steps[4])
+	}
+}
+
+func TestCreateRepositoriesPlanErrors(t *testing.T) {
+	for _, configYaml := range []string{
+		// The class of an existing repository can't be changed.
+		"virtual: [{key: libs-local, packageType: maven}]",
+		"local: [{key: a}, {key: a}]",
+		"local: [{key: a}]\nvirtual: [{key: a}]",
+		"virtual: [{key: a}, {key: a}]",
+		"local: [{packageType: maven}]",
+		"virtual: [{key: a, repositories: [b]}, {key: b, repositories: [a]}]",
+	} {
+		config, err := ParseRepositoriesConfig([]byte(configYaml))
+		assert.NoError(t, err)
+		_, err = createRepositoriesPlan(RepositoriesConfigParams{Config: config}, testExistingRepositories, getTestCurrentRepository)
+		assert.Error(t, err, configYaml)
+	}
+}
+
+func TestDiffRepositoryParams(t *testing.T) {
+	enabled, disabled := true, false
+	desired := RemoteRepositoryBaseParams{Url: "https://example.com", ContentSynchronisation: &ContentSynchronisation{Enabled: &enabled}}
+	current := RemoteRepositoryBaseParams{Url: "https://example.com", ContentSynchronisation: &ContentSynchronisation{Enabled: &disabled}}
+	changes, err := diffRepositoryParams(desired, current)
+	assert.NoError(t, err)
+	assert.Equal(t, []RepositoryFieldChange{{Field: "contentSynchronisation.enabled", Current: false, Desired: true}}, changes)
+}
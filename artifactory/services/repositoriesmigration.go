@@ -0,0 +1,630 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+type RepositoryImportStatus string
+
+const (
+	RepositoryImportCreated RepositoryImportStatus = "created"
+	RepositoryImportUpdated RepositoryImportStatus = "updated"
+	RepositoryImportSkipped RepositoryImportStatus = "skipped"
+	RepositoryImportFailed  RepositoryImportStatus = "failed"
+)
+
+// Matches placeholders like "${maven-remote.password}".
+var credentialsPlaceholderRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// The fields which hold names of key pairs.
+var keyPairRefFields = []string{"primaryKeyPairRef", "secondaryKeyPairRef", "keyPair"}
+
+func newTypedRepositoryParams[T any](newParams func() T) func() interface{} {
+	return func() interface{} {
+		params := newParams()
+		return &params
+	}
+}
+
+// The constructors of the typed params of each repository class, by package type.
+var typedRepositoryParams = map[string]map[string]func() interface{}{
+	LocalRepositoryRepoType: {
+		"alpine":    newTypedRepositoryParams(NewAlpineLocalRepositoryParams),
+		"bower":     newTypedRepositoryParams(NewBowerLocalRepositoryParams),
+		"cargo":     newTypedRepositoryParams(NewCargoLocalRepositoryParams),
+		"chef":      newTypedRepositoryParams(NewChefLocalRepositoryParams),
+		"cocoapods": newTypedRepositoryParams(NewCocoapodsLocalRepositoryParams),
+		"composer":  newTypedRepositoryParams(NewComposerLocalRepositoryParams),
+		"conan":     newTypedRepositoryParams(NewConanLocalRepositoryParams),
+		"conda":     newTypedRepositoryParams(NewCondaLocalRepositoryParams),
+		"cran":      newTypedRepositoryParams(NewCranLocalRepositoryParams),
+		"debian":    newTypedRepositoryParams(NewDebianLocalRepositoryParams),
+		"docker":    newTypedRepositoryParams(NewDockerLocalRepositoryParams),
+		"gems":      newTypedRepositoryParams(NewGemsLocalRepositoryParams),
+		"generic":   newTypedRepositoryParams(NewGenericLocalRepositoryParams),
+		"gitlfs":    newTypedRepositoryParams(NewGitlfsLocalRepositoryParams),
+		"go":        newTypedRepositoryParams(NewGoLocalRepositoryParams),
+		"gradle":    newTypedRepositoryParams(NewGradleLocalRepositoryParams),
+		"helm":      newTypedRepositoryParams(NewHelmLocalRepositoryParams),
+		"ivy":       newTypedRepositoryParams(NewIvyLocalRepositoryParams),
+		"maven":     newTypedRepositoryParams(NewMavenLocalRepositoryParams),
+		"npm":       newTypedRepositoryParams(NewNpmLocalRepositoryParams),
+		"nuget":     newTypedRepositoryParams(NewNugetLocalRepositoryParams),
+		"opkg":      newTypedRepositoryParams(NewOpkgLocalRepositoryParams),
+		"puppet":    newTypedRepositoryParams(NewPuppetLocalRepositoryParams),
+		"pypi":      newTypedRepositoryParams(NewPypiLocalRepositoryParams),
+		"rpm":       newTypedRepositoryParams(NewRpmLocalRepositoryParams),
+		"sbt":       newTypedRepositoryParams(NewSbtLocalRepositoryParams),
+		"swift":     newTypedRepositoryParams(NewSwiftLocalRepositoryParams),
+		"vagrant":   newTypedRepositoryParams(NewVagrantLocalRepositoryParams),
+		"yum":       newTypedRepositoryParams(NewYumLocalRepositoryParams),
+	},
+	RemoteRepositoryRepoType: {
+		"alpine":    newTypedRepositoryParams(NewAlpineRemoteRepositoryParams),
+		"bower":     newTypedRepositoryParams(NewBowerRemoteRepositoryParams),
+		"cargo":     newTypedRepositoryParams(NewCargoRemoteRepositoryParams),
+		"chef":      newTypedRepositoryParams(NewChefRemoteRepositoryParams),
+		"cocoapods": newTypedRepositoryParams(NewCocoapodsRemoteRepositoryParams),
+		"composer":  newTypedRepositoryParams(NewComposerRemoteRepositoryParams),
+		"conan":     newTypedRepositoryParams(NewConanRemoteRepositoryParams),
+		"conda":     newTypedRepositoryParams(NewCondaRemoteRepositoryParams),
+		"cran":      newTypedRepositoryParams(NewCranRemoteRepositoryParams),
+		"debian":    newTypedRepositoryParams(NewDebianRemoteRepositoryParams),
+		"docker":    newTypedRepositoryParams(NewDockerRemoteRepositoryParams),
+		"gems":      newTypedRepositoryParams(NewGemsRemoteRepositoryParams),
+		"generic":   newTypedRepositoryParams(NewGenericRemoteRepositoryParams),
+		"gitlfs":    newTypedRepositoryParams(NewGitlfsRemoteRepositoryParams),
+		"go":        newTypedRepositoryParams(NewGoRemoteRepositoryParams),
+		"gradle":    newTypedRepositoryParams(NewGradleRemoteRepositoryParams),
+		"helm":      newTypedRepositoryParams(NewHelmRemoteRepositoryParams),
+		"ivy":       newTypedRepositoryParams(NewIvyRemoteRepositoryParams),
+		"maven":     newTypedRepositoryParams(NewMavenRemoteRepositoryParams),
+		"npm":       newTypedRepositoryParams(NewNpmRemoteRepositoryParams),
+		"nuget":     newTypedRepositoryParams(NewNugetRemoteRepositoryParams),
+		"opkg":      newTypedRepositoryParams(NewOpkgRemoteRepositoryParams),
+		"puppet":    newTypedRepositoryParams(NewPuppetRemoteRepositoryParams),
+		"pypi":      newTypedRepositoryParams(NewPypiRemoteRepositoryParams),
+		"rpm":       newTypedRepositoryParams(NewRpmRemoteRepositoryParams),
+		"sbt":       newTypedRepositoryParams(NewSbtRemoteRepositoryParams),
+		"swift":     newTypedRepositoryParams(NewSwiftRemoteRepositoryParams),
+		"vcs":       newTypedRepositoryParams(NewVcsRemoteRepositoryParams),
+		"yum":       newTypedRepositoryParams(NewYumRemoteRepositoryParams),
+	},
+	VirtualRepositoryRepoType: {
+		"alpine":  newTypedRepositoryParams(NewAlpineVirtualRepositoryParams),
+		"bower":   newTypedRepositoryParams(NewBowerVirtualRepositoryParams),
+		"chef":    newTypedRepositoryParams(NewChefVirtualRepositoryParams),
+		"conan":   newTypedRepositoryParams(NewConanVirtualRepositoryParams),
+		"conda":   newTypedRepositoryParams(NewCondaVirtualRepositoryParams),
+		"cran":    newTypedRepositoryParams(NewCranVirtualRepositoryParams),
+		"debian":  newTypedRepositoryParams(NewDebianVirtualRepositoryParams),
+		"docker":  newTypedRepositoryParams(NewDockerVirtualRepositoryParams),
+		"gems":    newTypedRepositoryParams(NewGemsVirtualRepositoryParams),
+		"generic": newTypedRepositoryParams(NewGenericVirtualRepositoryParams),
+		"gitlfs":  newTypedRepositoryParams(NewGitlfsVirtualRepositoryParams),
+		"go":      newTypedRepositoryParams(NewGoVirtualRepositoryParams),
+		"gradle":  newTypedRepositoryParams(NewGradleVirtualRepositoryParams),
+		"helm":    newTypedRepositoryParams(NewHelmVirtualRepositoryParams),
+		"ivy":     newTypedRepositoryParams(NewIvyVirtualRepositoryParams),
+		"maven":   newTypedRepositoryParams(NewMavenVirtualRepositoryParams),
+		"npm":     newTypedRepositoryParams(NewNpmVirtualRepositoryParams),
+		"nuget":   newTypedRepositoryParams(NewNugetVirtualRepositoryParams),
+		"puppet":  newTypedRepositoryParams(NewPuppetVirtualRepositoryParams),
+		"pypi":    newTypedRepositoryParams(NewPypiVirtualRepositoryParams),
+		"rpm":     newTypedRepositoryParams(NewRpmVirtualRepositoryParams),
+		"sbt":     newTypedRepositoryParams(NewSbtVirtualRepositoryParams),
+		"swift":   newTypedRepositoryParams(NewSwiftVirtualRepositoryParams),
+		"yum":     newTypedRepositoryParams(NewYumVirtualRepositoryParams),
+	},
+	FederatedRepositoryRepoType: {
+		"alpine":    newTypedRepositoryParams(NewAlpineFederatedRepositoryParams),
+		"bower":     newTypedRepositoryParams(NewBowerFederatedRepositoryParams),
+		"cargo":     newTypedRepositoryParams(NewCargoFederatedRepositoryParams),
+		"chef":      newTypedRepositoryParams(NewChefFederatedRepositoryParams),
+		"cocoapods": newTypedRepositoryParams(NewCocoapodsFederatedRepositoryParams),
+		"composer":  newTypedRepositoryParams(NewComposerFederatedRepositoryParams),
+		"conan":     newTypedRepositoryParams(NewConanFederatedRepositoryParams),
+		"conda":     newTypedRepositoryParams(NewCondaFederatedRepositoryParams),
+		"cran":      newTypedRepositoryParams(NewCranFederatedRepositoryParams),
+		"debian":    newTypedRepositoryParams(NewDebianFederatedRepositoryParams),
+		"docker":    newTypedRepositoryParams(NewDockerFederatedRepositoryParams),
+		"gems":      newTypedRepositoryParams(NewGemsFederatedRepositoryParams),
+		"generic":   newTypedRepositoryParams(NewGenericFederatedRepositoryParams),
+		"gitlfs":    newTypedRepositoryParams(NewGitlfsFederatedRepositoryParams),
+		"go":        newTypedRepositoryParams(NewGoFederatedRepositoryParams),
+		"gradle":    newTypedRepositoryParams(NewGradleFederatedRepositoryParams),
+		"helm":      newTypedRepositoryParams(NewHelmFederatedRepositoryParams),
+		"ivy":       newTypedRepositoryParams(NewIvyFederatedRepositoryParams),
+		"maven":     newTypedRepositoryParams(NewMavenFederatedRepositoryParams),
+		"npm":       newTypedRepositoryParams(NewNpmFederatedRepositoryParams),
+		"nuget":     newTypedRepositoryParams(NewNugetFederatedRepositoryParams),
+		"opkg":      newTypedRepositoryParams(NewOpkgFederatedRepositoryParams),
+		"puppet":    newTypedRepositoryParams(NewPuppetFederatedRepositoryParams),
+		"pypi":      newTypedRepositoryParams(NewPypiFederatedRepositoryParams),
+		"rpm":       newTypedRepositoryParams(NewRpmFederatedRepositoryParams),
+		"sbt":       newTypedRepositoryParams(NewSbtFederatedRepositoryParams),
+		"swift":     newTypedRepositoryParams(NewSwiftFederatedRepositoryParams),
+		"vagrant":   newTypedRepositoryParams(NewVagrantFederatedRepositoryParams),
+		"yum":       newTypedRepositoryParams(NewYumFederatedRepositoryParams),
+	},
+}
+
+// The params of the repositories whose package types have no typed params.
+var baseRepositoryParams = map[string]func() interface{}{
+	LocalRepositoryRepoType:     newTypedRepositoryParams(NewLocalRepositoryBaseParams),
+	RemoteRepositoryRepoType:    newTypedRepositoryParams(NewRemoteRepositoryBaseParams),
+	VirtualRepositoryRepoType:   newTypedRepositoryParams(NewVirtualRepositoryBaseParams),
+	FederatedRepositoryRepoType: newTypedRepositoryParams(NewFederatedRepositoryBaseParams),
+}
+
+// Returns a pointer to new typed params of the repository class and package type, like *MavenLocalRepositoryParams.
+func getTypedRepositoryParams(rclass, packageType string) (interface{}, error) {
+	byPackageType, ok := typedRepositoryParams[rclass]
+	if !ok {
+		return nil, errorutils.CheckErrorf("unsupported repository class: %s", rclass)
+	}
+	if newParams, ok := byPackageType[strings.ToLower(packageType)]; ok {
+		return newParams(), nil
+	}
+	log.Warn(fmt.Sprintf("There are no typed params of %s %s repositories. Only their common fields are migrated.", packageType, rclass))
+	return baseRepositoryParams[rclass](), nil
+}
+
+type RepositoriesExportParams struct {
+	// The directory the repositories are exported to, as a JSON file per repository, named by its key.
+	TargetDir string
+	// Only the repositories of the filter's class and package type are exported. All of them by default.
+	Filter RepositoriesFilterParams
+}
+
+func NewRepositoriesExportParams() RepositoriesExportParams {
+	return RepositoriesExportParams{}
+}
+
+// Maps the references to the source instance, which the exported repositories contain, to the target instance.
+type RepositoriesRemapping struct {
+	// Project keys of the source instance, mapped to project keys of the target instance.
+	// The repositories of projects which are mapped to an empty key are imported without a project.
+	ProjectKeys map[string]string
+	// URL prefixes, which are replaced in the URLs of remote repositories and of federated repositories' members.
+	// The longest matching prefix is replaced.
+	Urls map[string]string
+	// The values of credentials placeholders by their names. An exported remote repository with credentials has
+	// a placeholder instead of its password, like "${maven-remote.password}", whose name is "maven-remote.password".
+	// Usernames and passwords with placeholders which have no values are omitted.
+	Credentials map[string]string
+	// Key pair names of the source instance, mapped to key pair names of the target instance.
+	KeyPairRefs map[string]string
+}
+
+type RepositoriesImportParams struct {
+	// The directory the repositories were exported to.
+	SourceDir string
+	Remapping RepositoriesRemapping
+	// Update the repositories which exist on the target instance. Otherwise, they are skipped.
+	Update bool
+	// Retry the repositories whose fields were rejected by the target instance, without these fields.
+	DropRejectedFields bool
+}
+
+func NewRepositoriesImportParams() RepositoriesImportParams {
+	return RepositoriesImportParams{}
+}
+
+type RepositoryImportResult struct {
+	Key    string                 `json:"key,omitempty"`
+	Rclass string                 `json:"rclass,omitempty"`
+	Status RepositoryImportStatus `json:"status,omitempty"`
+	// The fields which the target instance rejected. If DropRejectedFields is set, the repository was retried without them.
+	RejectedFields []string `json:"rejectedFields,omitempty"`
+	// The names of the credentials placeholders which had no values.
+	UnresolvedPlaceholders []string `json:"unresolvedPlaceholders,omitempty"`
+	Error                  string   `json:"error,omitempty"`
+}
+
+type RepositoriesImportReport struct {
+	Results []RepositoryImportResult `json:"results,omitempty"`
+}
+
+func (rir *RepositoriesImportReport) HasFailures() bool {
+	for _, result := range rir.Results {
+		if result.Status == RepositoryImportFailed {
+			return true
+		}
+	}
+	return false
+}
+
+type RepositoriesMigrationService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewRepositoriesMigrationService(client *jfroghttpclient.JfrogHttpClient) *RepositoriesMigrationService {
+	return &RepositoriesMigrationService{client: client}
+}
+
+func (rms *RepositoriesMigrationService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return rms.client
+}
+
+func (rms *RepositoriesMigrationService) getRepositoriesService() *RepositoriesService {
+	return &RepositoriesService{client: rms.client, ArtDetails: rms.ArtDetails}
+}
+
+// Exports the full configuration of the local, remote, virtual and federated repositories to files, and returns their paths.
+func (rms *RepositoriesMigrationService) Export(params RepositoriesExportParams) ([]string, error) {
+	if params.TargetDir == "" {
+		return nil, errorutils.CheckErrorf("no target directory was given")
+	}
+	repositoriesService := rms.getRepositoriesService()
+	repos, err := repositoriesService.GetWithFilter(params.Filter)
+	if err != nil {
+		return nil, err
+	}
+	if err = fileutils.CreateDirIfNotExist(params.TargetDir); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, repo := range *repos {
+		rclass := strings.ToLower(repo.GetRepoType())
+		if _, ok := typedRepositoryParams[rclass]; !ok {
+			log.Debug(fmt.Sprintf("Skipping the %s repository '%s'.", rclass, repo.Key))
+			continue
+		}
+		log.Info("Exporting repository '" + repo.Key + "'...")
+		fields, err := exportRepository(repo.Key, rclass, repo.PackageType, repositoriesService.Get)
+		if err != nil {
+			return paths, err
+		}
+		content, err := json.MarshalIndent(fields, "", "  ")
+		if err != nil {
+			return paths, errorutils.CheckError(err)
+		}
+		path := filepath.Join(params.TargetDir, repo.Key+".json")
+		if err = os.WriteFile(path, content, 0600); err != nil {
+			return paths, errorutils.CheckError(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// Reads the repository into its typed params, and returns its fields. The password of a remote repository with credentials
+// is replaced by a placeholder, since Artifactory doesn't return it.
+func exportRepository(repoKey, rclass, packageType string, getCurrent func(repoKey string, repoDetails interface{}) error) (map[string]interface{}, error) {
+	params, err := getTypedRepositoryParams(rclass, packageType)
+	if err != nil {
+		return nil, err
+	}
+	if err = getCurrent(repoKey, params); err != nil {
+		return nil, err
+	}
+	fields, err := toJsonMap(params)
+	if err != nil {
+		return nil, err
+	}
+	if rclass == RemoteRepositoryRepoType && (fields["username"] != nil || fields["password"] != nil) {
+		fields["password"] = "${" + repoKey + ".password}"
+	}
+	return fields, nil
+}
+
+// Creates the exported repositories on this instance, after remapping them. The local, remote and federated repositories
+// are imported first, then the virtual repositories, each after the virtual repositories it includes.
+// Repositories which fail are reported, and don't stop the import.
+func (rms *RepositoriesMigrationService) Import(params RepositoriesImportParams) (*RepositoriesImportReport, error) {
+	repos, err := readExportedRepositories(params.SourceDir)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := rms.getRepositoriesService().GetAll()
+	if err != nil {
+		return nil, err
+	}
+	existingKeys := map[string]bool{}
+	for _, repo := range *existing {
+		existingKeys[repo.Key] = true
+	}
+	return importRepositories(params, repos, existingKeys, rms.sendRepository), nil
+}
+
+type exportedRepository struct {
+	key    string
+	rclass string
+	fields map[string]interface{}
+}
+
+// Reads the exported repositories in the order they are imported.
+func readExportedRepositories(dir string) ([]exportedRepository, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var repos []exportedRepository
+	virtualByKey := map[string]exportedRepository{}
+	var virtualKeys []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		repo, err := parseExportedRepository(content)
+		if err != nil {
+			return nil, errorutils.CheckErrorf("failed to read the exported repository %s: %s", entry.Name(), err.Error())
+		}
+		if repo.rclass != VirtualRepositoryRepoType {
+			repos = append(repos, repo)
+			continue
+		}
+		virtualByKey[repo.key] = repo
+		virtualKeys = append(virtualKeys, repo.key)
+	}
+	sortedKeys, err := sortByIncludedRepositories(virtualKeys, func(key string) []string {
+		included, _ := virtualByKey[key].fields["repositories"].([]interface{})
+		keys := make([]string, 0, len(included))
+		for _, includedKey := range included {
+			keys = append(keys, fmt.Sprint(includedKey))
+		}
+		return keys
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys {
+		repos = append(repos, virtualByKey[key])
+	}
+	return repos, nil
+}
+
+// Reads the exported repository into its typed params, so that only the fields of its class and package type are imported.
+func parseExportedRepository(content []byte) (exportedRepository, error) {
+	base := RepositoryBaseParams{}
+	if err := json.Unmarshal(content, &base); err != nil {
+		return exportedRepository{}, err
+	}
+	if base.Key == "" {
+		return exportedRepository{}, errors.New("the repository has no key")
+	}
+	params, err := getTypedRepositoryParams(base.Rclass, base.PackageType)
+	if err != nil {
+		return exportedRepository{}, err
+	}
+	if err = json.Unmarshal(content, params); err != nil {
+		return exportedRepository{}, err
+	}
+	fields, err := toJsonMap(params)
+	return exportedRepository{key: base.Key, rclass: base.Rclass, fields: fields}, err
+}
+
+// Sends the repository to Artifactory. A repository which Artifactory rejects fails with a *repositoryRejectedError.
+type repositorySender func(repoKey string, fields map[string]interface{}, update bool) error
+
+func importRepositories(params RepositoriesImportParams, repos []exportedRepository, existingKeys map[string]bool, send repositorySender) *RepositoriesImportReport {
+	report := &RepositoriesImportReport{}
+	remappedKeys := remapRepositoryKeys(repos, params.Remapping.ProjectKeys)
+	for _, repo := range repos {
+		repoKey := repo.key
+		if remappedKey, ok := remappedKeys[repoKey]; ok {
+			repoKey = remappedKey
+		}
+		result := RepositoryImportResult{Key: repoKey, Rclass: repo.rclass}
+		update := existingKeys[repoKey]
+		if update && !params.Update {
+			result.Status = RepositoryImportSkipped
+			report.Results = append(report.Results, result)
+			continue
+		}
+		result.UnresolvedPlaceholders = remapRepository(repo.fields, params.Remapping, remappedKeys)
+		err := send(repoKey, repo.fields, update)
+		var rejectedErr *repositoryRejectedError
+		if errors.As(err, &rejectedErr) {
+			result.RejectedFields = rejectedErr.getRejectedFields(repo.fields)
+			if params.DropRejectedFields && len(result.RejectedFields) > 0 {
+				log.Info(fmt.Sprintf("Retrying repository '%s' without the rejected fields: %s", repoKey, strings.Join(result.RejectedFields, ", ")))
+				for _, field := range result.RejectedFields {
+					delete(repo.fields, field)
+				}
+				err = send(repoKey, repo.fields, update)
+			}
+		}
+		switch {
+		case err != nil:
+			result.Status = RepositoryImportFailed
+			result.Error = err.Error()
+		case update:
+			result.Status = RepositoryImportUpdated
+		default:
+			result.Status = RepositoryImportCreated
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report
+}
+
+// The keys of the repositories of a project start with the project key, so the keys of the repositories of remapped
+// projects are remapped too, like "src-libs" of project "src" to "dst-libs" of project "dst". Returns the remapped keys
+// by the exported keys. Repositories which are imported without a project keep their keys.
+func remapRepositoryKeys(repos []exportedRepository, projectKeys map[string]string) map[string]string {
+	remappedKeys := map[string]string{}
+	for _, repo := range repos {
+		projectKey, _ := repo.fields["projectKey"].(string)
+		mapped := projectKeys[projectKey]
+		if projectKey == "" || mapped == "" || !strings.HasPrefix(repo.key, projectKey+"-") {
+			continue
+		}
+		remappedKeys[repo.key] = mapped + "-" + strings.TrimPrefix(repo.key, projectKey+"-")
+	}
+	return remappedKeys
+}
+
+// Remaps the fields of the repository, and returns the names of the credentials placeholders which had no values.
+// remappedKeys are the keys of the repositories of remapped projects, which replace the key of the repository and
+// the keys of the repositories it references.
+func remapRepository(fields map[string]interface{}, remapping RepositoriesRemapping, remappedKeys map[string]string) []string {
+	if projectKey, ok := fields["projectKey"].(string); ok {
+		if mapped, ok := remapping.ProjectKeys[projectKey]; ok {
+			if mapped == "" {
+				delete(fields, "projectKey")
+			} else {
+				fields["projectKey"] = mapped
+			}
+		}
+	}
+	for _, field := range []string{"key", "defaultDeploymentRepo"} {
+		if mapped, ok := remappedKeys[fmt.Sprint(fields[field])]; ok {
+			fields[field] = mapped
+		}
+	}
+	if included, ok := fields["repositories"].([]interface{}); ok {
+		for i, includedKey := range included {
+			if mapped, ok := remappedKeys[fmt.Sprint(includedKey)]; ok {
+				included[i] = mapped
+			}
+		}
+	}
+	if repoUrl, ok := fields["url"].(string); ok {
+		fields["url"] = remapUrl(repoUrl, remapping.Urls)
+	}
+	if members, ok := fields["members"].([]interface{}); ok {
+		for _, member := range members {
+			if memberFields, ok := member.(map[string]interface{}); ok {
+				if memberUrl, ok := memberFields["url"].(string); ok {
+					memberFields["url"] = remapUrl(memberUrl, remapping.Urls)
+				}
+			}
+		}
+	}
+	for _, field := range keyPairRefFields {
+		if keyPair, ok := fields[field].(string); ok {
+			if mapped, ok := remapping.KeyPairRefs[keyPair]; ok {
+				fields[field] = mapped
+			}
+		}
+	}
+	var unresolved []string
+	for _, field := range []string{"username", "password"} {
+		value, ok := fields[field].(string)
+		if !ok {
+			continue
+		}
+		resolved := true
+		fields[field] = credentialsPlaceholderRegexp.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := credentialsPlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+			credentials, ok := remapping.Credentials[name]
+			if !ok {
+				resolved = false
+				unresolved = append(unresolved, name)
+			}
+			return credentials
+		})
+		if !resolved {
+			delete(fields, field)
+		}
+	}
+	return unresolved
+}
+
+func remapUrl(repoUrl string, urls map[string]string) string {
+	longestPrefix := ""
+	for prefix := range urls {
+		if strings.HasPrefix(repoUrl, prefix) && len(prefix) > len(longestPrefix) {
+			longestPrefix = prefix
+		}
+	}
+	if longestPrefix == "" {
+		return repoUrl
+	}
+	return urls[longestPrefix] + strings.TrimPrefix(repoUrl, longestPrefix)
+}
+
+func (rms *RepositoriesMigrationService) sendRepository(repoKey string, fields map[string]interface{}, update bool) error {
+	content, err := json.Marshal(fields)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	httpClientsDetails := rms.ArtDetails.CreateHttpClientDetails()
+	utils.SetContentType("application/json", &httpClientsDetails.Headers)
+	repoUrl := rms.ArtDetails.GetUrl() + "api/repositories/" + url.PathEscape(repoKey)
+	var resp *http.Response
+	var body []byte
+	if update {
+		log.Info("Updating repository '" + repoKey + "'...")
+		resp, body, err = rms.client.SendPost(repoUrl, content, &httpClientsDetails)
+	} else {
+		log.Info("Creating repository '" + repoKey + "'...")
+		resp, body, err = rms.client.SendPut(repoUrl, content, &httpClientsDetails)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errorutils.CheckError(newRepositoryRejectedError(resp.Status, body))
+	}
+	log.Debug("Artifactory response:", resp.Status)
+	return nil
+}
+
+// The error of a repository which Artifactory rejected, with the messages of its response.
+type repositoryRejectedError struct {
+	status   string
+	messages []string
+}
+
+// Reads the messages of an Artifactory error response, like {"errors":[{"status":400,"message":"..."}]}.
+// Responses of other forms are kept as they are.
+func newRepositoryRejectedError(status string, body []byte) *repositoryRejectedError {
+	rejectedErr := &repositoryRejectedError{status: status}
+	response := struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}{}
+	if json.Unmarshal(body, &response) == nil {
+		for _, responseErr := range response.Errors {
+			rejectedErr.messages = append(rejectedErr.messages, responseErr.Message)
+		}
+	}
+	if len(rejectedErr.messages) == 0 && len(body) > 0 {
+		rejectedErr.messages = []string{string(body)}
+	}
+	return rejectedErr
+}
+
+func (rre *repositoryRejectedError) Error() string {
+	return errorutils.GenerateResponseError(rre.status, strings.Join(rre.messages, "\n")).Error()
+}
+
+// Returns the fields of the repository which the messages mention in quotes, like: Unrecognized field "xrayIndex".
+func (rre *repositoryRejectedError) getRejectedFields(fields map[string]interface{}) []string {
+	var rejected []string
+	for field := range fields {
+		for _, message := range rre.messages {
+			if strings.Contains(message, `"`+field+`"`) || strings.Contains(message, "'"+field+"'") || strings.Contains(message, "`"+field+"`") {
+				rejected = append(rejected, field)
+				break
+			}
+		}
+	}
+	sort.Strings(rejected)
+	return rejected
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTypedRepositoryParams(t *testing.T) {
+	params, err := getTypedRepositoryParams(LocalRepositoryRepoType, "Maven")
+	assert.NoError(t, err)
+	assert.IsType(t, &MavenLocalRepositoryParams{}, params)
+
+	params, err = getTypedRepositoryParams(RemoteRepositoryRepoType, "unknown")
+	assert.NoError(t, err)
+	assert.IsType(t, &RemoteRepositoryBaseParams{}, params)
+
+	_, err = getTypedRepositoryParams("releasebundles", "generic")
+	assert.Error(t, err)
+}
+
+func TestExportRepository(t *testing.T) {
+	getCurrent := func(repoKey string, repoDetails interface{}) error {
+		return json.Unmarshal([]byte(`{"key":"maven-remote","rclass":"remote","packageType":"maven","url":"https://example.com",`+
+			`"username":"admin","password":"","fetchJarsEagerly":true,"unknownField":"x"}`), repoDetails)
+	}
+	fields, err := exportRepository("maven-remote", RemoteRepositoryRepoType, "Maven", getCurrent)
+	assert.NoError(t, err)
+	// The password is replaced by a placeholder, and the package-specific fields are kept.
+	assert.Equal(t, "${maven-remote.password}", fields["password"])
+	assert.Equal(t, true, fields["fetchJarsEagerly"])
+	assert.NotContains(t, fields, "unknownField")
+}
+
+func TestReadExportedRepositories(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a-virtual.json":  `{"key":"a-virtual","rclass":"virtual","packageType":"maven","repositories":["b-virtual"]}`,
+		"b-virtual.json":  `{"key":"b-virtual","rclass":"virtual","packageType":"maven","repositories":["libs-local"]}`,
+		"libs-local.json": `{"key":"libs-local","rclass":"local","packageType":"maven","handleSnapshots":false}`,
+		"notes.txt":       `not a repository`,
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	repos, err := readExportedRepositories(dir)
+	assert.NoError(t, err)
+	var keys []string
+	for _, repo := range repos {
+		keys = append(keys, repo.key)
+	}
+	// The virtual repositories are read after the repositories they include.
+	assert.Equal(t, []string{"libs-local", "b-virtual", "a-virtual"}, keys)
+	assert.Equal(t, false, repos[0].fields["handleSnapshots"])
+}
+
+func TestRemapRepository(t *testing.T) {
+	fields := map[string]interface{}{
+		"projectKey":        "src",
+		"url":               "https://source.example.com/artifactory/api/npm/npm",
+		"username":          "admin",
+		"password":          "${npm-remote.password}",
+		"primaryKeyPairRef": "old-pair",
+		"members":           []interface{}{map[string]interface{}{"url": "https://source.example.com/artifactory/fed"}},
+	}
+	remapping := RepositoriesRemapping{
+		ProjectKeys: map[string]string{"src": "dst"},
+		Urls:        map[string]string{"https://source.example.com": "https://other", "https://source.example.com/artifactory": "https://target.example.com/artifactory"},
+		KeyPairRefs: map[string]string{"old-pair": "new-pair"},
+	}
+	unresolved := remapRepository(fields, remapping, nil)
+	assert.Equal(t, []string{"npm-remote.password"}, unresolved)
+	assert.NotContains(t, fields, "password")
+	assert.Equal(t, "admin", fields["username"])
+	assert.Equal(t, "dst", fields["projectKey"])
+	assert.Equal(t, "https://target.example.com/artifactory/api/npm/npm", fields["url"])
+	assert.Equal(t, "https://target.example.com/artifactory/fed", fields["members"].([]interface{})[0].(map[string]interface{})["url"])
+	assert.Equal(t, "new-pair", fields["primaryKeyPairRef"])
+
+	fields = map[string]interface{}{"projectKey": "src", "password": "${npm-remote.password}"}
+	remapping = RepositoriesRemapping{ProjectKeys: map[string]string{"src": ""}, Credentials: map[string]string{"npm-remote.password": "secret"}}
+	assert.Empty(t, remapRepository(fields, remapping, nil))
+	assert.Equal(t, map[string]interface{}{"password": "secret"}, fields)
+}
+
+func TestRemapRepositoryKeys(t *testing.T) {
+	repos := []exportedRepository{
+		{key: "src-libs-local", fields: map[string]interface{}{"key": "src-libs-local", "projectKey": "src"}},
+		{key: "src-libs", fields: map[string]interface{}{"key": "src-libs", "projectKey": "src",
+			"repositories": []interface{}{"src-libs-local", "maven-remote"}, "defaultDeploymentRepo": "src-libs-local"}},
+		{key: "maven-remote", fields: map[string]interface{}{"key": "maven-remote"}},
+		{key: "other-local", fields: map[string]interface{}{"key": "other-local", "projectKey": "other"}},
+	}
+	remapping := RepositoriesRemapping{ProjectKeys: map[string]string{"src": "dst", "other": ""}}
+	remappedKeys := remapRepositoryKeys(repos, remapping.ProjectKeys)
+	assert.Equal(t, map[string]string{"src-libs-local": "dst-libs-local", "src-libs": "dst-libs"}, remappedKeys)
+
+	for _, repo := range repos {
+		remapRepository(repo.fields, remapping, remappedKeys)
+	}
+	assert.Equal(t, map[string]interface{}{"key": "dst-libs", "projectKey": "dst",
+		"repositories": []interface{}{"dst-libs-local", "maven-remote"}, "defaultDeploymentRepo": "dst-libs-local"}, repos[1].fields)
+	// Repositories which are removed from their project keep their keys.
+	assert.Equal(t, map[string]interface{}{"key": "other-local"}, repos[3].fields)
+}
+
+func TestImportRepositories(t *testing.T) {
+	repos := []exportedRepository{
+		{key: "libs-local", rclass: LocalRepositoryRepoType, fields: map[string]interface{}{"key": "libs-local", "cdnRedirect": true}},
+		{key: "existing", rclass: LocalRepositoryRepoType, fields: map[string]interface{}{"key": "existing"}},
+		{key: "bad-remote", rclass: RemoteRepositoryRepoType, fields: map[string]interface{}{"key": "bad-remote", "url": "x"}},
+	}
+	var sent []map[string]interface{}
+	send := func(repoKey string, fields map[string]interface{}, update bool) error {
+		if repoKey == "bad-remote" {
+			return newRepositoryRejectedError("400 Bad Request", []byte(`{"errors":[{"status":400,"message":"Invalid URL"}]}`))
+		}
+		if _, ok := fields["cdnRedirect"]; ok {
+			return newRepositoryRejectedError("400 Bad Request", []byte(`{"errors":[{"status":400,"message":"Unrecognized field \"cdnRedirect\""}]}`))
+		}
+		sent = append(sent, fields)
+		return nil
+	}
+	report := importRepositories(RepositoriesImportParams{DropRejectedFields: true}, repos, map[string]bool{"existing": true}, send)
+	assert.Equal(t, []RepositoryImportResult{
+		{Key: "libs-local", Rclass: LocalRepositoryRepoType, Status: RepositoryImportCreated, RejectedFields: []string{"cdnRedirect"}},
+		{Key: "existing", Rclass: LocalRepositoryRepoType, Status: RepositoryImportSkipped},
+		{Key: "bad-remote", Rclass: RemoteRepositoryRepoType, Status: RepositoryImportFailed, Error: "server response: 400 Bad Request\nInvalid URL"},
+	}, report.Results)
+	assert.True(t, report.HasFailures())
+	assert.Equal(t, []map[string]interface{}{{"key": "libs-local"}}, sent)
+}
@@ -16,6 +16,8 @@ type RepositoryService struct {
 	isUpdate   bool
 	client     *jfroghttpclient.JfrogHttpClient
 	ArtDetails auth.ServiceDetails
+	// Send the params without validating them, for fields which the typed params don't have yet.
+	SkipValidation bool
 }
 
 func NewRepositoryService(client *jfroghttpclient.JfrogHttpClient, isUpdate bool) *RepositoryService {
@@ -27,6 +29,11 @@ func (rs *RepositoryService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClie
 }
 
 func (rs *RepositoryService) performRequest(params interface{}, repoKey string) error {
+	if !rs.SkipValidation {
+		if err := ValidateRepositoryParams(repoKey, params, rs.isUpdate); err != nil {
+			return err
+		}
+	}
 	content, err := json.Marshal(params)
 	if errorutils.CheckError(err) != nil {
 		return err
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+// Artifactory limits repository keys to 64 characters, and doesn't allow these characters in them.
+const (
+	maxRepositoryKeyLength      = 64
+	forbiddenRepositoryKeyChars = ` /\:|?*"<>`
+)
+
+// The rules of fields, which apply to the repositories of all classes and package types which have the fields.
+type repositoryFieldRule struct {
+	// The allowed values of a string field.
+	enum []string
+	// The known values of a string field. Since newer Artifactory versions may accept other values, they are only warned about.
+	knownValues []string
+	// The number field must not be negative.
+	nonNegative bool
+	// The string field must be an absolute URL.
+	absoluteUrl bool
+}
+
+var repositoryFieldRules = map[string]repositoryFieldRule{
+	"rclass":                               {enum: []string{LocalRepositoryRepoType, RemoteRepositoryRepoType, VirtualRepositoryRepoType, FederatedRepositoryRepoType}},
+	"snapshotVersionBehavior":              {knownValues: []string{"unique", "non-unique", "deployer"}},
+	"checksumPolicyType":                   {knownValues: []string{"client-checksums", "server-generated-checksums"}},
+	"remoteRepoChecksumPolicyType":         {knownValues: []string{"generate-if-absent", "fail", "ignore-and-generate", "pass-thru"}},
+	"pomRepositoryReferencesCleanupPolicy": {knownValues: []string{"discard_active_reference", "discard_any_reference", "nothing"}},
+	"dockerApiVersion":                     {knownValues: []string{"V1", "V2"}},
+	"vcsType":                              {knownValues: []string{"GIT"}},
+	"vcsGitProvider":                       {knownValues: []string{"GITHUB", "GITHUBENTERPRISE", "BITBUCKET", "OLDSTASH", "STASH", "ARTIFACTORY", "CUSTOM"}},
+	"url":                                  {absoluteUrl: true},
+	"vcsGitDownloadUrl":                    {absoluteUrl: true},
+	"maxUniqueTags":                        {nonNegative: true},
+	"maxUniqueSnapshots":                   {nonNegative: true},
+	"dockerTagRetention":                   {nonNegative: true},
+	"socketTimeoutMillis":                  {nonNegative: true},
+	"retrievalCachePeriodSecs":             {nonNegative: true},
+	"metadataRetrievalTimeoutSecs":         {nonNegative: true},
+	"missedRetrievalCachePeriodSecs":       {nonNegative: true},
+	"unusedArtifactsCleanupPeriodHours":    {nonNegative: true},
+	"assumedOfflinePeriodSecs":             {nonNegative: true},
+	"virtualRetrievalCachePeriodSecs":      {nonNegative: true},
+	"yumRootDepth":                         {nonNegative: true},
+}
+
+// The fields which are required to create repositories of each class.
+var requiredRepositoryFields = map[string][]string{
+	RemoteRepositoryRepoType: {"url"},
+}
+
+// Groups of fields, of which at most one may be set. The key pair of Maven virtual repositories is replaced by the key pair refs.
+var exclusiveRepositoryFields = [][]string{
+	{"keyPair", "primaryKeyPairRef"},
+}
+
+// Fields which may be set only with other fields.
+var dependentRepositoryFields = map[string]string{
+	"secondaryKeyPairRef": "primaryKeyPairRef",
+}
+
+// A rule which the value of a field violates. Nested fields are separated by dots, and indexes of lists are in brackets.
+type RepositoryParamsViolation struct {
+	Field   string
+	Message string
+}
+
+// The error of repository params which violate rules, with all their violations.
+type RepositoryParamsValidationError struct {
+	RepoKey    string
+	Violations []RepositoryParamsViolation
+}
+
+func (rpve *RepositoryParamsValidationError) Error() string {
+	lines := []string{fmt.Sprintf("the params of repository '%s' are invalid:", rpve.RepoKey)}
+	for _, violation := range rpve.Violations {
+		lines = append(lines, fmt.Sprintf("  %s: %s", violation.Field, violation.Message))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validates the params of a repository, before it is created or updated. The fields of repositories whose package types
+// have typed params, like MavenLocalRepositoryParams, must be fields of these params. Updates may omit required fields.
+// Returns a *RepositoryParamsValidationError with all the violations, if there are any. Unknown values of fields,
+// which newer Artifactory versions may accept, are logged as warnings.
+func ValidateRepositoryParams(repoKey string, params interface{}, isUpdate bool) error {
+	fields, err := toJsonMap(params)
+	if err != nil {
+		return err
+	}
+	violations, warnings := validateRepositoryFields(repoKey, fields, isUpdate)
+	for _, warning := range warnings {
+		log.Warn(fmt.Sprintf("The %s of repository '%s' may be invalid: %s", warning.Field, repoKey, warning.Message))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Slice(violations, func(i, j int) bool {
+		if violations[i].Field != violations[j].Field {
+			return violations[i].Field < violations[j].Field
+		}
+		return violations[i].Message < violations[j].Message
+	})
+	return errorutils.CheckError(&RepositoryParamsValidationError{RepoKey: repoKey, Violations: violations})
+}
+
+// Returns the violations of the fields, and the warnings of their unknown values.
+func validateRepositoryFields(repoKey string, fields map[string]interface{}, isUpdate bool) (violations, warnings []RepositoryParamsViolation) {
+	addViolation := func(field, format string, a ...interface{}) {
+		violations = append(violations, RepositoryParamsViolation{Field: field, Message: fmt.Sprintf(format, a...)})
+	}
+	isSet := func(field string) bool {
+		value, ok := fields[field]
+		return ok && value != nil && value != ""
+	}
+
+	switch {
+	case repoKey == "":
+		addViolation("key", "is required")
+	case len(repoKey) > maxRepositoryKeyLength:
+		addViolation("key", "must be at most %d characters long", maxRepositoryKeyLength)
+	case strings.ContainsAny(repoKey, forbiddenRepositoryKeyChars):
+		addViolation("key", "must not contain any of the characters %q", forbiddenRepositoryKeyChars)
+	}
+	if key, ok := fields["key"].(string); ok && key != "" && key != repoKey {
+		addViolation("key", "%q differs from the repository key %q", key, repoKey)
+	}
+
+	rclass, _ := fields["rclass"].(string)
+	packageType, _ := fields["packageType"].(string)
+	if !isUpdate {
+		if rclass == "" {
+			addViolation("rclass", "is required")
+		}
+		for _, field := range requiredRepositoryFields[rclass] {
+			if !isSet(field) {
+				addViolation(field, "is required in %s repositories", rclass)
+			}
+		}
+	}
+	if allowedFields := getAllowedRepositoryFields(rclass, packageType); allowedFields != nil {
+		for field := range fields {
+			if !allowedFields[field] {
+				addViolation(field, "is not a field of %s %s repositories", packageType, rclass)
+			}
+		}
+	}
+
+	for field, value := range fields {
+		if rule, ok := repositoryFieldRules[field]; ok {
+			if message := rule.check(value); message != "" {
+				addViolation(field, message)
+			}
+			if stringValue, ok := value.(string); ok && stringValue != "" && len(rule.knownValues) > 0 && !slices.Contains(rule.knownValues, stringValue) {
+				warnings = append(warnings, RepositoryParamsViolation{Field: field, Message: fmt.Sprintf("%q isn't one of: %s", stringValue, strings.Join(rule.knownValues, ", "))})
+			}
+		}
+	}
+	for _, group := range exclusiveRepositoryFields {
+		var setFields []string
+		for _, field := range group {
+			if isSet(field) {
+				setFields = append(setFields, field)
+			}
+		}
+		for _, field := range setFields[min(len(setFields), 1):] {
+			addViolation(field, "can't be set with %s", setFields[0])
+		}
+	}
+	for field, requiredField := range dependentRepositoryFields {
+		if isSet(field) && !isSet(requiredField) {
+			addViolation(field, "can be set only with %s", requiredField)
+		}
+	}
+	if fields["vcsGitProvider"] == "CUSTOM" && !isSet("vcsGitDownloadUrl") {
+		addViolation("vcsGitDownloadUrl", "is required with the CUSTOM vcsGitProvider")
+	}
+	// Updates which don't set the included repositories keep the current ones, so the default deployment repository isn't checked.
+	if defaultDeploymentRepo, ok := fields["defaultDeploymentRepo"].(string); ok && defaultDeploymentRepo != "" {
+		if repositories, ok := fields["repositories"].([]interface{}); ok && !slices.Contains(repositories, interface{}(defaultDeploymentRepo)) {
+			addViolation("defaultDeploymentRepo", "%q isn't one of the repositories of the virtual repository", defaultDeploymentRepo)
+		}
+	}
+	if members, ok := fields["members"].([]interface{}); ok {
+		for i, member := range members {
+			memberFields, _ := member.(map[string]interface{})
+			if memberUrl, _ := memberFields["url"].(string); !isAbsoluteUrl(memberUrl) {
+				addViolation(fmt.Sprintf("members[%d].url", i), "must be an absolute URL")
+			}
+		}
+	}
+	return
+}
+
+// Returns the violation message of the value, or an empty string if it is valid.
+func (rule repositoryFieldRule) check(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		if typedValue == "" {
+			return ""
+		}
+		if len(rule.enum) > 0 && !slices.Contains(rule.enum, typedValue) {
+			return fmt.Sprintf("%q isn't one of: %s", typedValue, strings.Join(rule.enum, ", "))
+		}
+		if rule.absoluteUrl && !isAbsoluteUrl(typedValue) {
+			return fmt.Sprintf("%q isn't an absolute URL", typedValue)
+		}
+	case float64:
+		if rule.nonNegative && typedValue < 0 {
+			return fmt.Sprintf("%v is negative", typedValue)
+		}
+	}
+	return ""
+}
+
+// Returns the JSON fields of the typed params of the class and package type, or nil if there are no such typed params.
+func getAllowedRepositoryFields(rclass, packageType string) map[string]bool {
+	newParams, ok := typedRepositoryParams[rclass][strings.ToLower(packageType)]
+	if !ok {
+		return nil
+	}
+	allowedFields := map[string]bool{}
+	addJsonFields(reflect.TypeOf(newParams()).Elem(), allowedFields)
+	return allowedFields
+}
+
+// Adds the JSON names of the struct's fields, including the fields of embedded structs.
+func addJsonFields(structType reflect.Type, jsonFields map[string]bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addJsonFields(field.Type, jsonFields)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		jsonFields[name] = true
+	}
+}
+
+func isAbsoluteUrl(value string) bool {
+	parsedUrl, err := url.Parse(value)
+	return err == nil && parsedUrl.Scheme != "" && parsedUrl.Host != ""
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRepositoryParams(t *testing.T) {
+	params := map[string]interface{}{
+		"key":                 "docker-remote",
+		"rclass":              "remote",
+		"packageType":         "docker",
+		"url":                 "registry-1.docker.io",
+		"dockerApiVersion":    "V3",
+		"socketTimeoutMillis": -1,
+		"secondaryKeyPairRef": "pair",
+	}
+	err := ValidateRepositoryParams("docker/remote", params, false)
+	var validationErr *RepositoryParamsValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []RepositoryParamsViolation{
+			{Field: "dockerApiVersion", Message: "is not a field of docker remote repositories"},
+			{Field: "key", Message: `"docker-remote" differs from the repository key "docker/remote"`},
+			{Field: "key", Message: "must not contain any of the characters \" /\\\\:|?*\\\"<>\""},
+			{Field: "secondaryKeyPairRef", Message: "can be set only with primaryKeyPairRef"},
+			{Field: "secondaryKeyPairRef", Message: "is not a field of docker remote repositories"},
+			{Field: "socketTimeoutMillis", Message: "-1 is negative"},
+			{Field: "url", Message: `"registry-1.docker.io" isn't an absolute URL`},
+		}, validationErr.Violations)
+	}
+
+	remoteParams := NewDockerRemoteRepositoryParams()
+	remoteParams.Key = "docker-remote"
+	remoteParams.Url = "https://registry-1.docker.io"
+	assert.NoError(t, ValidateRepositoryParams("docker-remote", remoteParams, false))
+
+	// Values which aren't known are only warned about, since newer Artifactory versions may accept them.
+	localParams := NewDockerLocalRepositoryParams()
+	localParams.Key = "docker-local"
+	localParams.DockerApiVersion = "V3"
+	fields, err := toJsonMap(localParams)
+	assert.NoError(t, err)
+	violations, warnings := validateRepositoryFields("docker-local", fields, false)
+	assert.Empty(t, violations)
+	assert.Equal(t, []RepositoryParamsViolation{{Field: "dockerApiVersion", Message: `"V3" isn't one of: V1, V2`}}, warnings)
+	assert.NoError(t, ValidateRepositoryParams("docker-local", localParams, false))
+}
+
+func TestValidateRepositoryParamsRequiredFields(t *testing.T) {
+	remoteParams := NewGenericRemoteRepositoryParams()
+	err := ValidateRepositoryParams("generic-remote", remoteParams, false)
+	assert.EqualError(t, err, "the params of repository 'generic-remote' are invalid:\n  url: is required in remote repositories")
+	// Updates may omit the required fields.
+	assert.NoError(t, ValidateRepositoryParams("generic-remote", remoteParams, true))
+
+	assert.Error(t, ValidateRepositoryParams("generic-local", map[string]interface{}{"packageType": "generic"}, false))
+	// Repositories of package types without typed params, or without package types, may have any fields.
+	assert.NoError(t, ValidateRepositoryParams("generic-local", NewLocalRepositoryBaseParams(), false))
+	assert.NoError(t, ValidateRepositoryParams("any-local", map[string]interface{}{"rclass": "local", "packageType": "new", "newField": 1}, false))
+}
+
+func TestValidateRepositoryParamsRelatedFields(t *testing.T) {
+	virtualParams := NewMavenVirtualRepositoryParams()
+	virtualParams.Repositories = []string{"libs-local"}
+	virtualParams.DefaultDeploymentRepo = "other-local"
+	virtualParams.KeyPair = "pair"
+	virtualParams.PrimaryKeyPairRef = "pair"
+	err := ValidateRepositoryParams("libs", virtualParams, false)
+	var validationErr *RepositoryParamsValidationError
+	if assert.True(t, errors.As(err, &validationErr)) {
+		assert.Equal(t, []RepositoryParamsViolation{
+			{Field: "defaultDeploymentRepo", Message: `"other-local" isn't one of the repositories of the virtual repository`},
+			{Field: "primaryKeyPairRef", Message: "can't be set with keyPair"},
+		}, validationErr.Violations)
+	}
+
+	vcsParams := NewVcsRemoteRepositoryParams()
+	vcsParams.Url = "https://github.com"
+	vcsParams.VcsGitProvider = "CUSTOM"
+	err = ValidateRepositoryParams("vcs-remote", vcsParams, false)
+	assert.EqualError(t, err, "the params of repository 'vcs-remote' are invalid:\n  vcsGitDownloadUrl: is required with the CUSTOM vcsGitProvider")
+
+	federatedParams := NewGenericFederatedRepositoryParams()
+	federatedParams.Members = []FederatedRepositoryMember{{Url: "https://example.com/artifactory/a"}, {Url: "b"}}
+	err = ValidateRepositoryParams("a", federatedParams, false)
+	assert.EqualError(t, err, "the params of repository 'a' are invalid:\n  members[1].url: must be an absolute URL")
+}
@@ -0,0 +1,463 @@
+package services
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/madotis/jfrog-client-go/artifactory/services/fspatterns"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+type SyncDirection string
+
+const (
+	// Local directory is the source, the repository path is the destination.
+	SyncUpload SyncDirection = "upload"
+	// Repository path is the source, the local directory is the destination.
+	SyncDownload SyncDirection = "download"
+)
+
+type SyncActionType string
+
+const (
+	SyncActionUpload    SyncActionType = "upload"
+	SyncActionDownload  SyncActionType = "download"
+	SyncActionDelete    SyncActionType = "delete"
+	SyncActionUnchanged SyncActionType = "unchanged"
+)
+
+const (
+	syncReasonMissing          = "missing on destination"
+	syncReasonSizeDiffers      = "size differs"
+	syncReasonChecksumDiffers  = "checksum differs"
+	syncReasonMissingOnSource  = "missing on source"
+	syncReasonChecksumsMatched = "checksums match"
+)
+
+type SyncService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	artDetails *auth.ServiceDetails
+	Progress   ioutils.ProgressMgr
+	// If set, receives an event for each step of each file's transfer or deletion.
+	Observer ioutils.TransferObserver
+	DryRun   bool
+	Threads  int
+}
+
+func NewSyncService(artDetails auth.ServiceDetails, client *jfroghttpclient.JfrogHttpClient) *SyncService {
+	return &SyncService{artDetails: &artDetails, client: client}
+}
+
+func (ss *SyncService) GetArtifactoryDetails() auth.ServiceDetails {
+	return *ss.artDetails
+}
+
+func (ss *SyncService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return ss.client
+}
+
+func (ss *SyncService) SetTransferObserver(observer ioutils.TransferObserver) {
+	ss.Observer = observer
+}
+
+func (ss *SyncService) IsDryRun() bool {
+	return ss.DryRun
+}
+
+func (ss *SyncService) GetThreads() int {
+	return ss.Threads
+}
+
+func (ss *SyncService) SetThreads(threads int) {
+	ss.Threads = threads
+}
+
+type SyncParams struct {
+	// Local directory to synchronize.
+	LocalPath string
+	// Repository path in the form of repo/path/in/repo.
+	RepoPath  string
+	Direction SyncDirection
+	// Delete files which exist on the destination but not on the source.
+	DeleteExtra bool
+	// Abort the sync before changing anything if more than MaxDeletions files would be deleted. 0 means no limit.
+	MaxDeletions int
+	// Wildcard patterns, relative to LocalPath and RepoPath, of files which are neither transferred nor deleted.
+	Exclusions []string
+}
+
+func NewSyncParams() SyncParams {
+	return SyncParams{Direction: SyncUpload}
+}
+
+// A single decision made while comparing the source and the destination.
+// Paths are relative to SyncParams.LocalPath and SyncParams.RepoPath, separated by '/'.
+type SyncAction struct {
+	Action SyncActionType `json:"action,omitempty"`
+	Path   string         `json:"path,omitempty"`
+	Size   int64          `json:"size,omitempty"`
+	Sha1   string         `json:"sha1,omitempty"`
+	Reason string         `json:"reason,omitempty"`
+}
+
+type SyncSummary struct {
+	// A ContentReader of SyncAction structs, including the unchanged files.
+	ActionsReader    *content.ContentReader
+	TotalTransferred int
+	TotalDeleted     int
+	TotalUnchanged   int
+	TotalFailed      int
+}
+
+func (ss *SyncSummary) Close() error {
+	if ss.ActionsReader != nil {
+		return ss.ActionsReader.Close()
+	}
+	return nil
+}
+
+// Compares the local directory with the repository path and transfers the files which differ.
+// When DryRun is set, only the actions report is returned.
+func (ss *SyncService) SyncFiles(syncParams SyncParams) (summary *SyncSummary, err error) {
+	if err = validateSyncParams(syncParams); err != nil {
+		return nil, err
+	}
+	excludePattern, err := compileSyncExclusions(syncParams.Exclusions)
+	if err != nil {
+		return nil, err
+	}
+	localPath, err := filepath.Abs(syncParams.LocalPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	localFiles, err := listLocalSyncFiles(localPath, excludePattern)
+	if err != nil {
+		return nil, err
+	}
+	remoteFiles, err := ss.listRemoteSyncFiles(syncParams.RepoPath, excludePattern)
+	if err != nil {
+		return nil, err
+	}
+	localSha1Func := func(relativePath string) (string, error) {
+		details, err := fileutils.GetFileDetails(filepath.Join(localPath, filepath.FromSlash(relativePath)), true)
+		if err != nil {
+			return "", err
+		}
+		return details.Checksum.Sha1, nil
+	}
+	actions, err := computeSyncActions(localFiles, remoteFiles, syncParams.Direction, syncParams.DeleteExtra, localSha1Func)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkMaxDeletions(actions, syncParams.MaxDeletions); err != nil {
+		return nil, err
+	}
+
+	summary = &SyncSummary{}
+	if summary.ActionsReader, err = writeSyncActions(actions); err != nil {
+		return nil, err
+	}
+	summary.TotalUnchanged = countSyncActions(actions, SyncActionUnchanged)
+	if ss.DryRun {
+		summary.TotalTransferred = countSyncActions(actions, SyncActionUpload) + countSyncActions(actions, SyncActionDownload)
+		summary.TotalDeleted = countSyncActions(actions, SyncActionDelete)
+		return summary, nil
+	}
+	err = ss.performSyncActions(actions, localPath, syncParams, remoteFiles, summary)
+	return summary, err
+}
+
+func validateSyncParams(syncParams SyncParams) error {
+	if syncParams.LocalPath == "" {
+		return errorutils.CheckErrorf("sync requires a local path")
+	}
+	if strings.Trim(syncParams.RepoPath, "/") == "" {
+		return errorutils.CheckErrorf("sync requires a repository path")
+	}
+	if strings.Contains(syncParams.RepoPath, "*") {
+		return errorutils.CheckErrorf("the sync repository path must not contain wildcards: %s", syncParams.RepoPath)
+	}
+	if syncParams.Direction != SyncUpload && syncParams.Direction != SyncDownload {
+		return errorutils.CheckErrorf("unsupported sync direction: '%s'", syncParams.Direction)
+	}
+	return nil
+}
+
+// Exclusions are matched against paths relative to the synced directories, so both sides share the same pattern.
+// The pattern is a local path pattern, whose separators are the OS separators.
+func compileSyncExclusions(exclusions []string) (*regexp.Regexp, error) {
+	excludePathPattern := fspatterns.PrepareExcludePathPattern(&utils.CommonParams{Exclusions: exclusions, Recursive: true})
+	if excludePathPattern == "" {
+		return nil, nil
+	}
+	excludeRegexp, err := regexp.Compile(excludePathPattern)
+	return excludeRegexp, errorutils.CheckError(err)
+}
+
+// The relative path is slash separated, and is matched with the OS separators of the pattern.
+func isSyncPathExcluded(relativePath string, excludePattern *regexp.Regexp) bool {
+	return excludePattern != nil && excludePattern.MatchString(filepath.FromSlash(relativePath))
+}
+
+// Returns the sizes of all files under rootPath, keyed by their slash separated relative path.
+func listLocalSyncFiles(rootPath string, excludePattern *regexp.Regexp) (map[string]int64, error) {
+	localFiles := make(map[string]int64)
+	exists, err := fileutils.IsDirExists(rootPath, false)
+	if err != nil || !exists {
+		return localFiles, err
+	}
+	err = filepath.WalkDir(rootPath, func(currentPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(rootPath, currentPath)
+		if err != nil {
+			return err
+		}
+		relativePath = filepath.ToSlash(relativePath)
+		if isSyncPathExcluded(relativePath, excludePattern) {
+			log.Debug("The path '" + relativePath + "' is excluded")
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		localFiles[relativePath] = info.Size()
+		return nil
+	})
+	return localFiles, errorutils.CheckError(err)
+}
+
+// Returns the files under repoPath, keyed by their path relative to repoPath.
+func (ss *SyncService) listRemoteSyncFiles(repoPath string, excludePattern *regexp.Regexp) (remoteFiles map[string]utils.ResultItem, err error) {
+	repoPath = strings.Trim(repoPath, "/")
+	subPath := ""
+	if slashIndex := strings.Index(repoPath, "/"); slashIndex >= 0 {
+		subPath = repoPath[slashIndex+1:] + "/"
+	}
+	searchParams := &utils.CommonParams{Pattern: repoPath + "/*", Recursive: true}
+	reader, err := utils.SearchBySpecWithPattern(searchParams, ss, utils.NONE)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e := reader.Close()
+		if err == nil {
+			err = e
+		}
+	}()
+	remoteFiles = make(map[string]utils.ResultItem)
+	for item := new(utils.ResultItem); reader.NextRecord(item) == nil; item = new(utils.ResultItem) {
+		if item.Type == "folder" {
+			continue
+		}
+		relativePath := strings.TrimPrefix(path.Join(item.Path, item.Name), subPath)
+		if isSyncPathExcluded(relativePath, excludePattern) {
+			log.Debug("The path '" + relativePath + "' is excluded")
+			continue
+		}
+		remoteFiles[relativePath] = *item
+	}
+	return remoteFiles, reader.GetError()
+}
+
+// Decides what should happen to every file on both sides.
+// Files with equal sizes are compared by their SHA1 checksum, which is calculated for local files only when needed.
+func computeSyncActions(localFiles map[string]int64, remoteFiles map[string]utils.ResultItem, direction SyncDirection, deleteExtra bool,
+	localSha1Func func(relativePath string) (string, error)) ([]SyncAction, error) {
+	transferAction := SyncActionUpload
+	if direction == SyncDownload {
+		transferAction = SyncActionDownload
+	}
+	var actions []SyncAction
+	for _, relativePath := range sortedSyncKeys(localFiles) {
+		localSize := localFiles[relativePath]
+		remoteItem, existsRemotely := remoteFiles[relativePath]
+		if !existsRemotely {
+			if direction == SyncUpload {
+				actions = append(actions, SyncAction{Action: transferAction, Path: relativePath, Size: localSize, Reason: syncReasonMissing})
+			} else if deleteExtra {
+				actions = append(actions, SyncAction{Action: SyncActionDelete, Path: relativePath, Size: localSize, Reason: syncReasonMissingOnSource})
+			}
+			continue
+		}
+		action := SyncAction{Action: transferAction, Path: relativePath, Size: remoteItem.Size, Sha1: remoteItem.Actual_Sha1}
+		if direction == SyncUpload {
+			// The report describes the source file, whose checksum is calculated only if the sizes match.
+			action.Size, action.Sha1 = localSize, ""
+		}
+		if localSize != remoteItem.Size {
+			action.Reason = syncReasonSizeDiffers
+			actions = append(actions, action)
+			continue
+		}
+		localSha1, err := localSha1Func(relativePath)
+		if err != nil {
+			return nil, err
+		}
+		if direction == SyncUpload {
+			action.Sha1 = localSha1
+		}
+		if localSha1 == remoteItem.Actual_Sha1 {
+			action.Action = SyncActionUnchanged
+			action.Reason = syncReasonChecksumsMatched
+		} else {
+			action.Reason = syncReasonChecksumDiffers
+		}
+		actions = append(actions, action)
+	}
+	for _, relativePath := range sortedSyncKeys(remoteFiles) {
+		if _, existsLocally := localFiles[relativePath]; existsLocally {
+			continue
+		}
+		remoteItem := remoteFiles[relativePath]
+		action := SyncAction{Path: relativePath, Size: remoteItem.Size, Sha1: remoteItem.Actual_Sha1}
+		if direction == SyncDownload {
+			action.Action = transferAction
+			action.Reason = syncReasonMissing
+		} else if deleteExtra {
+			action.Action = SyncActionDelete
+			action.Reason = syncReasonMissingOnSource
+		} else {
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+func sortedSyncKeys[V any](files map[string]V) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func countSyncActions(actions []SyncAction, actionType SyncActionType) (count int) {
+	for _, action := range actions {
+		if action.Action == actionType {
+			count++
+		}
+	}
+	return
+}
+
+func checkMaxDeletions(actions []SyncAction, maxDeletions int) error {
+	if maxDeletions <= 0 {
+		return nil
+	}
+	deletions := countSyncActions(actions, SyncActionDelete)
+	if deletions > maxDeletions {
+		return errorutils.CheckErrorf("sync aborted: %d files would be deleted, which exceeds the maximum of %d deletions", deletions, maxDeletions)
+	}
+	return nil
+}
+
+func writeSyncActions(actions []SyncAction) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		writer.Write(action)
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+func (ss *SyncService) performSyncActions(actions []SyncAction, localPath string, syncParams SyncParams, remoteFiles map[string]utils.ResultItem, summary *SyncSummary) error {
+	producerConsumer := parallel.NewRunner(ss.Threads, 20000, false)
+	errorsQueue := clientutils.NewErrorsQueue(1)
+	transferResult := utils.NewResult(ss.Threads)
+	deleteResult := utils.NewResult(ss.Threads)
+	downloadSuccessCounters := make([]int, ss.Threads)
+	repoPath := strings.Trim(syncParams.RepoPath, "/")
+
+	uploadService := NewUploadService(ss.client)
+	uploadService.ArtDetails = ss.GetArtifactoryDetails()
+	uploadService.Progress = ss.Progress
+	uploadService.Observer = ss.Observer
+	uploadParams := NewUploadParams()
+	uploadHandler := uploadService.createArtifactHandlerFunc(transferResult, uploadParams)
+
+	downloadService := NewDownloadService(ss.GetArtifactoryDetails(), ss.client)
+	downloadService.Progress = ss.Progress
+	downloadService.Observer = ss.Observer
+	downloadHandler := downloadService.createFileHandlerFunc(NewDownloadParams(), downloadSuccessCounters)
+
+	deleteService := NewDeleteService(ss.GetArtifactoryDetails(), ss.client)
+	deleteService.Observer = ss.Observer
+	remoteDeleteHandler := deleteService.createFileHandlerFunc(deleteResult)
+
+	go func() {
+		defer producerConsumer.Done()
+		for _, action := range actions {
+			localFilePath := filepath.Join(localPath, filepath.FromSlash(action.Path))
+			switch {
+			case action.Action == SyncActionUpload:
+				artifact := clientutils.Artifact{LocalPath: localFilePath, TargetPath: repoPath + "/" + action.Path}
+				_, _ = producerConsumer.AddTaskWithError(uploadHandler(UploadData{Artifact: artifact, TargetProps: utils.NewProperties()}), errorsQueue.AddError)
+			case action.Action == SyncActionDownload:
+				remoteItem := remoteFiles[action.Path]
+				downloadData := DownloadData{Dependency: remoteItem, DownloadPath: remoteItem.GetItemRelativePath(), Target: localFilePath, Flat: true}
+				_, _ = producerConsumer.AddTaskWithError(downloadHandler(downloadData), errorsQueue.AddError)
+			case action.Action == SyncActionDelete && syncParams.Direction == SyncUpload:
+				_, _ = producerConsumer.AddTaskWithError(remoteDeleteHandler(remoteFiles[action.Path]), errorsQueue.AddError)
+			case action.Action == SyncActionDelete && syncParams.Direction == SyncDownload:
+				_, _ = producerConsumer.AddTaskWithError(ss.createLocalDeleteTask(localFilePath, deleteResult), errorsQueue.AddError)
+			}
+		}
+	}()
+	producerConsumer.Run()
+
+	summary.TotalTransferred = utils.SumIntArray(transferResult.SuccessCount) + utils.SumIntArray(downloadSuccessCounters)
+	summary.TotalDeleted = utils.SumIntArray(deleteResult.SuccessCount)
+	expectedTransfers := utils.SumIntArray(transferResult.TotalCount) + countSyncActions(actions, SyncActionDownload)
+	summary.TotalFailed = expectedTransfers - summary.TotalTransferred + utils.SumIntArray(deleteResult.TotalCount) - summary.TotalDeleted
+	log.Debug("Sync transferred", strconv.Itoa(summary.TotalTransferred), "and deleted", strconv.Itoa(summary.TotalDeleted), "files.")
+	if summary.TotalFailed > 0 {
+		log.Error("Failed syncing", strconv.Itoa(summary.TotalFailed), "files.")
+	}
+	return errorsQueue.GetError()
+}
+
+func (ss *SyncService) createLocalDeleteTask(localFilePath string, deleteResult *utils.Result) parallel.TaskFunc {
+	events := newFileTransferEvents(ss.Observer, ioutils.DeleteOperation, localFilePath, "")
+	events.queued()
+	return func(threadId int) (err error) {
+		deleteResult.TotalCount[threadId]++
+		events.started()
+		defer func() {
+			events.finished(err == nil, err)
+		}()
+		log.Info(clientutils.GetLogMsgPrefix(threadId, false)+"Deleting", localFilePath)
+		if err = os.Remove(localFilePath); err != nil {
+			return errorutils.CheckError(err)
+		}
+		deleteResult.SuccessCount[threadId]++
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSyncActions(t *testing.T) {
+	localFiles := map[string]int64{"a/same.txt": 3, "a/changed.txt": 3, "a/resized.txt": 4, "new.txt": 1}
+	remoteFiles := map[string]utils.ResultItem{
+		"a/same.txt":    {Name: "same.txt", Size: 3, Actual_Sha1: "sha-same"},
+		"a/changed.txt": {Name: "changed.txt", Size: 3, Actual_Sha1: "sha-remote"},
+		"a/resized.txt": {Name: "resized.txt", Size: 5, Actual_Sha1: "sha-resized"},
+		"stale.txt":     {Name: "stale.txt", Size: 2, Actual_Sha1: "sha-stale"},
+	}
+	localSha1 := map[string]string{"a/same.txt": "sha-same", "a/changed.txt": "sha-local"}
+	var checksumCalls []string
+	localSha1Func := func(relativePath string) (string, error) {
+		checksumCalls = append(checksumCalls, relativePath)
+		return localSha1[relativePath], nil
+	}
+
+	tests := []struct {
+		name        string
+		direction   SyncDirection
+		deleteExtra bool
+		expected    []SyncAction
+	}{
+		{"upload", SyncUpload, false, []SyncAction{
+			{Action: SyncActionUpload, Path: "a/changed.txt", Size: 3, Sha1: "sha-local", Reason: syncReasonChecksumDiffers},
+			{Action: SyncActionUpload, Path: "a/resized.txt", Size: 4, Reason: syncReasonSizeDiffers},
+			{Action: SyncActionUnchanged, Path: "a/same.txt", Size: 3, Sha1: "sha-same", Reason: syncReasonChecksumsMatched},
+			{Action: SyncActionUpload, Path: "new.txt", Size: 1, Reason: syncReasonMissing},
+		}},
+		{"upload with deletions", SyncUpload, true, []SyncAction{
+			{Action: SyncActionUpload, Path: "a/changed.txt", Size: 3, Sha1: "sha-local", Reason: syncReasonChecksumDiffers},
+			{Action: SyncActionUpload, Path: "a/resized.txt", Size: 4, Reason: syncReasonSizeDiffers},
+			{Action: SyncActionUnchanged, Path: "a/same.txt", Size: 3, Sha1: "sha-same", Reason: syncReasonChecksumsMatched},
+			{Action: SyncActionUpload, Path: "new.txt", Size: 1, Reason: syncReasonMissing},
+			{Action: SyncActionDelete, Path: "stale.txt", Size: 2, Sha1: "sha-stale", Reason: syncReasonMissingOnSource},
+		}},
+		{"download with deletions", SyncDownload, true, []SyncAction{
+			{Action: SyncActionDownload, Path: "a/changed.txt", Size: 3, Sha1: "sha-remote", Reason: syncReasonChecksumDiffers},
+			{Action: SyncActionDownload, Path: "a/resized.txt", Size: 5, Sha1: "sha-resized", Reason: syncReasonSizeDiffers},
+			{Action: SyncActionUnchanged, Path: "a/same.txt", Size: 3, Sha1: "sha-same", Reason: syncReasonChecksumsMatched},
+			{Action: SyncActionDelete, Path: "new.txt", Size: 1, Reason: syncReasonMissingOnSource},
+			{Action: SyncActionDownload, Path: "stale.txt", Size: 2, Sha1: "sha-stale", Reason: syncReasonMissing},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checksumCalls = nil
+			actions, err := computeSyncActions(localFiles, remoteFiles, test.direction, test.deleteExtra, localSha1Func)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actions)
+			// Checksums should be calculated only for files with identical sizes.
+			assert.ElementsMatch(t, []string{"a/changed.txt", "a/same.txt"}, checksumCalls)
+		})
+	}
+}
+
+func TestCheckMaxDeletions(t *testing.T) {
+	actions := []SyncAction{{Action: SyncActionDelete}, {Action: SyncActionUpload}, {Action: SyncActionDelete}}
+	assert.NoError(t, checkMaxDeletions(actions, 0))
+	assert.NoError(t, checkMaxDeletions(actions, 2))
+	assert.Error(t, checkMaxDeletions(actions, 1))
+}
+
+func TestListLocalSyncFiles(t *testing.T) {
+	rootPath := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(rootPath, "a", "b"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootPath, "a", "b", "file.txt"), []byte("abc"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootPath, "a", "file.tmp"), []byte("a"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(rootPath, "root.txt"), []byte("ab"), 0644))
+
+	excludePattern, err := compileSyncExclusions([]string{"*.tmp"})
+	assert.NoError(t, err)
+	localFiles, err := listLocalSyncFiles(rootPath, excludePattern)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a/b/file.txt": 3, "root.txt": 2}, localFiles)
+
+	// A missing local directory is treated as empty.
+	localFiles, err = listLocalSyncFiles(filepath.Join(rootPath, "missing"), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, localFiles)
+}
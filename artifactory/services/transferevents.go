@@ -0,0 +1,253 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+)
+
+// Progressed events are emitted at most once per this number of transferred bytes, and when the transfer's content ends.
+const transferProgressEventInterval = 1024 * 1024
+
+var errTransferRejected = errors.New("the transfer was rejected by Artifactory, see the log for the server response")
+
+// Emits the events of a single file's transfer to a TransferObserver.
+// All methods are safe to call on a nil *fileTransferEvents, which is used when no observer is set.
+type fileTransferEvents struct {
+	observer   ioutils.TransferObserver
+	operation  ioutils.TransferOperation
+	sourcePath string
+	targetPath string
+	size       int64
+	checksum   entities.Checksum
+	// The number of times the content of the file started being transferred.
+	attempts int32
+	// A skipped transfer doesn't emit a final succeeded event.
+	wasSkipped bool
+}
+
+func newFileTransferEvents(observer ioutils.TransferObserver, operation ioutils.TransferOperation, sourcePath, targetPath string) *fileTransferEvents {
+	if observer == nil {
+		return nil
+	}
+	return &fileTransferEvents{observer: observer, operation: operation, sourcePath: sourcePath, targetPath: targetPath}
+}
+
+// Creates the events of an operation on an item found in Artifactory, whose size and checksums are already known.
+func newResultItemTransferEvents(observer ioutils.TransferObserver, operation ioutils.TransferOperation, resultItem utils.ResultItem, targetPath string) *fileTransferEvents {
+	events := newFileTransferEvents(observer, operation, resultItem.GetItemRelativePath(), targetPath)
+	events.setDetails(&fileutils.FileDetails{Size: resultItem.Size, Checksum: getResultItemChecksum(resultItem)})
+	return events
+}
+
+func (fte *fileTransferEvents) emit(event ioutils.TransferEvent) {
+	if fte == nil {
+		return
+	}
+	event.Operation = fte.operation
+	event.SourcePath = fte.sourcePath
+	event.TargetPath = fte.targetPath
+	event.Size = fte.size
+	event.Checksum = fte.checksum
+	event.Attempt = int(atomic.LoadInt32(&fte.attempts))
+	event.Time = time.Now()
+	fte.observer.OnTransferEvent(event)
+}
+
+func (fte *fileTransferEvents) setTargetPath(targetPath string) {
+	if fte != nil {
+		fte.targetPath = targetPath
+	}
+}
+
+// Sets the size and checksums reported by the following events.
+func (fte *fileTransferEvents) setDetails(details *fileutils.FileDetails) {
+	if fte == nil || details == nil {
+		return
+	}
+	fte.size = details.Size
+	fte.checksum = details.Checksum
+}
+
+func (fte *fileTransferEvents) queued() {
+	fte.emit(ioutils.TransferEvent{Type: ioutils.TransferQueued})
+}
+
+func (fte *fileTransferEvents) started() {
+	fte.emit(ioutils.TransferEvent{Type: ioutils.TransferStarted})
+}
+
+func (fte *fileTransferEvents) checksumDeployed() {
+	fte.emit(ioutils.TransferEvent{Type: ioutils.TransferChecksumDeployed})
+}
+
+func (fte *fileTransferEvents) skipped(reason string) {
+	if fte == nil {
+		return
+	}
+	fte.wasSkipped = true
+	fte.emit(ioutils.TransferEvent{Type: ioutils.TransferSkipped, Reason: reason})
+}
+
+// Emits the final event of the transfer. A transfer which didn't succeed without returning an error, was rejected by the server.
+func (fte *fileTransferEvents) finished(succeeded bool, err error) {
+	if err == nil && !succeeded {
+		err = errTransferRejected
+	}
+	if err != nil {
+		fte.emit(ioutils.TransferEvent{Type: ioutils.TransferFailed, Err: err})
+		return
+	}
+	if fte != nil && fte.wasSkipped {
+		return
+	}
+	fte.emit(ioutils.TransferEvent{Type: ioutils.TransferSucceeded})
+}
+
+func (fte *fileTransferEvents) newAttempt() {
+	if atomic.AddInt32(&fte.attempts, 1) > 1 {
+		fte.emit(ioutils.TransferEvent{Type: ioutils.TransferRetried})
+	}
+}
+
+// Returns a ProgressMgr which emits the progressed and retried events of the file, in addition to updating base.
+// The http client creates a new progress reader for each attempt to transfer the file's content, which is how retries are detected.
+// base may be nil.
+func (fte *fileTransferEvents) progressMgr(base ioutils.ProgressMgr) ioutils.ProgressMgr {
+	if fte == nil {
+		return base
+	}
+	return &transferEventsProgressMgr{base: base, events: fte}
+}
+
+type transferEventsProgressMgr struct {
+	base    ioutils.ProgressMgr
+	events  *fileTransferEvents
+	current *transferEventsProgress
+}
+
+func (tepm *transferEventsProgressMgr) NewProgressReader(total int64, label, path string) ioutils.Progress {
+	tepm.events.newAttempt()
+	progress := &transferEventsProgress{events: tepm.events}
+	if tepm.base != nil {
+		progress.base = tepm.base.NewProgressReader(total, label, path)
+	}
+	tepm.current = progress
+	return progress
+}
+
+// Concurrent downloads of a file's chunks share the file's progress reader.
+func (tepm *transferEventsProgressMgr) GetProgress(id int) ioutils.Progress {
+	if tepm.current != nil && tepm.current.GetId() == id {
+		return tepm.current
+	}
+	if tepm.base != nil {
+		return tepm.base.GetProgress(id)
+	}
+	return nil
+}
+
+func (tepm *transferEventsProgressMgr) SetProgressState(id int, state string) {
+	if tepm.base != nil {
+		tepm.base.SetProgressState(id, state)
+	}
+}
+
+func (tepm *transferEventsProgressMgr) RemoveProgress(id int) {
+	if tepm.base != nil {
+		tepm.base.RemoveProgress(id)
+	}
+}
+
+func (tepm *transferEventsProgressMgr) IncrementGeneralProgress() {
+	if tepm.base != nil {
+		tepm.base.IncrementGeneralProgress()
+	}
+}
+
+func (tepm *transferEventsProgressMgr) Quit() error {
+	if tepm.base != nil {
+		return tepm.base.Quit()
+	}
+	return nil
+}
+
+func (tepm *transferEventsProgressMgr) IncGeneralProgressTotalBy(n int64) {
+	if tepm.base != nil {
+		tepm.base.IncGeneralProgressTotalBy(n)
+	}
+}
+
+func (tepm *transferEventsProgressMgr) SetHeadlineMsg(msg string) {
+	if tepm.base != nil {
+		tepm.base.SetHeadlineMsg(msg)
+	}
+}
+
+func (tepm *transferEventsProgressMgr) ClearHeadlineMsg() {
+	if tepm.base != nil {
+		tepm.base.ClearHeadlineMsg()
+	}
+}
+
+func (tepm *transferEventsProgressMgr) InitProgressReaders() {
+	if tepm.base != nil {
+		tepm.base.InitProgressReaders()
+	}
+}
+
+type transferEventsProgress struct {
+	base        ioutils.Progress
+	events      *fileTransferEvents
+	transferred int64
+	reported    int64
+}
+
+func (tep *transferEventsProgress) ActionWithProgress(reader io.Reader) io.Reader {
+	if tep.base != nil {
+		reader = tep.base.ActionWithProgress(reader)
+	}
+	return &transferEventsReader{reader: reader, progress: tep}
+}
+
+func (tep *transferEventsProgress) Abort() {
+	if tep.base != nil {
+		tep.base.Abort()
+	}
+}
+
+func (tep *transferEventsProgress) GetId() int {
+	if tep.base != nil {
+		return tep.base.GetId()
+	}
+	return 0
+}
+
+// Called concurrently when the chunks of a file are downloaded in parallel.
+func (tep *transferEventsProgress) add(n int64, eof bool) {
+	transferred := atomic.AddInt64(&tep.transferred, n)
+	reported := atomic.LoadInt64(&tep.reported)
+	if transferred == reported || (!eof && transferred-reported < transferProgressEventInterval) {
+		return
+	}
+	if atomic.CompareAndSwapInt64(&tep.reported, reported, transferred) {
+		tep.events.emit(ioutils.TransferEvent{Type: ioutils.TransferProgressed, TransferredBytes: transferred})
+	}
+}
+
+type transferEventsReader struct {
+	reader   io.Reader
+	progress *transferEventsProgress
+}
+
+func (ter *transferEventsReader) Read(p []byte) (int, error) {
+	n, err := ter.reader.Read(p)
+	ter.progress.add(int64(n), err == io.EOF)
+	return n, err
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTransferObserver struct {
+	mutex  sync.Mutex
+	events []ioutils.TransferEvent
+}
+
+func (tto *testTransferObserver) OnTransferEvent(event ioutils.TransferEvent) {
+	tto.mutex.Lock()
+	defer tto.mutex.Unlock()
+	tto.events = append(tto.events, event)
+}
+
+func (tto *testTransferObserver) types() (types []ioutils.TransferEventType) {
+	for _, event := range tto.events {
+		types = append(types, event.Type)
+	}
+	return
+}
+
+func TestFileTransferEvents(t *testing.T) {
+	observer := &testTransferObserver{}
+	events := newFileTransferEvents(observer, ioutils.UploadOperation, "a.bin", "repo/a.bin")
+	events.queued()
+	events.started()
+	events.setDetails(&fileutils.FileDetails{Size: 3 * transferProgressEventInterval})
+	progressMgr := events.progressMgr(nil)
+	content := make([]byte, 3*transferProgressEventInterval)
+	for i := 0; i < 2; i++ {
+		progress := progressMgr.NewProgressReader(int64(len(content)), "Uploading", "repo/a.bin")
+		_, err := io.Copy(io.Discard, progress.ActionWithProgress(bytes.NewReader(content)))
+		assert.NoError(t, err)
+		progressMgr.RemoveProgress(progress.GetId())
+	}
+	events.finished(true, nil)
+
+	assert.Equal(t, []ioutils.TransferEventType{
+		ioutils.TransferQueued, ioutils.TransferStarted,
+		ioutils.TransferProgressed, ioutils.TransferProgressed, ioutils.TransferProgressed,
+		ioutils.TransferRetried,
+		ioutils.TransferProgressed, ioutils.TransferProgressed, ioutils.TransferProgressed,
+		ioutils.TransferSucceeded,
+	}, observer.types())
+	last := observer.events[len(observer.events)-1]
+	assert.Equal(t, ioutils.UploadOperation, last.Operation)
+	assert.Equal(t, "a.bin", last.SourcePath)
+	assert.Equal(t, "repo/a.bin", last.TargetPath)
+	assert.Equal(t, int64(len(content)), last.Size)
+	assert.Equal(t, 2, last.Attempt)
+	assert.Equal(t, int64(len(content)), observer.events[len(observer.events)-2].TransferredBytes)
+}
+
+func TestFileTransferEventsFinished(t *testing.T) {
+	observer := &testTransferObserver{}
+	skipped := newFileTransferEvents(observer, ioutils.DownloadOperation, "repo/a", "a")
+	skipped.skipped("the file already exists locally")
+	skipped.finished(true, nil)
+	assert.Equal(t, []ioutils.TransferEventType{ioutils.TransferSkipped}, observer.types())
+
+	observer.events = nil
+	failed := newFileTransferEvents(observer, ioutils.MoveOperation, "repo/a", "repo/b")
+	failed.finished(false, nil)
+	err := errors.New("failure")
+	failed.finished(true, err)
+	if assert.Len(t, observer.events, 2) {
+		assert.Equal(t, errTransferRejected, observer.events[0].Err)
+		assert.Equal(t, err, observer.events[1].Err)
+	}
+
+	// Without an observer, nothing is emitted and the progress manager is left as is.
+	noEvents := newFileTransferEvents(nil, ioutils.DeleteOperation, "repo/a", "")
+	assert.Nil(t, noEvents)
+	noEvents.queued()
+	noEvents.finished(false, nil)
+	assert.Nil(t, noEvents.progressMgr(nil))
+}
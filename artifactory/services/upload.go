@@ -18,6 +18,7 @@ import (
 	"github.com/madotis/jfrog-client-go/utils/io/httputils"
 	"github.com/madotis/jfrog-client-go/utils/log"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -26,16 +27,21 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 type UploadService struct {
-	client         *jfroghttpclient.JfrogHttpClient
-	Progress       ioutils.ProgressMgr
+	client   *jfroghttpclient.JfrogHttpClient
+	Progress ioutils.ProgressMgr
+	// If set, receives an event for each step of each file's upload.
+	Observer       ioutils.TransferObserver
 	ArtDetails     auth.ServiceDetails
 	DryRun         bool
 	Threads        int
 	saveSummary    bool
 	resultsManager *resultsManager
+	// When set, the actions of the upload are written to it instead of being performed.
+	planWriter *content.ContentWriter
 }
 
 func NewUploadService(client *jfroghttpclient.JfrogHttpClient) *UploadService {
@@ -46,6 +52,10 @@ func (us *UploadService) SetThreads(threads int) {
 	us.Threads = threads
 }
 
+func (us *UploadService) SetTransferObserver(observer ioutils.TransferObserver) {
+	us.Observer = observer
+}
+
 func (us *UploadService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
 	return us.client
 }
@@ -62,6 +72,17 @@ func (us *UploadService) SetSaveSummary(saveSummary bool) {
 	us.saveSummary = saveSummary
 }
 
+// Returns the observer of the transfers' events, which also records the failed transfers when a summary is saved.
+func (us *UploadService) getTransferObserver() ioutils.TransferObserver {
+	if us.planWriter != nil {
+		return nil
+	}
+	if us.saveSummary && us.resultsManager != nil {
+		return us.resultsManager.failedTransfers
+	}
+	return us.Observer
+}
+
 func (us *UploadService) getOperationSummary(totalSucceeded, totalFailed int) *utils.OperationSummary {
 	if !us.saveSummary {
 		return &utils.OperationSummary{
@@ -78,7 +99,7 @@ func (us *UploadService) UploadFiles(uploadParams ...UploadParams) (summary *uti
 	producerConsumer := parallel.NewRunner(us.Threads, 20000, false)
 	errorsQueue := clientutils.NewErrorsQueue(1)
 	if us.saveSummary {
-		us.resultsManager, err = newResultManager()
+		us.resultsManager, err = newResultManager(us.Observer)
 		if err != nil {
 			return nil, err
 		}
@@ -91,9 +112,29 @@ func (us *UploadService) UploadFiles(uploadParams ...UploadParams) (summary *uti
 	}
 	us.prepareUploadTasks(producerConsumer, errorsQueue, uploadSummary, uploadParams...)
 	totalUploaded, totalFailed := us.performUploadTasks(producerConsumer, uploadSummary)
+	if us.planWriter == nil {
+		if err = saveUploadIndexes(uploadParams...); err != nil {
+			errorsQueue.AddError(err)
+		}
+	}
 	return us.getOperationSummary(totalUploaded, totalFailed), errorsQueue.GetError()
 }
 
+// Saves the upload indexes of the params, so that files uploaded successfully in this run can be skipped in the next one.
+func saveUploadIndexes(uploadParams ...UploadParams) error {
+	saved := make(map[*UploadIndex]bool)
+	for _, params := range uploadParams {
+		if params.UploadIndex == nil || saved[params.UploadIndex] {
+			continue
+		}
+		if err := params.UploadIndex.Save(); err != nil {
+			return err
+		}
+		saved[params.UploadIndex] = true
+	}
+	return nil
+}
+
 type ArchiveUploadData struct {
 	writer       *content.ContentWriter
 	uploadParams UploadParams
@@ -122,8 +163,13 @@ func (us *UploadService) prepareUploadTasks(producer parallel.Runner, errorsQueu
 		toArchive := make(map[string]*ArchiveUploadData)
 		for _, uploadParams := range uploadParamsSlice {
 			var taskHandler UploadDataHandlerFunc
+			if err := resolveUploadSource(&uploadParams); err != nil {
+				log.Error(err)
+				errorsQueue.AddError(err)
+				continue
+			}
 
-			if uploadParams.Archive == "zip" {
+			if uploadParams.Archive != "" {
 				taskHandler = getSaveTaskInContentWriterFunc(toArchive, uploadParams, errorsQueue)
 			} else {
 				artifactHandlerFunc := us.createArtifactHandlerFunc(uploadSummary, uploadParams)
@@ -146,7 +192,7 @@ func (us *UploadService) prepareUploadTasks(producer parallel.Runner, errorsQueu
 			if us.Progress != nil {
 				us.Progress.IncGeneralProgressTotalBy(1)
 			}
-			_, _ = producer.AddTaskWithError(us.CreateUploadAsZipFunc(uploadSummary, targetPath, archiveData, errorsQueue), errorsQueue.AddError)
+			_, _ = producer.AddTaskWithError(us.CreateUploadAsArchiveFunc(uploadSummary, targetPath, archiveData, errorsQueue), errorsQueue.AddError)
 		}
 	}()
 }
@@ -230,12 +276,24 @@ func getSaveTaskInContentWriterFunc(writersMap map[string]*ArchiveUploadData, up
 }
 
 func CollectFilesForUpload(uploadParams UploadParams, progressMgr ioutils.ProgressMgr, vcsCache *clientutils.VcsCache, dataHandlerFunc UploadDataHandlerFunc) error {
+	if err := resolveUploadSource(&uploadParams); err != nil {
+		return err
+	}
+	if uploadParams.FailedTransfers != nil {
+		return collectFailedTransfersForUpload(uploadParams, progressMgr, vcsCache, dataHandlerFunc)
+	}
+	if uploadParams.SourceFS != nil {
+		return collectFilesFromFS(uploadParams, progressMgr, dataHandlerFunc)
+	}
 	if !strings.Contains(uploadParams.GetTarget(), "/") {
 		uploadParams.SetTarget(uploadParams.GetTarget() + "/")
 	}
 	if uploadParams.Archive != "" && strings.HasSuffix(uploadParams.GetTarget(), "/") {
 		return errorutils.CheckErrorf("an archive's target cannot be a directory")
 	}
+	if uploadParams.Archive != "" && !isSupportedArchive(uploadParams.Archive) {
+		return errorutils.CheckErrorf("unsupported archive format: '%s'. Supported formats: %s, %s, %s and %s", uploadParams.Archive, ZipArchive, TarArchive, TarGzArchive, TarZstArchive)
+	}
 	uploadParams.SetPattern(clientutils.ReplaceTildeWithUserHome(uploadParams.GetPattern()))
 	// Save parentheses index in pattern, witch have corresponding placeholder.
 	rootPath, err := fspatterns.GetRootPath(uploadParams.GetPattern(), uploadParams.GetTarget(), uploadParams.TargetPathInArchive, uploadParams.GetPatternType(), uploadParams.IsSymlink())
@@ -453,7 +511,7 @@ func getUploadTarget(rootPath, target string, isFlat, placeholdersUsed bool) str
 
 // Uploads the file in the specified local path to the specified target path.
 // Returns true if the file was successfully uploaded.
-func (us *UploadService) uploadFile(artifact UploadData, uploadParams UploadParams, logMsgPrefix string) (*fileutils.FileDetails, bool, error) {
+func (us *UploadService) uploadFile(artifact UploadData, uploadParams UploadParams, logMsgPrefix string, events *fileTransferEvents) (*fileutils.FileDetails, bool, error) {
 	var checksumDeployed = false
 	var resp *http.Response
 	var details *fileutils.FileDetails
@@ -470,7 +528,14 @@ func (us *UploadService) uploadFile(artifact UploadData, uploadParams UploadPara
 	if uploadParams.IsSymlink() && fileutils.IsFileSymlink(fileInfo) {
 		resp, details, body, err = us.uploadSymlink(targetPathWithProps, logMsgPrefix, httpClientsDetails, uploadParams)
 	} else {
-		resp, details, body, checksumDeployed, err = us.doUpload(artifact.Artifact.LocalPath, targetPathWithProps, logMsgPrefix, httpClientsDetails, fileInfo, uploadParams)
+		if uploadParams.UploadIndex != nil && !us.DryRun {
+			if details = us.getDeployedFileDetails(artifact.Artifact, targetPathWithProps, fileInfo, uploadParams.UploadIndex, logMsgPrefix); details != nil {
+				events.setDetails(details)
+				events.skipped("the file was not modified since it was last uploaded")
+				return details, true, nil
+			}
+		}
+		resp, details, body, checksumDeployed, err = us.doUpload(artifact.Artifact.LocalPath, targetPathWithProps, logMsgPrefix, httpClientsDetails, fileInfo, uploadParams, events)
 	}
 	if err != nil {
 		return nil, false, err
@@ -479,8 +544,38 @@ func (us *UploadService) uploadFile(artifact UploadData, uploadParams UploadPara
 	if err != nil {
 		return nil, false, err
 	}
+	events.setDetails(details)
 	logUploadResponse(logMsgPrefix, resp, body, checksumDeployed, us.DryRun)
-	return details, us.DryRun || checksumDeployed || isSuccessfulUploadStatusCode(resp.StatusCode), nil
+	uploaded := us.DryRun || checksumDeployed || isSuccessfulUploadStatusCode(resp.StatusCode)
+	if uploaded && !us.DryRun && uploadParams.UploadIndex != nil && !fileutils.IsFileSymlink(fileInfo) {
+		uploadParams.UploadIndex.setDeployed(artifact.Artifact.LocalPath, targetPathWithProps, fileInfo, details, time.Now())
+	}
+	return details, uploaded, nil
+}
+
+// Returns the indexed details of the file if it was already deployed to the target and wasn't modified since, or nil if it should be uploaded.
+// Entries which are due for verification are checked against the checksum Artifactory holds for the target.
+func (us *UploadService) getDeployedFileDetails(artifact clientutils.Artifact, targetUrlWithProps string, fileInfo os.FileInfo, index *UploadIndex, logMsgPrefix string) *fileutils.FileDetails {
+	entry := index.getDeployedEntry(artifact.LocalPath, targetUrlWithProps, fileInfo)
+	if entry == nil {
+		return nil
+	}
+	now := time.Now()
+	if index.needsVerification(entry, now) {
+		targetUrl, err := utils.BuildArtifactoryUrl(us.ArtDetails.GetUrl(), artifact.TargetPath, make(map[string]string))
+		if err != nil {
+			return nil
+		}
+		httpClientsDetails := us.ArtDetails.CreateHttpClientDetails()
+		remoteDetails, _, err := us.client.GetRemoteFileDetails(targetUrl, &httpClientsDetails)
+		if err != nil || remoteDetails.Checksum.Sha1 != entry.Sha1 {
+			log.Debug(logMsgPrefix+"The indexed target of", artifact.LocalPath, "could not be verified, uploading it again.")
+			return nil
+		}
+		index.setVerified(artifact.LocalPath, now)
+	}
+	log.Debug(logMsgPrefix+"Skipping", artifact.LocalPath+", it was not modified since it was last uploaded.")
+	return &fileutils.FileDetails{Checksum: entry.Checksum, Size: entry.Size}
 }
 
 func (us *UploadService) shouldTryChecksumDeploy(fileSize int64, uploadParams UploadParams) bool {
@@ -490,11 +585,12 @@ func (us *UploadService) shouldTryChecksumDeploy(fileSize int64, uploadParams Up
 // Reads a file from a Reader that is given from a function (getReaderFunc) and uploads it to the specified target path.
 // getReaderFunc is called only if checksum deploy was successful.
 // Returns true if the file was successfully uploaded.
-func (us *UploadService) uploadFileFromReader(getReaderFunc func() (io.Reader, error), targetUrlWithProps string, uploadParams UploadParams, logMsgPrefix string, details *fileutils.FileDetails) (bool, error) {
+func (us *UploadService) uploadFileFromReader(getReaderFunc func() (io.Reader, error), targetUrlWithProps string, uploadParams UploadParams, logMsgPrefix string, details *fileutils.FileDetails, events *fileTransferEvents) (bool, error) {
 	var resp *http.Response
 	var body []byte
 	var checksumDeployed = false
 	var e error
+	events.setDetails(details)
 	httpClientsDetails := us.ArtDetails.CreateHttpClientDetails()
 	if !us.DryRun {
 		if us.shouldTryChecksumDeploy(details.Size, uploadParams) {
@@ -503,6 +599,9 @@ func (us *UploadService) uploadFileFromReader(getReaderFunc func() (io.Reader, e
 				return false, e
 			}
 			checksumDeployed = isSuccessfulUploadStatusCode(resp.StatusCode)
+			if checksumDeployed {
+				events.checksumDeployed()
+			}
 		}
 
 		if !checksumDeployed {
@@ -516,7 +615,7 @@ func (us *UploadService) uploadFileFromReader(getReaderFunc func() (io.Reader, e
 					if e != nil {
 						return false, e
 					}
-					resp, details, body, e = us.doUploadFromReader(uploadZipReader, targetUrlWithProps, httpClientsDetails, uploadParams, details)
+					resp, details, body, e = us.doUploadFromReader(uploadZipReader, targetUrlWithProps, httpClientsDetails, uploadParams, details, events)
 					if e != nil {
 						return true, e
 					}
@@ -554,28 +653,38 @@ func (us *UploadService) uploadSymlink(targetPath, logMsgPrefix string, httpClie
 	return
 }
 
-func (us *UploadService) doUpload(localPath, targetUrlWithProps, logMsgPrefix string, httpClientsDetails httputils.HttpClientDetails, fileInfo os.FileInfo, uploadParams UploadParams) (*http.Response, *fileutils.FileDetails, []byte, bool, error) {
+func (us *UploadService) doUpload(localPath, targetUrlWithProps, logMsgPrefix string, httpClientsDetails httputils.HttpClientDetails, fileInfo os.FileInfo, uploadParams UploadParams, events *fileTransferEvents) (*http.Response, *fileutils.FileDetails, []byte, bool, error) {
 	var details *fileutils.FileDetails
 	var checksumDeployed bool
 	var resp *http.Response
 	var body []byte
 	var err error
+	if uploadParams.UploadIndex != nil && uploadParams.ChecksumsCalcEnabled {
+		// Reuse the checksums calculated in a previous run, if the file wasn't modified since.
+		details = uploadParams.UploadIndex.getUnchangedFileDetails(localPath, fileInfo)
+	}
 	addExplodeHeader(&httpClientsDetails, uploadParams.IsExplodeArchive())
 	if !us.DryRun {
 		if us.shouldTryChecksumDeploy(fileInfo.Size(), uploadParams) {
-			details, err = fileutils.GetFileDetails(localPath, uploadParams.ChecksumsCalcEnabled)
-			if err != nil {
-				return resp, details, body, checksumDeployed, err
+			if details == nil {
+				details, err = fileutils.GetFileDetails(localPath, uploadParams.ChecksumsCalcEnabled)
+				if err != nil {
+					return resp, details, body, checksumDeployed, err
+				}
 			}
+			events.setDetails(details)
 			resp, body, err = us.tryChecksumDeploy(details, targetUrlWithProps, httpClientsDetails, us.client)
 			if err != nil {
 				return resp, details, body, checksumDeployed, err
 			}
 			checksumDeployed = isSuccessfulUploadStatusCode(resp.StatusCode)
+			if checksumDeployed {
+				events.checksumDeployed()
+			}
 		}
 		if !checksumDeployed {
 			resp, body, err = utils.UploadFile(localPath, targetUrlWithProps, logMsgPrefix, &us.ArtDetails, details,
-				httpClientsDetails, us.client, uploadParams.ChecksumsCalcEnabled, us.Progress)
+				httpClientsDetails, us.client, uploadParams.ChecksumsCalcEnabled, events.progressMgr(us.Progress))
 			if err != nil {
 				return resp, details, body, checksumDeployed, err
 			}
@@ -587,16 +696,16 @@ func (us *UploadService) doUpload(localPath, targetUrlWithProps, logMsgPrefix st
 	return resp, details, body, checksumDeployed, err
 }
 
-func (us *UploadService) doUploadFromReader(fileReader io.Reader, targetUrlWithProps string, httpClientsDetails httputils.HttpClientDetails, uploadParams UploadParams, details *fileutils.FileDetails) (*http.Response, *fileutils.FileDetails, []byte, error) {
+func (us *UploadService) doUploadFromReader(fileReader io.Reader, targetUrlWithProps string, httpClientsDetails httputils.HttpClientDetails, uploadParams UploadParams, details *fileutils.FileDetails, events *fileTransferEvents) (*http.Response, *fileutils.FileDetails, []byte, error) {
 	var resp *http.Response
 	var body []byte
 	var err error
 	var reader io.Reader
 	addExplodeHeader(&httpClientsDetails, uploadParams.IsExplodeArchive())
-	if us.Progress != nil {
-		progressReader := us.Progress.NewProgressReader(details.Size, "Uploading", targetUrlWithProps)
+	if progressMgr := events.progressMgr(us.Progress); progressMgr != nil {
+		progressReader := progressMgr.NewProgressReader(details.Size, "Uploading", targetUrlWithProps)
 		reader = progressReader.ActionWithProgress(fileReader)
-		defer us.Progress.RemoveProgress(progressReader.GetId())
+		defer progressMgr.RemoveProgress(progressReader.GetId())
 	} else {
 		reader = fileReader
 	}
@@ -664,6 +773,16 @@ type UploadParams struct {
 	Archive              string
 	// When using the 'archive' option for upload, we can control the target path inside the uploaded archive using placeholders. This operation determines the TargetPathInArchive value.
 	TargetPathInArchive string
+	// When set, files which were not modified since they were last deployed to the same target are skipped.
+	// The index is saved when the upload ends. Files are indexed only if ChecksumsCalcEnabled is set.
+	UploadIndex *UploadIndex
+	// When set, files are collected from SourceFS instead of the local file system. The pattern is a slash separated path in SourceFS.
+	SourceFS fs.FS
+	// In-memory files to upload. They are buffered into SourceFS, so the pattern is matched against their names.
+	Readers []NamedReader
+	// A ContentReader of FailedTransferDetails structs, such as the FailedDetailsReader of a previous upload's summary.
+	// When set, each of its files is uploaded to the target it failed to be uploaded to, and the pattern and target are ignored.
+	FailedTransfers *content.ContentReader
 }
 
 func NewUploadParams() UploadParams {
@@ -709,11 +828,23 @@ type artifactContext func(UploadData) parallel.TaskFunc
 
 func (us *UploadService) createArtifactHandlerFunc(uploadResult *utils.Result, uploadParams UploadParams) artifactContext {
 	return func(artifact UploadData) parallel.TaskFunc {
+		events := newFileTransferEvents(us.getTransferObserver(), ioutils.UploadOperation, artifact.Artifact.LocalPath, artifact.Artifact.TargetPath)
+		events.queued()
 		return func(threadId int) (err error) {
 			uploadResult.TotalCount[threadId]++
 			checksums := &entities.Checksum{}
 			var uploaded bool
+			events.started()
+			defer func() {
+				events.finished(uploaded, err)
+			}()
 			logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, us.DryRun)
+			if us.planWriter != nil {
+				if err = us.planUpload(artifact, uploadParams); err == nil {
+					uploadResult.SuccessCount[threadId]++
+				}
+				return
+			}
 			log.Info(logMsgPrefix+"Uploading:", artifact.Artifact.LocalPath)
 			if artifact.IsDir {
 				// Upload directory
@@ -725,7 +856,11 @@ func (us *UploadService) createArtifactHandlerFunc(uploadResult *utils.Result, u
 			} else {
 				// Upload file
 				var uploadFileDetails *fileutils.FileDetails
-				uploadFileDetails, uploaded, err = us.uploadFile(artifact, uploadParams, logMsgPrefix)
+				if uploadParams.SourceFS != nil {
+					uploadFileDetails, uploaded, err = us.uploadFileFromFS(artifact, uploadParams, logMsgPrefix, events)
+				} else {
+					uploadFileDetails, uploaded, err = us.uploadFile(artifact, uploadParams, logMsgPrefix, events)
+				}
 				if err != nil {
 					return
 				}
@@ -764,8 +899,15 @@ func (us *UploadService) createFolderInArtifactory(artifact UploadData) error {
 }
 
 func (us *UploadService) CreateUploadAsZipFunc(uploadResult *utils.Result, targetPath string, archiveData *ArchiveUploadData, errorsQueue *clientutils.ErrorsQueue) parallel.TaskFunc {
+	events := newFileTransferEvents(us.getTransferObserver(), ioutils.UploadOperation, "", targetPath)
+	events.queued()
 	return func(threadId int) (err error) {
 		uploadResult.TotalCount[threadId]++
+		var uploaded bool
+		events.started()
+		defer func() {
+			events.finished(uploaded, err)
+		}()
 		logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, us.DryRun)
 
 		archiveDataReader := content.NewContentReader(archiveData.writer.GetFilePath(), archiveData.writer.GetArrayKey())
@@ -800,7 +942,7 @@ func (us *UploadService) CreateUploadAsZipFunc(uploadResult *utils.Result, targe
 			return us.readFilesAsZip(archiveDataReader, "Archiving", archiveData.uploadParams.Flat,
 				archiveData.uploadParams.Symlink, nil, errorsQueue, &zipReadersWg), nil
 		}
-		uploaded, err := us.uploadFileFromReader(getReaderFunc, targetUrlWithProps, archiveData.uploadParams, logMsgPrefix, details)
+		uploaded, err = us.uploadFileFromReader(getReaderFunc, targetUrlWithProps, archiveData.uploadParams, logMsgPrefix, details, events)
 
 		if uploaded {
 			uploadResult.SuccessCount[threadId]++
@@ -857,13 +999,7 @@ func (us *UploadService) readFilesAsZip(archiveDataReader *content.ContentReader
 
 func (us *UploadService) addFileToZip(artifact *clientutils.Artifact, progressPrefix string, flat, symlink bool, zipWriter *zip.Writer) (err error) {
 	var reader io.Reader
-	localPath := artifact.LocalPath
-	// In case of a symlink there are 2 options:
-	// 1. symlink == true : symlink will be added to zip as a symlink file.
-	// 2. symlink == false : the symlink's target will be added to zip.
-	if artifact.SymlinkTargetPath != "" && !symlink {
-		localPath = artifact.SymlinkTargetPath
-	}
+	localPath := getArchiveSourcePath(artifact, symlink)
 	info, err := os.Lstat(localPath)
 	if errorutils.CheckError(err) != nil {
 		return
@@ -872,12 +1008,7 @@ func (us *UploadService) addFileToZip(artifact *clientutils.Artifact, progressPr
 	if errorutils.CheckError(err) != nil {
 		return
 	}
-	if !flat {
-		header.Name = clientutils.TrimPath(localPath)
-	}
-	if artifact.TargetPathInArchive != "" {
-		header.Name = artifact.TargetPathInArchive
-	}
+	header.Name = getArchiveEntryName(artifact, localPath, info, flat)
 	header.Method = zip.Deflate
 
 	// If this is a directory, add it to the writer with a trailing slash.
@@ -995,9 +1126,11 @@ type resultsManager struct {
 	// A ContentWriter of ArtifactDetails structs. Each struct written to this ContentWriter represents an artifact in Artifactory
 	// that was successfully uploaded in the current operation.
 	artifactsDetailsWriter *content.ContentWriter
+	// Records the details of the files that failed to be uploaded.
+	failedTransfers *failedTransfersRecorder
 }
 
-func newResultManager() (*resultsManager, error) {
+func newResultManager(observer ioutils.TransferObserver) (*resultsManager, error) {
 	singleFinalTransfersWriter, e := content.NewContentWriter(content.DefaultKey, true, false)
 	if e != nil {
 		return nil, e
@@ -1006,10 +1139,15 @@ func newResultManager() (*resultsManager, error) {
 	if e != nil {
 		return nil, e
 	}
+	failedTransfers, e := newFailedTransfersRecorder(observer)
+	if e != nil {
+		return nil, e
+	}
 	return &resultsManager{
 		singleFinalTransfersWriter: singleFinalTransfersWriter,
 		notFinalTransfersWriters:   make(map[string]*content.ContentWriter),
 		artifactsDetailsWriter:     artifactsDetailsWriter,
+		failedTransfers:            failedTransfers,
 	}, nil
 }
 
@@ -1088,6 +1226,10 @@ func (rm *resultsManager) close() error {
 	if err != nil {
 		return err
 	}
+	err = rm.failedTransfers.close()
+	if err != nil {
+		return err
+	}
 	for _, writer := range rm.notFinalTransfersWriters {
 		err = writer.Close()
 		if err != nil {
@@ -1106,6 +1248,7 @@ func (rm *resultsManager) getOperationSummary(totalSucceeded, totalFailed int) *
 	return &utils.OperationSummary{
 		TransferDetailsReader:  rm.getTransferDetailsReader(),
 		ArtifactsDetailsReader: content.NewContentReader(rm.artifactsDetailsWriter.GetFilePath(), content.DefaultKey),
+		FailedDetailsReader:    rm.failedTransfers.getReader(),
 		TotalSucceeded:         totalSucceeded,
 		TotalFailed:            totalFailed,
 	}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jfrog/gofrog/parallel"
+	"github.com/klauspost/compress/zstd"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+// Supported values of UploadParams.Archive.
+const (
+	ZipArchive    = "zip"
+	TarArchive    = "tar"
+	TarGzArchive  = "tar.gz"
+	TarZstArchive = "tar.zst"
+)
+
+// The modification time of all tar entries, so that archiving the same files always produces the same archive.
+var tarEntriesModTime = time.Unix(0, 0).UTC()
+
+func isSupportedArchive(archive string) bool {
+	switch archive {
+	case ZipArchive, TarArchive, TarGzArchive, TarZstArchive:
+		return true
+	}
+	return false
+}
+
+func (us *UploadService) CreateUploadAsArchiveFunc(uploadResult *utils.Result, targetPath string, archiveData *ArchiveUploadData, errorsQueue *clientutils.ErrorsQueue) parallel.TaskFunc {
+	if us.planWriter != nil {
+		return us.createPlanArchiveFunc(uploadResult, targetPath, archiveData, errorsQueue)
+	}
+	if archiveData.uploadParams.Archive == ZipArchive {
+		return us.CreateUploadAsZipFunc(uploadResult, targetPath, archiveData, errorsQueue)
+	}
+	return us.createUploadAsTarFunc(uploadResult, targetPath, archiveData)
+}
+
+// The tar archive is written once to a temp file while its checksums are calculated, and the temp file is then uploaded.
+func (us *UploadService) createUploadAsTarFunc(uploadResult *utils.Result, targetPath string, archiveData *ArchiveUploadData) parallel.TaskFunc {
+	events := newFileTransferEvents(us.getTransferObserver(), ioutils.UploadOperation, "", targetPath)
+	events.queued()
+	return func(threadId int) (err error) {
+		uploadResult.TotalCount[threadId]++
+		var uploaded bool
+		events.started()
+		defer func() {
+			events.finished(uploaded, err)
+		}()
+		logMsgPrefix := clientutils.GetLogMsgPrefix(threadId, us.DryRun)
+
+		archiveDataReader := content.NewContentReader(archiveData.writer.GetFilePath(), archiveData.writer.GetArrayKey())
+		defer func() {
+			deferErr := archiveDataReader.Close()
+			if err == nil {
+				err = deferErr
+			}
+		}()
+		targetUrlWithProps, err := buildUploadUrls(us.ArtDetails.GetUrl(), targetPath, archiveData.uploadParams.BuildProps, archiveData.uploadParams.GetDebian(), archiveData.uploadParams.TargetProps)
+		if err != nil {
+			return
+		}
+		entries, err := us.collectTarEntries(archiveDataReader, archiveData.uploadParams)
+		if err != nil {
+			return
+		}
+		tempFile, err := fileutils.CreateTempFile()
+		if errorutils.CheckError(err) != nil {
+			return
+		}
+		defer func() {
+			deferErr := os.Remove(tempFile.Name())
+			if err == nil {
+				err = errorutils.CheckError(deferErr)
+			}
+		}()
+		details, err := us.writeTarArchive(tempFile, entries, archiveData.uploadParams)
+		if closeErr := tempFile.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+		if err != nil {
+			return
+		}
+		log.Info(logMsgPrefix+"Uploading artifact:", targetPath)
+
+		var archiveFile *os.File
+		defer func() {
+			if archiveFile != nil {
+				_ = archiveFile.Close()
+			}
+		}()
+		getReaderFunc := func() (io.Reader, error) {
+			if archiveFile != nil {
+				_ = archiveFile.Close()
+			}
+			file, e := os.Open(tempFile.Name())
+			if e != nil {
+				return nil, errorutils.CheckError(e)
+			}
+			archiveFile = file
+			return file, nil
+		}
+		uploaded, err = us.uploadFileFromReader(getReaderFunc, targetUrlWithProps, archiveData.uploadParams, logMsgPrefix, details, events)
+		if err != nil || !uploaded {
+			return
+		}
+		uploadResult.SuccessCount[threadId]++
+		if us.saveSummary {
+			for _, entry := range entries {
+				if err = us.resultsManager.addNonFinalResult(entry.artifact.LocalPath, targetPath, us.ArtDetails.GetUrl()); err != nil {
+					return
+				}
+			}
+			err = us.resultsManager.finalizeResult(targetPath, &details.Checksum)
+		}
+		return
+	}
+}
+
+type tarEntry struct {
+	name      string
+	localPath string
+	info      os.FileInfo
+	artifact  clientutils.Artifact
+}
+
+// Reads the files to archive and sorts them by their path in the archive.
+func (us *UploadService) collectTarEntries(archiveDataReader *content.ContentReader, uploadParams UploadParams) ([]tarEntry, error) {
+	var entries []tarEntry
+	for uploadData := new(UploadData); archiveDataReader.NextRecord(uploadData) == nil; uploadData = new(UploadData) {
+		localPath := getArchiveSourcePath(&uploadData.Artifact, uploadParams.Symlink)
+		info, err := os.Lstat(localPath)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		name := getArchiveEntryName(&uploadData.Artifact, localPath, info, uploadParams.Flat)
+		entries = append(entries, tarEntry{name: name, localPath: localPath, info: info, artifact: uploadData.Artifact})
+	}
+	if err := archiveDataReader.GetError(); err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// Writes the entries as a tar archive, compressed according to uploadParams.Archive, and returns the archive's size and checksums.
+func (us *UploadService) writeTarArchive(output io.Writer, entries []tarEntry, uploadParams UploadParams) (details *fileutils.FileDetails, err error) {
+	counter := &countingWriter{}
+	writers := []io.Writer{output, counter}
+	var md5Hash, sha1Hash, sha256Hash hash.Hash
+	if uploadParams.ChecksumsCalcEnabled {
+		md5Hash, sha1Hash, sha256Hash = md5.New(), sha1.New(), sha256.New()
+		writers = append(writers, md5Hash, sha1Hash, sha256Hash)
+	}
+	archiveWriter, err := newCompressedWriter(io.MultiWriter(writers...), uploadParams.Archive)
+	if err != nil {
+		return nil, err
+	}
+	tarWriter := tar.NewWriter(archiveWriter)
+	for _, entry := range entries {
+		if err = us.addFileToTar(entry, uploadParams.Symlink, tarWriter); err != nil {
+			return nil, err
+		}
+	}
+	if err = tarWriter.Close(); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = archiveWriter.Close(); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	details = &fileutils.FileDetails{Size: counter.size}
+	if uploadParams.ChecksumsCalcEnabled {
+		details.Checksum.Md5 = hex.EncodeToString(md5Hash.Sum(nil))
+		details.Checksum.Sha1 = hex.EncodeToString(sha1Hash.Sum(nil))
+		details.Checksum.Sha256 = hex.EncodeToString(sha256Hash.Sum(nil))
+	}
+	return details, nil
+}
+
+func newCompressedWriter(writer io.Writer, archive string) (io.WriteCloser, error) {
+	switch archive {
+	case TarGzArchive:
+		return gzip.NewWriter(writer), nil
+	case TarZstArchive:
+		// A single encoder goroutine keeps the output independent of scheduling.
+		zstdWriter, err := zstd.NewWriter(writer, zstd.WithEncoderConcurrency(1))
+		return zstdWriter, errorutils.CheckError(err)
+	case TarArchive:
+		return nopWriteCloser{writer}, nil
+	}
+	return nil, errorutils.CheckErrorf("unsupported archive format: '%s'", archive)
+}
+
+func (us *UploadService) addFileToTar(entry tarEntry, symlink bool, tarWriter *tar.Writer) (err error) {
+	linkTarget := ""
+	if entry.artifact.SymlinkTargetPath != "" && symlink {
+		linkTarget = filepath.ToSlash(entry.artifact.SymlinkTargetPath)
+	}
+	header, err := tar.FileInfoHeader(entry.info, linkTarget)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	header.Name = entry.name
+	if entry.info.IsDir() {
+		header.Name += "/"
+	}
+	// Only the permissions and the type of the entry are taken from the local file.
+	header.ModTime = tarEntriesModTime
+	header.AccessTime, header.ChangeTime = time.Time{}, time.Time{}
+	header.Uid, header.Gid = 0, 0
+	header.Uname, header.Gname = "", ""
+	header.Format = tar.FormatPAX
+	if err = tarWriter.WriteHeader(header); errorutils.CheckError(err) != nil {
+		return
+	}
+	if header.Typeflag != tar.TypeReg {
+		return
+	}
+	file, err := os.Open(entry.localPath)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	defer func() {
+		deferErr := file.Close()
+		if err == nil {
+			err = errorutils.CheckError(deferErr)
+		}
+	}()
+	var reader io.Reader = file
+	if us.Progress != nil {
+		progressReader := us.Progress.NewProgressReader(entry.info.Size(), "Archiving", entry.localPath)
+		reader = progressReader.ActionWithProgress(file)
+		defer us.Progress.RemoveProgress(progressReader.GetId())
+	}
+	_, err = io.Copy(tarWriter, reader)
+	return errorutils.CheckError(err)
+}
+
+// In case of a symlink there are 2 options:
+// 1. symlink == true : the symlink itself is archived.
+// 2. symlink == false : the symlink's target is archived.
+func getArchiveSourcePath(artifact *clientutils.Artifact, symlink bool) string {
+	if artifact.SymlinkTargetPath != "" && !symlink {
+		return artifact.SymlinkTargetPath
+	}
+	return artifact.LocalPath
+}
+
+// Returns the slash separated path of the file inside the archive, without a trailing slash for directories.
+func getArchiveEntryName(artifact *clientutils.Artifact, localPath string, info os.FileInfo, flat bool) string {
+	if artifact.TargetPathInArchive != "" {
+		return filepath.ToSlash(artifact.TargetPathInArchive)
+	}
+	if !flat {
+		return filepath.ToSlash(clientutils.TrimPath(localPath))
+	}
+	return info.Name()
+}
+
+type countingWriter struct {
+	size int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.size += int64(len(p))
+	return len(p), nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTarArchive(t *testing.T) {
+	tempDir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "b.sh"), []byte("#!/bin/sh"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "a.txt"), []byte("a"), 0600))
+	assert.NoError(t, os.Symlink("a.txt", filepath.Join(tempDir, "link")))
+	artifacts := []clientutils.Artifact{
+		{LocalPath: filepath.Join(tempDir, "b.sh")},
+		{LocalPath: filepath.Join(tempDir, "link"), SymlinkTargetPath: "a.txt"},
+		{LocalPath: filepath.Join(tempDir, "a.txt"), TargetPathInArchive: "docs/a.txt"},
+	}
+
+	for _, archive := range []string{TarArchive, TarGzArchive, TarZstArchive} {
+		t.Run(archive, func(t *testing.T) {
+			uploadParams := NewUploadParams()
+			uploadParams.Archive = archive
+			uploadParams.Flat = true
+			uploadParams.Symlink = true
+
+			first, details := writeTestTarArchive(t, artifacts, uploadParams)
+			sha1Sum := sha1.Sum(first)
+			assert.Equal(t, hex.EncodeToString(sha1Sum[:]), details.Checksum.Sha1)
+			assert.Equal(t, int64(len(first)), details.Size)
+
+			// Archiving the same content with different timestamps should produce an identical archive.
+			later := time.Now().Add(time.Hour)
+			assert.NoError(t, os.Chtimes(filepath.Join(tempDir, "b.sh"), later, later))
+			second, _ := writeTestTarArchive(t, artifacts, uploadParams)
+			assert.Equal(t, first, second)
+
+			headers := readTestTarHeaders(t, first, archive)
+			if assert.Len(t, headers, 3) {
+				assert.Equal(t, "b.sh", headers[0].Name)
+				assert.Equal(t, int64(0755), headers[0].Mode&0777)
+				assert.Equal(t, "docs/a.txt", headers[1].Name)
+				assert.Equal(t, int64(0600), headers[1].Mode&0777)
+				assert.Equal(t, "link", headers[2].Name)
+				assert.Equal(t, byte(tar.TypeSymlink), headers[2].Typeflag)
+				assert.Equal(t, "a.txt", headers[2].Linkname)
+				for _, header := range headers {
+					assert.Equal(t, 0, header.Uid)
+					assert.Equal(t, 0, header.Gid)
+					assert.True(t, header.ModTime.Equal(tarEntriesModTime))
+				}
+			}
+		})
+	}
+}
+
+func writeTestTarArchive(t *testing.T, artifacts []clientutils.Artifact, uploadParams UploadParams) ([]byte, *fileutils.FileDetails) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, artifact := range artifacts {
+		writer.Write(UploadData{Artifact: artifact})
+	}
+	assert.NoError(t, writer.Close())
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	us := NewUploadService(nil)
+	entries, err := us.collectTarEntries(reader, uploadParams)
+	assert.NoError(t, err)
+	var buffer bytes.Buffer
+	details, err := us.writeTarArchive(&buffer, entries, uploadParams)
+	assert.NoError(t, err)
+	return buffer.Bytes(), details
+}
+
+func readTestTarHeaders(t *testing.T, archive []byte, format string) (headers []*tar.Header) {
+	var reader io.Reader = bytes.NewReader(archive)
+	switch format {
+	case TarGzArchive:
+		gzipReader, err := gzip.NewReader(reader)
+		assert.NoError(t, err)
+		reader = gzipReader
+	case TarZstArchive:
+		zstdReader, err := zstd.NewReader(reader)
+		assert.NoError(t, err)
+		defer zstdReader.Close()
+		reader = zstdReader
+	}
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		headers = append(headers, header)
+	}
+}
+
+func TestIsSupportedArchive(t *testing.T) {
+	for _, archive := range []string{ZipArchive, TarArchive, TarGzArchive, TarZstArchive} {
+		assert.True(t, isSupportedArchive(archive))
+	}
+	assert.False(t, isSupportedArchive("rar"))
+}
@@ -0,0 +1,345 @@
+package services
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jfrog/gofrog/stringutils"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	ioutils "github.com/madotis/jfrog-client-go/utils/io"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+)
+
+// NamedReader is an in-memory upload source.
+// Name is the slash separated path of the file, which the upload pattern is matched against.
+type NamedReader struct {
+	Name   string
+	Reader io.Reader
+}
+
+// Buffers the readers into an fs.FS, so that they can be uploaded using the same pattern semantics as files.
+func NewReadersFS(readers ...NamedReader) (fs.FS, error) {
+	readersFs := &readersFS{files: make(map[string]*memFile), dirs: map[string]map[string]fs.DirEntry{".": {}}}
+	for _, namedReader := range readers {
+		name := path.Clean(strings.TrimPrefix(namedReader.Name, "/"))
+		if !fs.ValidPath(name) || name == "." {
+			return nil, errorutils.CheckErrorf("invalid upload source name: '%s'", namedReader.Name)
+		}
+		if _, exists := readersFs.files[name]; exists {
+			return nil, errorutils.CheckErrorf("duplicate upload source name: '%s'", namedReader.Name)
+		}
+		content, err := io.ReadAll(namedReader.Reader)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		file := &memFile{info: memFileInfo{name: path.Base(name), size: int64(len(content))}, content: content}
+		readersFs.files[name] = file
+		readersFs.addToParent(name, file.info)
+	}
+	return readersFs, nil
+}
+
+// Resolves UploadParams.Readers into UploadParams.SourceFS.
+func resolveUploadSource(uploadParams *UploadParams) error {
+	if uploadParams.SourceFS != nil || len(uploadParams.Readers) == 0 {
+		return nil
+	}
+	sourceFS, err := NewReadersFS(uploadParams.Readers...)
+	if err != nil {
+		return err
+	}
+	uploadParams.SourceFS = sourceFS
+	uploadParams.Readers = nil
+	return nil
+}
+
+func collectFilesFromFS(uploadParams UploadParams, progressMgr ioutils.ProgressMgr, dataHandlerFunc UploadDataHandlerFunc) error {
+	if uploadParams.Archive != "" {
+		return errorutils.CheckErrorf("the archive option is not supported when uploading from an fs.FS or readers source")
+	}
+	if uploadParams.IsAddVcsProps() {
+		return errorutils.CheckErrorf("VCS properties cannot be added when uploading from an fs.FS or readers source")
+	}
+	pattern := strings.TrimPrefix(strings.TrimPrefix(uploadParams.GetPattern(), "./"), "/")
+	patternRegex, err := regexp.Compile(fsPatternToRegexp(pattern, uploadParams))
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	excludeRegex, err := fsExclusionsToRegexp(uploadParams)
+	if err != nil {
+		return err
+	}
+	rootPath := getFSRootPath(pattern, uploadParams.Regexp)
+	var paths []string
+	dirs := make(map[string]bool)
+	err = fs.WalkDir(uploadParams.SourceFS, rootPath, func(currentPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if currentPath == rootPath && entry.IsDir() {
+			return nil
+		}
+		if excludeRegex != nil && excludeRegex.MatchString(currentPath) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			dirs[currentPath] = true
+		}
+		paths = append(paths, currentPath)
+		if entry.IsDir() && !uploadParams.IsRecursive() {
+			return fs.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Longest files path first
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	var uploadedTargets, uploadedDirs []string
+	for _, currentPath := range paths {
+		isDir := dirs[currentPath]
+		if isDir && !uploadParams.IsIncludeDirs() {
+			continue
+		}
+		matches := patternRegex.FindStringSubmatch(currentPath)
+		if len(matches) == 0 {
+			continue
+		}
+		target, placeholdersUsed, err := clientutils.ReplacePlaceHolders(matches, uploadParams.GetTarget(), uploadParams.Regexp)
+		if err != nil {
+			return err
+		}
+		target = getUploadTarget(currentPath, target, uploadParams.IsFlat(), placeholdersUsed)
+		if isDir {
+			if skipDirUpload(uploadedTargets, uploadedDirs, target, currentPath, uploadParams.IsIncludeDirs()) {
+				continue
+			}
+			uploadedDirs = append(uploadedDirs, currentPath)
+		}
+		artifact := clientutils.Artifact{LocalPath: currentPath, TargetPath: target}
+		props, err := createProperties(artifact, uploadParams)
+		if err != nil {
+			return err
+		}
+		incGeneralProgressTotal(progressMgr, uploadParams)
+		dataHandlerFunc(UploadData{Artifact: artifact, TargetProps: props, BuildProps: uploadParams.BuildProps, IsDir: isDir})
+		uploadedTargets = append(uploadedTargets, target)
+	}
+	return nil
+}
+
+// Paths in an fs.FS are always separated by '/', so the pattern is converted without the OS specific path cleaning.
+func fsPatternToRegexp(pattern string, uploadParams UploadParams) string {
+	switch uploadParams.GetPatternType() {
+	case clientutils.RegExp:
+		return pattern
+	case clientutils.AntPattern:
+		pattern = addEscapingParenthesesForUpload(pattern, uploadParams.GetTarget(), "")
+		return clientutils.AntToRegex(pattern)
+	default:
+		return addEscapingParenthesesForUpload(stringutils.WildcardPatternToRegExp(pattern), uploadParams.GetTarget(), "")
+	}
+}
+
+func fsExclusionsToRegexp(uploadParams UploadParams) (*regexp.Regexp, error) {
+	var exclusions []string
+	for _, exclusion := range uploadParams.GetExclusions() {
+		if exclusion == "" {
+			continue
+		}
+		exclusion = strings.TrimPrefix(exclusion, "./")
+		if uploadParams.GetPatternType() == clientutils.AntPattern {
+			exclusion = clientutils.AntToRegex(exclusion)
+		} else if uploadParams.GetPatternType() == clientutils.WildCardPattern {
+			exclusion = stringutils.WildcardPatternToRegExp(exclusion)
+		}
+		exclusions = append(exclusions, "("+exclusion+")")
+	}
+	if len(exclusions) == 0 {
+		return nil, nil
+	}
+	excludeRegex, err := regexp.Compile(strings.Join(exclusions, "|"))
+	return excludeRegex, errorutils.CheckError(err)
+}
+
+// Returns the deepest directory which contains all the paths the pattern may match.
+func getFSRootPath(pattern string, isRegexp bool) string {
+	if isRegexp {
+		return "."
+	}
+	if specialCharIndex := strings.IndexAny(pattern, "*?("); specialCharIndex >= 0 {
+		pattern = pattern[:specialCharIndex]
+	}
+	if strings.HasSuffix(pattern, "/") {
+		return path.Clean(pattern)
+	}
+	return path.Dir(pattern)
+}
+
+func (us *UploadService) uploadFileFromFS(artifact UploadData, uploadParams UploadParams, logMsgPrefix string, events *fileTransferEvents) (*fileutils.FileDetails, bool, error) {
+	targetUrlWithProps, err := buildUploadUrls(us.ArtDetails.GetUrl(), artifact.Artifact.TargetPath, artifact.BuildProps, uploadParams.GetDebian(), artifact.TargetProps)
+	if err != nil {
+		return nil, false, err
+	}
+	details, err := getFSFileDetails(uploadParams.SourceFS, artifact.Artifact.LocalPath, uploadParams.ChecksumsCalcEnabled)
+	if err != nil {
+		return nil, false, err
+	}
+	var file fs.File
+	defer func() {
+		if file != nil {
+			_ = file.Close()
+		}
+	}()
+	getReaderFunc := func() (io.Reader, error) {
+		// A retry reopens the file, to read it from the start.
+		if file != nil {
+			_ = file.Close()
+		}
+		openedFile, e := uploadParams.SourceFS.Open(artifact.Artifact.LocalPath)
+		if e != nil {
+			return nil, errorutils.CheckError(e)
+		}
+		file = openedFile
+		return file, nil
+	}
+	uploaded, err := us.uploadFileFromReader(getReaderFunc, targetUrlWithProps, uploadParams, logMsgPrefix, details, events)
+	return details, uploaded, err
+}
+
+func getFSFileDetails(sourceFS fs.FS, filePath string, includeChecksums bool) (details *fileutils.FileDetails, err error) {
+	file, err := sourceFS.Open(filePath)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	defer func() {
+		e := file.Close()
+		if err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	if includeChecksums {
+		return fileutils.GetFileDetailsFromReader(file, true)
+	}
+	info, err := file.Stat()
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	return &fileutils.FileDetails{Size: info.Size()}, nil
+}
+
+// readersFS is a read-only in-memory fs.FS, holding the content of the readers passed to NewReadersFS.
+type readersFS struct {
+	files map[string]*memFile
+	dirs  map[string]map[string]fs.DirEntry
+}
+
+func (rfs *readersFS) addToParent(name string, info memFileInfo) {
+	parent := path.Dir(name)
+	if _, exists := rfs.dirs[parent]; !exists {
+		rfs.dirs[parent] = make(map[string]fs.DirEntry)
+		rfs.addToParent(parent, memFileInfo{name: path.Base(parent), isDir: true})
+	}
+	rfs.dirs[parent][info.name] = info
+}
+
+func (rfs *readersFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if file, exists := rfs.files[name]; exists {
+		return &memFileReader{Reader: bytes.NewReader(file.content), info: file.info}, nil
+	}
+	if _, exists := rfs.dirs[name]; exists {
+		entries, _ := rfs.ReadDir(name)
+		return &memDir{info: memFileInfo{name: path.Base(name), isDir: true}, entries: entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (rfs *readersFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	dir, exists := rfs.dirs[name]
+	if !exists {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries := make([]fs.DirEntry, 0, len(dir))
+	for _, entry := range dir {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+type memFile struct {
+	info    memFileInfo
+	content []byte
+}
+
+type memFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (fi memFileInfo) Name() string               { return fi.name }
+func (fi memFileInfo) Size() int64                { return fi.size }
+func (fi memFileInfo) ModTime() time.Time         { return time.Time{} }
+func (fi memFileInfo) IsDir() bool                { return fi.isDir }
+func (fi memFileInfo) Sys() any                   { return nil }
+func (fi memFileInfo) Type() fs.FileMode          { return fi.Mode().Type() }
+func (fi memFileInfo) Info() (fs.FileInfo, error) { return fi, nil }
+
+func (fi memFileInfo) Mode() fs.FileMode {
+	if fi.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+type memFileReader struct {
+	*bytes.Reader
+	info memFileInfo
+}
+
+func (f *memFileReader) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFileReader) Close() error               { return nil }
+
+type memDir struct {
+	info    memFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+func (d *memDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if count <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(remaining) {
+		count = len(remaining)
+	}
+	d.offset += count
+	return remaining[:count], nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectFilesFromFS(t *testing.T) {
+	sourceFS := fstest.MapFS{
+		"dist/app.jar":          {Data: []byte("app")},
+		"dist/app-sources.jar":  {Data: []byte("sources")},
+		"dist/lib/util.jar":     {Data: []byte("util")},
+		"dist/lib/readme.txt":   {Data: []byte("readme")},
+		"docs/index.html":       {Data: []byte("index")},
+		"dist/tmp/scratch.jar":  {Data: []byte("scratch")},
+		"dist/lib/nested/a.jar": {Data: []byte("a")},
+	}
+	tests := []struct {
+		name       string
+		pattern    string
+		target     string
+		flat       bool
+		recursive  bool
+		exclusions []string
+		expected   map[string]string
+	}{
+		{"flat", "dist/*.jar", "repo/libs/", true, true, []string{"dist/tmp/*"}, map[string]string{
+			"dist/app.jar":          "repo/libs/app.jar",
+			"dist/app-sources.jar":  "repo/libs/app-sources.jar",
+			"dist/lib/util.jar":     "repo/libs/util.jar",
+			"dist/lib/nested/a.jar": "repo/libs/a.jar",
+		}},
+		{"not flat", "dist/lib/*.jar", "repo/", false, true, nil, map[string]string{
+			"dist/lib/util.jar":     "repo/dist/lib/util.jar",
+			"dist/lib/nested/a.jar": "repo/dist/lib/nested/a.jar",
+		}},
+		{"not recursive", "dist/*.jar", "repo/", true, false, nil, map[string]string{
+			"dist/app.jar":         "repo/app.jar",
+			"dist/app-sources.jar": "repo/app-sources.jar",
+		}},
+		{"placeholders", "dist/lib/(*).(*)", "repo/{2}/{1}.{2}", false, false, nil, map[string]string{
+			"dist/lib/util.jar":   "repo/jar/util.jar",
+			"dist/lib/readme.txt": "repo/txt/readme.txt",
+		}},
+		{"single file", "docs/index.html", "repo/site/", true, true, nil, map[string]string{
+			"docs/index.html": "repo/site/index.html",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uploadParams := NewUploadParams()
+			uploadParams.SourceFS = sourceFS
+			uploadParams.Pattern = test.pattern
+			uploadParams.Target = test.target
+			uploadParams.Flat = test.flat
+			uploadParams.Recursive = test.recursive
+			uploadParams.Exclusions = test.exclusions
+			actual := make(map[string]string)
+			err := CollectFilesForUpload(uploadParams, nil, nil, func(data UploadData) {
+				actual[data.Artifact.LocalPath] = data.Artifact.TargetPath
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestCollectFilesFromFSProps(t *testing.T) {
+	uploadParams := NewUploadParams()
+	uploadParams.Readers = []NamedReader{{Name: "gen/report.json", Reader: strings.NewReader("{}")}}
+	uploadParams.Pattern = "gen/*"
+	uploadParams.Target = "repo/reports/"
+	uploadParams.Flat = true
+	uploadParams.TargetProps = utils.NewProperties()
+	uploadParams.TargetProps.AddProperty("key", "value")
+	var collected []UploadData
+	assert.NoError(t, CollectFilesForUpload(uploadParams, nil, nil, func(data UploadData) {
+		collected = append(collected, data)
+	}))
+	if assert.Len(t, collected, 1) {
+		assert.Equal(t, "repo/reports/report.json", collected[0].Artifact.TargetPath)
+		assert.Equal(t, "key=value", collected[0].TargetProps.ToEncodedString(false))
+	}
+
+	uploadParams.Archive = "zip"
+	uploadParams.Target = "repo/reports.zip"
+	assert.Error(t, CollectFilesForUpload(uploadParams, nil, nil, func(UploadData) {}))
+}
+
+func TestNewReadersFS(t *testing.T) {
+	readersFS, err := NewReadersFS(
+		NamedReader{Name: "a/b/c.txt", Reader: strings.NewReader("abc")},
+		NamedReader{Name: "/a/d.txt", Reader: strings.NewReader("d")},
+		NamedReader{Name: "e.txt", Reader: strings.NewReader("")},
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, fstest.TestFS(readersFS, "a/b/c.txt", "a/d.txt", "e.txt"))
+
+	file, err := readersFS.Open("a/b/c.txt")
+	assert.NoError(t, err)
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", string(content))
+	assert.NoError(t, file.Close())
+
+	details, err := getFSFileDetails(readersFS, "a/b/c.txt", true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), details.Size)
+	assert.Equal(t, "a9993e364706816aba3e25717850c26c9cd0d89d", details.Checksum.Sha1)
+
+	_, err = readersFS.Open("missing.txt")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = NewReadersFS(NamedReader{Name: "x.txt", Reader: strings.NewReader("")}, NamedReader{Name: "./x.txt", Reader: strings.NewReader("")})
+	assert.Error(t, err)
+	_, err = NewReadersFS(NamedReader{Name: "../x.txt", Reader: strings.NewReader("")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+)
+
+// UploadIndex is a local record of previously uploaded files.
+// A file whose size and modification time did not change since it was last deployed to the same target is not uploaded again,
+// and no request is sent to Artifactory for it, unless VerifyInterval has passed since the target was last verified.
+type UploadIndex struct {
+	filePath string
+	// When positive, targets which were not verified during this interval are checked against Artifactory using a HEAD request.
+	VerifyInterval time.Duration
+	entries        map[string]*UploadIndexEntry
+	mutex          sync.Mutex
+}
+
+type UploadIndexEntry struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"modTime"`
+	entities.Checksum
+	// The URL, including the matrix params, the file was last deployed to.
+	Target       string `json:"target,omitempty"`
+	LastVerified int64  `json:"lastVerified,omitempty"`
+}
+
+// Loads the index stored in filePath. A missing file results in an empty index.
+func LoadUploadIndex(filePath string) (*UploadIndex, error) {
+	index := &UploadIndex{filePath: filePath, entries: make(map[string]*UploadIndexEntry)}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, errorutils.CheckError(err)
+	}
+	if len(content) == 0 {
+		return index, nil
+	}
+	return index, errorutils.CheckError(json.Unmarshal(content, &index.entries))
+}
+
+// Writes the index to its file. The file is replaced atomically, so an interrupted save keeps the previous index.
+func (ui *UploadIndex) Save() error {
+	ui.mutex.Lock()
+	content, err := json.Marshal(ui.entries)
+	ui.mutex.Unlock()
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(ui.filePath), 0755); errorutils.CheckError(err) != nil {
+		return err
+	}
+	tempFilePath := ui.filePath + ".tmp"
+	if err = os.WriteFile(tempFilePath, content, 0600); errorutils.CheckError(err) != nil {
+		return err
+	}
+	return errorutils.CheckError(os.Rename(tempFilePath, ui.filePath))
+}
+
+func (ui *UploadIndex) GetEntry(localPath string) *UploadIndexEntry {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	if entry, ok := ui.entries[indexKey(localPath)]; ok {
+		entryCopy := *entry
+		return &entryCopy
+	}
+	return nil
+}
+
+// Returns the stored file details if the file was not modified since it was indexed, or nil otherwise.
+func (ui *UploadIndex) getUnchangedFileDetails(localPath string, fileInfo os.FileInfo) *fileutils.FileDetails {
+	entry := ui.GetEntry(localPath)
+	if entry == nil || !entry.matches(fileInfo) || entry.Sha1 == "" {
+		return nil
+	}
+	return &fileutils.FileDetails{Checksum: entry.Checksum, Size: entry.Size}
+}
+
+// Returns the index entry if the file was not modified since it was deployed to target, or nil otherwise.
+func (ui *UploadIndex) getDeployedEntry(localPath, target string, fileInfo os.FileInfo) *UploadIndexEntry {
+	entry := ui.GetEntry(localPath)
+	if entry == nil || !entry.matches(fileInfo) || entry.Target != target || entry.Sha1 == "" {
+		return nil
+	}
+	return entry
+}
+
+func (ui *UploadIndex) needsVerification(entry *UploadIndexEntry, now time.Time) bool {
+	return ui.VerifyInterval > 0 && now.Sub(time.Unix(0, entry.LastVerified)) >= ui.VerifyInterval
+}
+
+func (ui *UploadIndex) setDeployed(localPath, target string, fileInfo os.FileInfo, details *fileutils.FileDetails, now time.Time) {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	ui.entries[indexKey(localPath)] = &UploadIndexEntry{
+		Size:         fileInfo.Size(),
+		ModTime:      fileInfo.ModTime().UnixNano(),
+		Checksum:     details.Checksum,
+		Target:       target,
+		LastVerified: now.UnixNano(),
+	}
+}
+
+func (ui *UploadIndex) setVerified(localPath string, now time.Time) {
+	ui.mutex.Lock()
+	defer ui.mutex.Unlock()
+	if entry, ok := ui.entries[indexKey(localPath)]; ok {
+		entry.LastVerified = now.UnixNano()
+	}
+}
+
+func (entry *UploadIndexEntry) matches(fileInfo os.FileInfo) bool {
+	return entry.Size == fileInfo.Size() && entry.ModTime == fileInfo.ModTime().UnixNano()
+}
+
+func indexKey(localPath string) string {
+	if absPath, err := filepath.Abs(localPath); err == nil {
+		return absPath
+	}
+	return localPath
+}
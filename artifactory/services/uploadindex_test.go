@@ -0,0 +1,71 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+	clientutils "github.com/madotis/jfrog-client-go/utils"
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	localPath := filepath.Join(tempDir, "file.txt")
+	assert.NoError(t, os.WriteFile(localPath, []byte("content"), 0644))
+	fileInfo, err := os.Lstat(localPath)
+	assert.NoError(t, err)
+	target := "http://localhost:8081/artifactory/repo/file.txt;k=v"
+	details := &fileutils.FileDetails{Checksum: entities.Checksum{Sha1: "sha1", Md5: "md5", Sha256: "sha256"}, Size: fileInfo.Size()}
+
+	// A missing index file results in an empty index.
+	indexPath := filepath.Join(tempDir, "index", "upload-index.json")
+	index, err := LoadUploadIndex(indexPath)
+	assert.NoError(t, err)
+	assert.Nil(t, index.GetEntry(localPath))
+
+	index.setDeployed(localPath, target, fileInfo, details, time.Now())
+	assert.NoError(t, index.Save())
+
+	loaded, err := LoadUploadIndex(indexPath)
+	assert.NoError(t, err)
+	assert.NotNil(t, loaded.getDeployedEntry(localPath, target, fileInfo))
+	assert.Equal(t, details, loaded.getUnchangedFileDetails(localPath, fileInfo))
+	// A different target or props require uploading again, but the checksums can still be reused.
+	assert.Nil(t, loaded.getDeployedEntry(localPath, "http://localhost:8081/artifactory/repo/file.txt", fileInfo))
+
+	// Modifying the file invalidates its entry.
+	assert.NoError(t, os.WriteFile(localPath, []byte("modified content"), 0644))
+	modifiedInfo, err := os.Lstat(localPath)
+	assert.NoError(t, err)
+	assert.Nil(t, loaded.getDeployedEntry(localPath, target, modifiedInfo))
+	assert.Nil(t, loaded.getUnchangedFileDetails(localPath, modifiedInfo))
+}
+
+func TestUploadIndexSkipsUnchangedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	localPath := filepath.Join(tempDir, "file.txt")
+	assert.NoError(t, os.WriteFile(localPath, []byte("content"), 0644))
+	fileInfo, err := os.Lstat(localPath)
+	assert.NoError(t, err)
+	target := "http://localhost:8081/artifactory/repo/file.txt"
+	details := &fileutils.FileDetails{Checksum: entities.Checksum{Sha1: "sha1"}, Size: fileInfo.Size()}
+
+	index, err := LoadUploadIndex(filepath.Join(tempDir, "upload-index.json"))
+	assert.NoError(t, err)
+	index.setDeployed(localPath, target, fileInfo, details, time.Now())
+
+	// The upload service has no HTTP client, so skipping must not send any request.
+	us := NewUploadService(nil)
+	artifact := clientutils.Artifact{LocalPath: localPath, TargetPath: "repo/file.txt"}
+	assert.Equal(t, details, us.getDeployedFileDetails(artifact, target, fileInfo, index, ""))
+	assert.Nil(t, us.getDeployedFileDetails(artifact, target+";k=v", fileInfo, index, ""))
+
+	index.VerifyInterval = time.Hour
+	entry := index.GetEntry(localPath)
+	assert.False(t, index.needsVerification(entry, time.Now()))
+	assert.True(t, index.needsVerification(entry, time.Now().Add(2*time.Hour)))
+}
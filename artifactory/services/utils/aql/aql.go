@@ -0,0 +1,289 @@
+// Package aql builds Artifactory Query Language queries.
+//
+//	query := aql.Items.Find(aql.Criteria{
+//		aql.Field("repo").Equal("libs-release-local"),
+//		aql.Or(
+//			aql.Criteria{aql.Field("name").Match("*.jar")},
+//			aql.Criteria{aql.Property("build.name").Equal("my-build")},
+//		),
+//	}).Include("name", "repo", "path").Sort(aql.Desc, "created").Limit(10)
+//
+// The values are escaped, and the query's String() can be passed to ArtifactoryServicesManager.Aql.
+package aql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The date format of AQL values.
+const timeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// The domain a query finds. Each domain returns different entities, with different fields.
+type Domain string
+
+const (
+	Items            Domain = "items"
+	Builds           Domain = "builds"
+	Entries          Domain = "entries"
+	ArchiveEntries   Domain = "archive.entries"
+	Releases         Domain = "releases"
+	ReleaseArtifacts Domain = "release_artifacts"
+	Artifacts        Domain = "artifacts"
+	Dependencies     Domain = "dependencies"
+	Modules          Domain = "modules"
+	Properties       Domain = "properties"
+	Statistics       Domain = "statistics"
+)
+
+// Returns a query of the domain's entities which match the criteria object.
+func (d Domain) Find(object Object) *Query {
+	return &Query{domain: d, object: object}
+}
+
+type SortOrder string
+
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// A JSON object of criteria, which matches if all of its criteria match.
+type Object interface {
+	String() string
+}
+
+// An object of criteria, written in order. Criteria with the same key, like several $or criteria, are allowed.
+type Criteria []Criterion
+
+func (c Criteria) String() string {
+	parts := make([]string, len(c))
+	for i, criterion := range c {
+		parts[i] = criterion.String()
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// An object which is already written in AQL, like the AQL of a file spec. It is written as is, without being validated.
+type RawObject string
+
+func (ro RawObject) String() string {
+	return string(ro)
+}
+
+// A single key of a criteria object, and its value.
+type Criterion struct {
+	key   string
+	value string
+}
+
+func (c Criterion) String() string {
+	return quote(c.key) + ":" + c.value
+}
+
+// Matches if all the objects match.
+func And(objects ...Object) Criterion {
+	return Criterion{key: "$and", value: joinObjects(objects)}
+}
+
+// Matches if any of the objects matches.
+func Or(objects ...Object) Criterion {
+	return Criterion{key: "$or", value: joinObjects(objects)}
+}
+
+func joinObjects(objects []Object) string {
+	parts := make([]string, len(objects))
+	for i, object := range objects {
+		parts[i] = object.String()
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// A field of the domain's entities, or of the entities of a related domain, like "archive.entry.name" in the items domain.
+type Field string
+
+// Returns the field of a property's values. Properties are written with a leading '@' in the items domain.
+func Property(key string) Field {
+	return Field("@" + key)
+}
+
+// Values may be strings, numbers, booleans, times or nil, which matches missing fields.
+func (f Field) Equal(value interface{}) Criterion {
+	if value == nil {
+		return f.compare("$eq", value)
+	}
+	return Criterion{key: string(f), value: encodeValue(value)}
+}
+
+func (f Field) NotEqual(value interface{}) Criterion {
+	return f.compare("$ne", value)
+}
+
+// Matches a pattern, in which '*' matches any number of characters and '?' matches a single character.
+func (f Field) Match(pattern string) Criterion {
+	return f.compare("$match", pattern)
+}
+
+func (f Field) NotMatch(pattern string) Criterion {
+	return f.compare("$nmatch", pattern)
+}
+
+// Matches the pattern if it contains a wildcard, or equals it otherwise, since equality is faster to evaluate.
+func (f Field) Pattern(pattern string) Criterion {
+	if strings.Contains(pattern, "*") {
+		return f.Match(pattern)
+	}
+	return f.Equal(pattern)
+}
+
+// Doesn't match the pattern if it contains a wildcard, or doesn't equal it otherwise.
+func (f Field) NotPattern(pattern string) Criterion {
+	if strings.Contains(pattern, "*") {
+		return f.NotMatch(pattern)
+	}
+	return f.NotEqual(pattern)
+}
+
+func (f Field) Greater(value interface{}) Criterion {
+	return f.compare("$gt", value)
+}
+
+func (f Field) GreaterOrEqual(value interface{}) Criterion {
+	return f.compare("$gte", value)
+}
+
+func (f Field) Less(value interface{}) Criterion {
+	return f.compare("$lt", value)
+}
+
+func (f Field) LessOrEqual(value interface{}) Criterion {
+	return f.compare("$lte", value)
+}
+
+// Matches dates in the last period, like "3d" or "2w".
+func (f Field) Last(period string) Criterion {
+	return f.compare("$last", period)
+}
+
+// Matches dates before the last period, like "3d" or "2w".
+func (f Field) Before(period string) Criterion {
+	return f.compare("$before", period)
+}
+
+func (f Field) compare(operator string, value interface{}) Criterion {
+	return Criterion{key: string(f), value: "{" + quote(operator) + ":" + encodeValue(value) + "}"}
+}
+
+func encodeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(v)
+	case time.Time:
+		return quote(v.Format(timeFormat))
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return quote(fmt.Sprint(v))
+	}
+}
+
+// Returns the string as a JSON string. Unlike json.Marshal, '<', '>' and '&' are not escaped.
+func quote(s string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// Encoding a string can't fail.
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// An AQL query. Its methods set the parts of the query, and return the query to allow chaining them.
+type Query struct {
+	domain     Domain
+	object     Object
+	include    []string
+	sortOrder  SortOrder
+	sortFields []string
+	offset     int
+	limit      int
+	transitive bool
+}
+
+// Sets the fields returned for each of the entities. The domain's default fields are returned if none are set.
+func (q *Query) Include(fields ...string) *Query {
+	q.include = fields
+	return q
+}
+
+// Sorts the results by the fields. An empty order sorts in ascending order.
+func (q *Query) Sort(order SortOrder, fields ...string) *Query {
+	if order == "" {
+		order = Asc
+	}
+	q.sortOrder = order
+	q.sortFields = fields
+	return q
+}
+
+// Skips the first results. Zero or less returns all the results.
+func (q *Query) Offset(offset int) *Query {
+	q.offset = offset
+	return q
+}
+
+// Limits the number of results. Zero or less returns all the results.
+func (q *Query) Limit(limit int) *Query {
+	q.limit = limit
+	return q
+}
+
+// Searches the remote repositories of a virtual repository too, and not only the local and cached artifacts.
+func (q *Query) Transitive(transitive bool) *Query {
+	q.transitive = transitive
+	return q
+}
+
+func (q *Query) String() string {
+	var sb strings.Builder
+	sb.WriteString(string(q.domain) + ".find(" + q.object.String() + ")")
+	if len(q.include) > 0 {
+		sb.WriteString(".include(" + quoteAll(q.include) + ")")
+	}
+	if len(q.sortFields) > 0 {
+		sb.WriteString(".sort({" + sortCriterion(q.sortOrder, q.sortFields).String() + "})")
+	}
+	if q.offset > 0 {
+		sb.WriteString(".offset(" + strconv.Itoa(q.offset) + ")")
+	}
+	if q.transitive {
+		sb.WriteString(".transitive()")
+	}
+	if q.limit > 0 {
+		sb.WriteString(".limit(" + strconv.Itoa(q.limit) + ")")
+	}
+	return sb.String()
+}
+
+func sortCriterion(order SortOrder, fields []string) Criterion {
+	return Criterion{key: "$" + string(order), value: "[" + quoteAll(fields) + "]"}
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = quote(value)
+	}
+	return strings.Join(quoted, ",")
+}
@@ -0,0 +1,53 @@
+package aql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery(t *testing.T) {
+	query := Items.Find(Criteria{
+		Field("repo").Equal("libs-release-local"),
+		Or(
+			Criteria{Field("name").Match("*.jar")},
+			Criteria{Property("build.name").Equal("my-build"), Property("build.number").NotEqual("1")},
+		),
+		Field("size").Greater(1024),
+	}).Include("name", "repo").Sort(Desc, "created", "name").Offset(10).Transitive(true).Limit(5)
+	assert.Equal(t, `items.find({"repo":"libs-release-local","$or":[{"name":{"$match":"*.jar"}},{"@build.name":"my-build","@build.number":{"$ne":"1"}}],"size":{"$gt":1024}})`+
+		`.include("name","repo").sort({"$desc":["created","name"]}).offset(10).transitive().limit(5)`, query.String())
+
+	// The optional parts are omitted.
+	assert.Equal(t, `builds.find({"name":"my-build"})`, Builds.Find(Criteria{Field("name").Equal("my-build")}).Offset(0).Sort("", nil...).String())
+	assert.Equal(t, `items.find({"$and":[{"repo":"a"},{"name":"b"}]}).sort({"$asc":["name"]})`,
+		Items.Find(Criteria{And(RawObject(`{"repo":"a"}`), Criteria{Field("name").Equal("b")})}).Sort("", "name").String())
+	assert.Equal(t, `archive.entries.find({})`, ArchiveEntries.Find(Criteria{}).String())
+}
+
+func TestValues(t *testing.T) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	tests := []struct {
+		criterion Criterion
+		expected  string
+	}{
+		{Field("name").Equal(`a "quoted" \ name<&>`), `"name":"a \"quoted\" \\ name<&>"`},
+		{Field("created").Less(created), `"created":{"$lt":"2020-01-02T03:04:05.006Z"}`},
+		{Field("modified").Last("3d"), `"modified":{"$last":"3d"}`},
+		{Field("modified").Before("2w"), `"modified":{"$before":"2w"}`},
+		{Field("size").LessOrEqual(int64(10)), `"size":{"$lte":10}`},
+		{Field("size").GreaterOrEqual(1.5), `"size":{"$gte":1.5}`},
+		{Field("downloaded").Equal(true), `"downloaded":true`},
+		{Field("stat.downloads").Equal(nil), `"stat.downloads":{"$eq":null}`},
+		{Field("name").Pattern("a*"), `"name":{"$match":"a*"}`},
+		{Field("name").Pattern("a"), `"name":"a"`},
+		{Field("name").NotPattern("a*"), `"name":{"$nmatch":"a*"}`},
+		{Field("name").NotPattern("a"), `"name":{"$ne":"a"}`},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.criterion.String())
+		})
+	}
+}
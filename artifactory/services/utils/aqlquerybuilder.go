@@ -1,13 +1,13 @@
 package utils
 
 import (
-	"fmt"
 	"golang.org/x/exp/slices"
-	"strconv"
 	"strings"
 
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils/aql"
 	"github.com/madotis/jfrog-client-go/utils"
 	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
 )
 
 // Returns an AQL body string to search file in Artifactory by pattern, according the specified arguments requirements.
@@ -23,28 +23,35 @@ func CreateAqlBodyForSpecWithPattern(params *CommonParams) (string, error) {
 	includeRoot := strings.Count(searchPattern, "/") < 2
 	triplesSize := len(repoPathFileTriples)
 
-	propsQueryPart, err := buildPropsQueryPart(params.Props, params.ExcludeProps)
+	criteria, err := buildPropsQueryPart(params.Props, params.ExcludeProps)
 	if err != nil {
 		return "", err
 	}
-	itemTypeQuery := buildItemTypeQueryPart(params)
-	nePath := buildNePathPart(triplesSize == 0 || includeRoot)
+	criteria = append(criteria, buildItemTypeQueryPart(params)...)
+	criteria = append(criteria, buildNePathPart(triplesSize == 0 || includeRoot)...)
 	excludeQuery, err := buildExcludeQueryPart(params, triplesSize == 0 || params.Recursive, params.Recursive)
 	if err != nil {
 		return "", err
 	}
+	criteria = append(criteria, excludeQuery...)
 	releaseBundle, err := buildReleaseBundleQuery(params)
 	if err != nil {
 		return "", err
 	}
-
-	json := fmt.Sprintf(`{%s"$or":[`, propsQueryPart+itemTypeQuery+nePath+excludeQuery+releaseBundle)
+	itemFilters, err := buildItemFiltersQueryPart(params)
+	if err != nil {
+		return "", err
+	}
+	// Joined with "$and", so that their keys don't repeat each other's keys or the keys of the pattern.
+	if conjunction := append(releaseBundle, itemFilters...); len(conjunction) > 0 {
+		criteria = append(criteria, aql.And(conjunction...))
+	}
 
 	// Get archive search parameters
 	archivePathFilePairs := createArchiveSearchParams(params)
 
-	json += handleRepoPathFileTriples(repoPathFileTriples, archivePathFilePairs, triplesSize) + "]}"
-	return json, nil
+	criteria = append(criteria, aql.Or(handleRepoPathFileTriples(repoPathFileTriples, archivePathFilePairs)...))
+	return criteria.String(), nil
 }
 
 func createArchiveSearchParams(params *CommonParams) []RepoPathFile {
@@ -59,105 +66,82 @@ func createArchiveSearchParams(params *CommonParams) []RepoPathFile {
 }
 
 // Handle building aql query when having PathFilePairs
-func handleRepoPathFileTriples(pathFilePairs []RepoPathFile, archivePathFilePairs []RepoPathFile, pathPairSize int) string {
-	var query string
-	archivePathPairSize := len(archivePathFilePairs)
-
-	for i := 0; i < pathPairSize; i++ {
-		if archivePathPairSize > 0 {
-			query += handleArchiveSearch(pathFilePairs[i], archivePathFilePairs)
+func handleRepoPathFileTriples(pathFilePairs []RepoPathFile, archivePathFilePairs []RepoPathFile) []aql.Object {
+	var query []aql.Object
+	for _, triple := range pathFilePairs {
+		if len(archivePathFilePairs) > 0 {
+			query = append(query, handleArchiveSearch(triple, archivePathFilePairs)...)
 		} else {
-			query += buildInnerQueryPart(pathFilePairs[i])
-		}
-
-		if i+1 < pathPairSize {
-			query += ","
+			query = append(query, buildInnerQueryPart(triple))
 		}
 	}
-
 	return query
 }
 
 // Handle building aql query including archive search
-func handleArchiveSearch(triple RepoPathFile, archivePathFilePairs []RepoPathFile) string {
-	var query string
-	archivePathPairSize := len(archivePathFilePairs)
-	for i := 0; i < archivePathPairSize; i++ {
-		query += buildInnerArchiveQueryPart(triple, archivePathFilePairs[i].path, archivePathFilePairs[i].file)
-
-		if i+1 < archivePathPairSize {
-			query += ","
-		}
+func handleArchiveSearch(triple RepoPathFile, archivePathFilePairs []RepoPathFile) []aql.Object {
+	var query []aql.Object
+	for _, archivePathFilePair := range archivePathFilePairs {
+		query = append(query, buildInnerArchiveQueryPart(triple, archivePathFilePair.path, archivePathFilePair.file))
 	}
 	return query
 }
 
-func createAqlBodyForBuildArtifacts(builds []Build) string {
-	buildArtifactsItem := `{"$and":[{"artifact.module.build.name":"%s","artifact.module.build.number":"%s"}]}`
-	var items []string
-	for _, build := range builds {
-		items = append(items, fmt.Sprintf(buildArtifactsItem, build.BuildName, build.BuildNumber))
-	}
-	return `{"$or":[` + strings.Join(items, ",") + "]}"
+func createAqlBodyForBuildArtifacts(builds []Build) aql.Criteria {
+	return createAqlBodyForBuilds("artifact", builds)
+}
+
+func createAqlBodyForBuildDependencies(builds []Build) aql.Criteria {
+	return createAqlBodyForBuilds("dependency", builds)
 }
 
-func createAqlBodyForBuildDependencies(builds []Build) string {
-	buildDependenciesItem := `{"$and":[{"dependency.module.build.name":"%s","dependency.module.build.number":"%s"}]}`
-	var items []string
+// Creates a query body of the artifacts or dependencies of the builds, according to the entity, "artifact" or "dependency".
+func createAqlBodyForBuilds(entity string, builds []Build) aql.Criteria {
+	var items []aql.Object
 	for _, build := range builds {
-		items = append(items, fmt.Sprintf(buildDependenciesItem, build.BuildName, build.BuildNumber))
+		items = append(items, aql.Criteria{aql.And(aql.Criteria{
+			aql.Field(entity + ".module.build.name").Equal(build.BuildName),
+			aql.Field(entity + ".module.build.number").Equal(build.BuildNumber),
+		})})
 	}
-	return `{"$or":[` + strings.Join(items, ",") + "]}"
+	return aql.Criteria{aql.Or(items...)}
 }
 
-func createAqlQueryForBuild(includeQueryPart string, artifactsQuery bool, builds []Build) string {
-	var queryBody string
+func createAqlQueryForBuild(includeFields []string, artifactsQuery bool, builds []Build) string {
+	var queryBody aql.Criteria
 	if artifactsQuery {
 		queryBody = createAqlBodyForBuildArtifacts(builds)
 	} else {
 		queryBody = createAqlBodyForBuildDependencies(builds)
 	}
-	itemsPart := `items.find(%s)%s`
-	return fmt.Sprintf(itemsPart, queryBody, includeQueryPart)
+	return aql.Items.Find(queryBody).Include(includeFields...).String()
 }
 
 // noinspection GoUnusedExportedFunction
 func CreateAqlQueryForYarn(npmName, npmVersion string) string {
-	itemsPart :=
-		`items.find({` +
-			`"@npm.name":"%s",` +
-			`"$or": [` +
-			// sometimes the npm.version in the repository is written with "v" prefix, so we search both syntaxes
-			`{"@npm.version":"%[2]s"},` +
-			`{"@npm.version":"v%[2]s"}` +
-			`]` +
-			`})%s`
-	return fmt.Sprintf(itemsPart, npmName, npmVersion, buildIncludeQueryPart([]string{"name", "repo", "path", "actual_sha1", "actual_md5", "sha256"}))
+	return aql.Items.Find(aql.Criteria{
+		aql.Property("npm.name").Equal(npmName),
+		// sometimes the npm.version in the repository is written with "v" prefix, so we search both syntaxes
+		aql.Or(
+			aql.Criteria{aql.Property("npm.version").Equal(npmVersion)},
+			aql.Criteria{aql.Property("npm.version").Equal("v" + npmVersion)},
+		),
+	}).Include("name", "repo", "path", "actual_sha1", "actual_md5", "sha256").String()
 }
 
 func CreateAqlQueryForPypi(repo, file string) string {
-	itemsPart :=
-		`items.find({` +
-			`"repo": "%s",` +
-			`"$or": [{` +
-			`"$and":[{` +
-			`"path": {"$match": "*"},` +
-			`"name": {"$match": "%s"}` +
-			`}]` +
-			`}]` +
-			`})%s`
-	return fmt.Sprintf(itemsPart, repo, file, buildIncludeQueryPart([]string{"name", "repo", "path", "actual_md5", "actual_sha1", "sha256"}))
+	return aql.Items.Find(aql.Criteria{
+		aql.Field("repo").Equal(repo),
+		aql.Field("path").Match("*"),
+		aql.Field("name").Match(file),
+	}).Include("name", "repo", "path", "actual_md5", "actual_sha1", "sha256").String()
 }
 
 func CreateAqlQueryForLatestCreated(repo, path string) string {
-	itemsPart :=
-		`items.find({` +
-			`"repo": "%s",` +
-			`"path": {"$match": "%s"}` +
-			`})` +
-			`.sort({%s})` +
-			`.limit(1)`
-	return fmt.Sprintf(itemsPart, repo, path, buildSortQueryPart([]string{"created"}, "desc"))
+	return aql.Items.Find(aql.Criteria{
+		aql.Field("repo").Equal(repo),
+		aql.Field("path").Match(path),
+	}).Sort(aql.Desc, "created").Limit(1).String()
 }
 
 func prepareSearchPattern(pattern string, repositoryExists bool) string {
@@ -168,97 +152,87 @@ func prepareSearchPattern(pattern string, repositoryExists bool) string {
 	return pattern
 }
 
-func buildPropsQueryPart(props, excludeProps string) (string, error) {
-	propsQuery := ""
+func buildPropsQueryPart(props, excludeProps string) (aql.Criteria, error) {
+	var propsQuery aql.Criteria
 	properties, err := ParseProperties(props)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	for key, values := range properties.ToMap() {
-		propsQuery += buildKeyAllValQueryPart(key, values) + `,`
+		propsQuery = append(propsQuery, buildKeyAllValQueryPart(key, values))
 	}
 
-	excludePropsQuery := ""
 	excludeProperties, err := ParseProperties(excludeProps)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	excludePropsLen := excludeProperties.KeysLen()
-	if excludePropsLen > 0 {
-		excludePropsQuery = `"$or":[`
+	if excludeProperties.KeysLen() > 0 {
+		var excludePropsQuery []aql.Object
 		for key, values := range excludeProperties.ToMap() {
 			for _, value := range values {
-				excludePropsQuery += `{` + buildExcludedKeyValQueryPart(key, value) + `},`
+				excludePropsQuery = append(excludePropsQuery, aql.Criteria{aql.Property(key).NotPattern(value)})
 			}
 		}
-		excludePropsQuery = strings.TrimSuffix(excludePropsQuery, ",") + `],`
+		propsQuery = append(propsQuery, aql.Or(excludePropsQuery...))
 	}
-	return propsQuery + excludePropsQuery, nil
+	return propsQuery, nil
 }
 
-func buildKeyValQueryPart(key string, propValues []string) string {
-	var items []string
-	for _, value := range propValues {
-		items = append(items, fmt.Sprintf(`{"@%s":%s}`, key, getAqlValue(value)))
-	}
-	return `"$or":[` + strings.Join(items, ",") + "]"
+func buildKeyValQueryPart(key string, propValues []string) aql.Criterion {
+	return aql.Or(buildPropValuesQueryParts(key, propValues)...)
 }
 
-func buildKeyAllValQueryPart(key string, propValues []string) string {
-	var items []string
-	for _, value := range propValues {
-		items = append(items, fmt.Sprintf(`{"@%s":%s}`, key, getAqlValue(value)))
-	}
-	return `"$and":[` + strings.Join(items, ",") + "]"
+func buildKeyAllValQueryPart(key string, propValues []string) aql.Criterion {
+	return aql.And(buildPropValuesQueryParts(key, propValues)...)
 }
 
-func buildExcludedKeyValQueryPart(key string, value string) string {
-	return fmt.Sprintf(`"@%s":{"$ne":%s}`, key, getAqlValue(value))
+func buildPropValuesQueryParts(key string, propValues []string) []aql.Object {
+	var items []aql.Object
+	for _, value := range propValues {
+		items = append(items, aql.Criteria{aql.Property(key).Pattern(value)})
+	}
+	return items
 }
 
-func buildItemTypeQueryPart(params *CommonParams) string {
+func buildItemTypeQueryPart(params *CommonParams) aql.Criteria {
 	if params.IncludeDirs {
-		return `"type":"any",`
+		return aql.Criteria{aql.Field("type").Equal("any")}
 	}
-	return ""
+	return nil
 }
 
-func buildNePathPart(includeRoot bool) string {
+func buildNePathPart(includeRoot bool) aql.Criteria {
 	if !includeRoot {
-		return `"path":{"$ne":"."},`
+		return aql.Criteria{aql.Field("path").NotEqual(".")}
 	}
-	return ""
+	return nil
 }
 
-func buildInnerQueryPart(triple RepoPathFile) string {
-	innerQueryPattern := `{"$and":` +
-		`[{` +
-		`"repo":%s,` +
-		`"path":%s,` +
-		`"name":%s` +
-		`}]}`
-	return fmt.Sprintf(innerQueryPattern, getAqlValue(triple.repo), getAqlValue(triple.path), getAqlValue(triple.file))
+func buildInnerQueryPart(triple RepoPathFile) aql.Object {
+	return aql.Criteria{aql.And(aql.Criteria{
+		aql.Field("repo").Pattern(triple.repo),
+		aql.Field("path").Pattern(triple.path),
+		aql.Field("name").Pattern(triple.file),
+	})}
 }
 
-func buildInnerArchiveQueryPart(triple RepoPathFile, archivePath, archiveName string) string {
-	innerQueryPattern := `{"$and":` +
-		`[{` +
-		`"repo":%s,` +
-		`"path":%s,` +
-		`"name":%s,` +
-		`"archive.entry.path":%s,` +
-		`"archive.entry.name":%s` +
-		`}]}`
-	return fmt.Sprintf(innerQueryPattern, getAqlValue(triple.repo), getAqlValue(triple.path), getAqlValue(triple.file), getAqlValue(archivePath), getAqlValue(archiveName))
+func buildInnerArchiveQueryPart(triple RepoPathFile, archivePath, archiveName string) aql.Object {
+	return aql.Criteria{aql.And(aql.Criteria{
+		aql.Field("repo").Pattern(triple.repo),
+		aql.Field("path").Pattern(triple.path),
+		aql.Field("name").Pattern(triple.file),
+		aql.Field("archive.entry.path").Pattern(archivePath),
+		aql.Field("archive.entry.name").Pattern(archiveName),
+	})}
 }
 
-func buildExcludeQueryPart(params *CommonParams, useLocalPath, recursive bool) (string, error) {
-	excludeQuery := ""
+func buildExcludeQueryPart(params *CommonParams, useLocalPath, recursive bool) (aql.Criteria, error) {
+	var excludeQuery aql.Criteria
 	var excludeTriples []RepoPathFile
 	for _, exclusion := range params.GetExclusions() {
 		repoPathFileTriples, _, err := createRepoPathFileTriples(prepareSearchPattern(exclusion, true), recursive)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		excludeTriples = append(excludeTriples, repoPathFileTriples...)
 	}
@@ -268,28 +242,26 @@ func buildExcludeQueryPart(params *CommonParams, useLocalPath, recursive bool) (
 		if !useLocalPath && excludePath == "." {
 			excludePath = "*"
 		}
-		excludeRepoStr := ""
-
+		var exclusion aql.Criteria
 		// repo="*" may cause an error to be returned from Artifactory in transitive search.
 		if excludeTriple.repo != "" && excludeTriple.repo != "*" {
-			excludeRepoStr = fmt.Sprintf(`"repo":{"$nmatch":"%s"},`, excludeTriple.repo)
+			exclusion = append(exclusion, aql.Field("repo").NotMatch(excludeTriple.repo))
 		}
-		excludeQuery += fmt.Sprintf(`"$or":[{%s"path":{"$nmatch":"%s"},"name":{"$nmatch":"%s"}}],`, excludeRepoStr, excludePath, excludeTriple.file)
+		exclusion = append(exclusion, aql.Field("path").NotMatch(excludePath), aql.Field("name").NotMatch(excludeTriple.file))
+		excludeQuery = append(excludeQuery, aql.Or(exclusion))
 	}
 	return excludeQuery, nil
 }
 
-func buildReleaseBundleQuery(params *CommonParams) (string, error) {
+func buildReleaseBundleQuery(params *CommonParams) ([]aql.Object, error) {
 	bundleName, bundleVersion, err := ParseNameAndVersion(params.Bundle, false)
 	if bundleName == "" || err != nil {
-		return "", err
+		return nil, err
 	}
-	itemsPart := `"$and":` +
-		`[{` +
-		`"release_artifact.release.name":%s,` +
-		`"release_artifact.release.version":%s` +
-		`}],`
-	return fmt.Sprintf(itemsPart, getAqlValue(bundleName), getAqlValue(bundleVersion)), nil
+	return []aql.Object{aql.Criteria{
+		aql.Field("release_artifact.release.name").Pattern(bundleName),
+		aql.Field("release_artifact.release.version").Pattern(bundleVersion),
+	}}, nil
 }
 
 // Creates a list of basic required return fields. The list will include the sortBy field if needed.
@@ -346,85 +318,48 @@ func appendMissingFields(fields []string, defaultFields []string) []string {
 	return defaultFields
 }
 
-func prepareFieldsForQuery(fields []string) []string {
-	// Since a slice is basically a pointer, we don't want to modify the underlying fields array because it might be used again (like in delete service)
-	// We will create new slice with the quoted values and will return it.
-	var queryFields []string
-	for _, val := range fields {
-		queryFields = append(queryFields, `"`+val+`"`)
-	}
-	return queryFields
-}
-
 // Creates an aql query from a spec file.
 func BuildQueryFromSpecFile(specFile *CommonParams, requiredArtifactProps RequiredArtifactProps) string {
-	aqlBody := specFile.Aql.ItemsFind
-	query := fmt.Sprintf(`items.find(%s)%s`, aqlBody, buildIncludeQueryPart(getQueryReturnFields(specFile, requiredArtifactProps)))
-	query = appendSortQueryPart(specFile, query)
-	query = appendOffsetQueryPart(specFile, query)
-	query = appendTransitiveQueryPart(specFile, query)
-	query = appendLimitQueryPart(specFile, query)
-	return query
-}
-
-func appendOffsetQueryPart(specFile *CommonParams, query string) string {
-	if specFile.Offset > 0 {
-		query = fmt.Sprintf(`%s.offset(%s)`, query, strconv.Itoa(specFile.Offset))
-	}
-	return query
-}
-
-func appendLimitQueryPart(specFile *CommonParams, query string) string {
-	if specFile.Limit > 0 {
-		query = fmt.Sprintf(`%s.limit(%s)`, query, strconv.Itoa(specFile.Limit))
-	}
-	return query
-}
-
-func appendSortQueryPart(specFile *CommonParams, query string) string {
-	if len(specFile.SortBy) > 0 {
-		query = fmt.Sprintf(`%s.sort({%s})`, query, buildSortQueryPart(specFile.SortBy, specFile.SortOrder))
-	}
-	return query
+	return aql.Items.Find(aql.RawObject(specFile.Aql.ItemsFind)).
+		Include(getQueryReturnFields(specFile, requiredArtifactProps)...).
+		Sort(aql.SortOrder(specFile.SortOrder), specFile.SortBy...).
+		Offset(specFile.Offset).
+		Transitive(specFile.Transitive).
+		Limit(specFile.Limit).
+		String()
 }
 
-func appendTransitiveQueryPart(specFile *CommonParams, query string) string {
-	if specFile.Transitive {
-		query = fmt.Sprintf(`%s.transitive()`, query)
+func createPropsQuery(aqlBody, propKey string, propValues []string) string {
+	propsQuery := aql.Criteria{aql.And(aql.RawObject(aqlBody), aql.Criteria{buildKeyValQueryPart(propKey, propValues)})}
+	return aql.Items.Find(propsQuery).Include("name", "repo", "path", "actual_sha1", "property").String()
+}
+
+// The maximal number of items which an AQL body created by createItemsQueryBodies matches, to limit its size.
+const itemsQueryBatchSize = 500
+
+// Creates AQL bodies which match exactly the items of the reader, each of up to itemsQueryBatchSize items.
+func createItemsQueryBodies(reader *content.ContentReader) ([]string, error) {
+	var bodies []string
+	var items []aql.Object
+	for resultItem := new(ResultItem); reader.NextRecord(resultItem) == nil; resultItem = new(ResultItem) {
+		items = append(items, aql.Criteria{
+			aql.Field("repo").Equal(resultItem.Repo),
+			aql.Field("path").Equal(resultItem.Path),
+			aql.Field("name").Equal(resultItem.Name),
+		})
+		if len(items) == itemsQueryBatchSize {
+			bodies = append(bodies, aql.Criteria{aql.Or(items...)}.String())
+			items = nil
+		}
 	}
-	return query
-}
-
-func buildSortQueryPart(sortFields []string, sortOrder string) string {
-	if sortOrder == "" {
-		sortOrder = "asc"
+	if err := reader.GetError(); err != nil {
+		return nil, err
 	}
-	return fmt.Sprintf(`"$%s":[%s]`, sortOrder, strings.Join(prepareFieldsForQuery(sortFields), `,`))
-}
-
-func createPropsQuery(aqlBody, propKey string, propValues []string) string {
-	propKeyValQueryPart := buildKeyValQueryPart(propKey, propValues)
-	propsQuery :=
-		`items.find({` +
-			`"$and":[%s,{%s}]` +
-			`})%s`
-	return fmt.Sprintf(propsQuery, aqlBody, propKeyValQueryPart, buildIncludeQueryPart([]string{"name", "repo", "path", "actual_sha1", "property"}))
-}
-
-func buildIncludeQueryPart(fieldsToInclude []string) string {
-	fieldsToInclude = prepareFieldsForQuery(fieldsToInclude)
-	return fmt.Sprintf(`.include(%s)`, strings.Join(fieldsToInclude, `,`))
-}
-
-// Optimization - If value is a wildcard pattern, return `{"$match":"value"}`. Otherwise, return `"value"`.
-func getAqlValue(val string) string {
-	var aqlValuePattern string
-	if strings.Contains(val, "*") {
-		aqlValuePattern = `{"$match":"%s"}`
-	} else {
-		aqlValuePattern = `"%s"`
+	reader.Reset()
+	if len(items) > 0 {
+		bodies = append(bodies, aql.Criteria{aql.Or(items...)}.String())
 	}
-	return fmt.Sprintf(aqlValuePattern, val)
+	return bodies, nil
 }
 
 func prepareSourceSearchPattern(pattern, target string) string {
@@ -108,30 +108,21 @@ func assertEqualFieldsList(t *testing.T, actual, expected []string) {
 	}
 }
 
-func TestBuildSortBody(t *testing.T) {
-	assertSortBody(t, buildSortQueryPart([]string{"bubu"}, ""), `"$asc":["bubu"]`)
-	assertSortBody(t, buildSortQueryPart([]string{"bubu", "kuku"}, ""), `"$asc":["bubu","kuku"]`)
-}
-
-func assertSortBody(t *testing.T, actual, expected string) {
-	if actual != expected {
-		t.Error("The function buildSortQueryPart expected to return the string:\n'" + expected + "'.\nbut returned:\n'" + actual + "'.")
-	}
-}
-
 func TestCreateAqlQueryForLatestCreated(t *testing.T) {
 	actual := CreateAqlQueryForLatestCreated("repo", "name")
-	expected := `items.find({` +
-		`"repo": "repo",` +
-		`"path": {"$match": "name"}` +
-		`})` +
-		`.sort({"$desc":["created"]})` +
-		`.limit(1)`
+	expected := `items.find({"repo":"repo","path":{"$match":"name"}}).sort({"$desc":["created"]}).limit(1)`
 	if actual != expected {
 		t.Error("The function CreateAqlQueryForLatestCreated expected to return the string:\n'" + expected + "'.\nbut returned:\n'" + actual + "'.")
 	}
 }
 
+func TestCreateAqlQueryForYarnAndPypi(t *testing.T) {
+	assert.Equal(t, `items.find({"@npm.name":"@scope/\"pkg\"","$or":[{"@npm.version":"1.0.0"},{"@npm.version":"v1.0.0"}]})`+
+		`.include("name","repo","path","actual_sha1","actual_md5","sha256")`, CreateAqlQueryForYarn(`@scope/"pkg"`, "1.0.0"))
+	assert.Equal(t, `items.find({"repo":"pypi-local","path":{"$match":"*"},"name":{"$match":"pkg-1.0\\.tar.gz"}})`+
+		`.include("name","repo","path","actual_md5","actual_sha1","sha256")`, CreateAqlQueryForPypi("pypi-local", `pkg-1.0\.tar.gz`))
+}
+
 func TestPrepareSourceSearchPattern(t *testing.T) {
 	newPattern := prepareSourceSearchPattern("/testdata/b/b1/b.in", "/testdata")
 	assert.Equal(t, "/testdata/b/b1/b.in", newPattern)
@@ -195,7 +186,7 @@ func TestCreateAqlQueryForBuild(t *testing.T) {
 	for _, sample := range aqlQueryForBuildDataProvider {
 		t.Run(fmt.Sprintf("%v, artifacts: %v", sample.builds, sample.artifactsQuery), func(t *testing.T) {
 			expected := `items.find({"$or":[` + sample.expected + "]})"
-			actual := createAqlQueryForBuild("", sample.artifactsQuery, sample.builds)
+			actual := createAqlQueryForBuild(nil, sample.artifactsQuery, sample.builds)
 			assert.Equal(t, expected, actual)
 		})
 	}
@@ -215,7 +206,7 @@ func TestBuildKeyValQueryPart(t *testing.T) {
 	for _, sample := range ketValuePartsProvider {
 		t.Run(sample.expected, func(t *testing.T) {
 			expected := `"$or":[` + sample.expected + "]"
-			actual := buildKeyValQueryPart(sample.key, sample.values)
+			actual := buildKeyValQueryPart(sample.key, sample.values).String()
 			assert.Equal(t, expected, actual)
 		})
 	}
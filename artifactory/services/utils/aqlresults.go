@@ -0,0 +1,95 @@
+package utils
+
+// The results of the AQL domains other than items, which are read as ResultItem.
+// The fields of a domain's results are prefixed with the domain's name, like "build.name".
+// Related domains which are included in the query are returned as nested arrays, like the modules of a build.
+// Read the results with the ContentReader returned by ExecAqlSaveToFile, for example:
+//
+//	for build := new(BuildResult); reader.NextRecord(build) == nil; build = new(BuildResult) {
+//		...
+//	}
+
+// A result of builds.find.
+type BuildResult struct {
+	Name       string `json:"build.name,omitempty"`
+	Number     string `json:"build.number,omitempty"`
+	Url        string `json:"build.url,omitempty"`
+	Repo       string `json:"build.repo,omitempty"`
+	Started    string `json:"build.started,omitempty"`
+	Created    string `json:"build.created,omitempty"`
+	CreatedBy  string `json:"build.created_by,omitempty"`
+	Modified   string `json:"build.modified,omitempty"`
+	ModifiedBy string `json:"build.modified_by,omitempty"`
+	// Returned when "module" is included.
+	Modules []BuildModuleResult `json:"modules,omitempty"`
+	// Returned when "property" is included.
+	Properties []BuildPropertyResult `json:"properties,omitempty"`
+}
+
+type BuildPropertyResult struct {
+	Key   string `json:"build.property.key,omitempty"`
+	Value string `json:"build.property.value,omitempty"`
+}
+
+// A result of modules.find, or a module of a build.
+type BuildModuleResult struct {
+	Name         string                  `json:"module.name,omitempty"`
+	Artifacts    []BuildArtifactResult   `json:"artifacts,omitempty"`
+	Dependencies []BuildDependencyResult `json:"dependencies,omitempty"`
+	// Returned when the module's build is included in a modules.find query.
+	Builds []BuildResult `json:"builds,omitempty"`
+}
+
+// A result of artifacts.find, or an artifact of a build's module.
+type BuildArtifactResult struct {
+	Name string `json:"artifact.name,omitempty"`
+	Type string `json:"artifact.type,omitempty"`
+	Sha1 string `json:"artifact.sha1,omitempty"`
+	Md5  string `json:"artifact.md5,omitempty"`
+	// The items in Artifactory of the artifact, returned when "item" is included.
+	Items []ResultItem `json:"items,omitempty"`
+}
+
+// A result of dependencies.find, or a dependency of a build's module.
+type BuildDependencyResult struct {
+	Name  string `json:"dependency.name,omitempty"`
+	Scope string `json:"dependency.scope,omitempty"`
+	Type  string `json:"dependency.type,omitempty"`
+	Sha1  string `json:"dependency.sha1,omitempty"`
+	Md5   string `json:"dependency.md5,omitempty"`
+	// The items in Artifactory of the dependency, returned when "item" is included.
+	Items []ResultItem `json:"items,omitempty"`
+}
+
+// The entries of an archive item, returned when "archive.entry" is included in items.find,
+// or the archive items of an entry, returned when "archive.item" is included in entries.find.
+type ArchiveResult struct {
+	Entries []ArchiveEntryResult `json:"entries,omitempty"`
+	Items   []ResultItem         `json:"items,omitempty"`
+}
+
+// A result of entries.find or archive.entries.find, or an entry of an archive item.
+type ArchiveEntryResult struct {
+	Name     string          `json:"entry.name,omitempty"`
+	Path     string          `json:"entry.path,omitempty"`
+	Archives []ArchiveResult `json:"archives,omitempty"`
+}
+
+// A result of releases.find.
+type ReleaseResult struct {
+	Name      string `json:"release.name,omitempty"`
+	Version   string `json:"release.version,omitempty"`
+	Status    string `json:"release.status,omitempty"`
+	Created   string `json:"release.created,omitempty"`
+	Signature string `json:"release.signature,omitempty"`
+	Type      string `json:"release.type,omitempty"`
+	// Returned when "release_artifact" is included.
+	Artifacts []ReleaseArtifactResult `json:"release_artifacts,omitempty"`
+}
+
+// A result of release_artifacts.find, or an artifact of a release.
+type ReleaseArtifactResult struct {
+	Path string `json:"release_artifact.path,omitempty"`
+	// The items in Artifactory of the artifact, returned when "item" is included.
+	Items []ResultItem `json:"items,omitempty"`
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBuildResults(t *testing.T) {
+	reader := createTestAqlResultsReader(t, `{"results":[{
+		"build.name":"my-build","build.number":"1","build.created":"2023-01-01T00:00:00.000Z",
+		"modules":[{"module.name":"my-module","artifacts":[{"artifact.name":"a.jar","artifact.sha1":"sha1",
+			"items":[{"repo":"libs","path":"a","name":"a.jar","sha256":"sha256"}]}]}]
+	}],"range":{"start_pos":0,"end_pos":1,"total":1}}`)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	var builds []BuildResult
+	for build := new(BuildResult); reader.NextRecord(build) == nil; build = new(BuildResult) {
+		builds = append(builds, *build)
+	}
+	assert.NoError(t, reader.GetError())
+	if assert.Len(t, builds, 1) {
+		assert.Equal(t, "my-build", builds[0].Name)
+		assert.Equal(t, "1", builds[0].Number)
+		if assert.Len(t, builds[0].Modules, 1) && assert.Len(t, builds[0].Modules[0].Artifacts, 1) {
+			artifact := builds[0].Modules[0].Artifacts[0]
+			assert.Equal(t, "a.jar", artifact.Name)
+			assert.Equal(t, []ResultItem{{Repo: "libs", Path: "a", Name: "a.jar", Sha256: "sha256"}}, artifact.Items)
+		}
+	}
+}
+
+func TestReadArchiveAndReleaseResults(t *testing.T) {
+	reader := createTestAqlResultsReader(t, `{"results":[{"repo":"libs","path":".","name":"a.zip",
+		"archives":[{"entries":[{"entry.name":"b.txt","entry.path":"dir"}]}],
+		"stats":[{"downloads":3,"remote_downloads":1,"remote_origin":"origin"}]}]}`)
+	item := new(ResultItem)
+	assert.NoError(t, reader.NextRecord(item))
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, []ArchiveResult{{Entries: []ArchiveEntryResult{{Name: "b.txt", Path: "dir"}}}}, item.Archives)
+	if assert.Len(t, item.Stats, 1) {
+		assert.Equal(t, "3", item.Stats[0].Downloads.String())
+		assert.Equal(t, "origin", item.Stats[0].RemoteOrigin)
+	}
+
+	reader = createTestAqlResultsReader(t, `{"results":[{"release.name":"bundle","release.version":"1.0","release.status":"signed",
+		"release_artifacts":[{"release_artifact.path":"libs/a.zip"}]}]}`)
+	release := new(ReleaseResult)
+	assert.NoError(t, reader.NextRecord(release))
+	assert.NoError(t, reader.Close())
+	assert.Equal(t, ReleaseResult{Name: "bundle", Version: "1.0", Status: "signed", Artifacts: []ReleaseArtifactResult{{Path: "libs/a.zip"}}}, *release)
+}
+
+func createTestAqlResultsReader(t *testing.T, results string) *content.ContentReader {
+	resultsPath := filepath.Join(t.TempDir(), "results.json")
+	assert.NoError(t, os.WriteFile(resultsPath, []byte(results), 0600))
+	return content.NewContentReader(resultsPath, content.DefaultKey)
+}
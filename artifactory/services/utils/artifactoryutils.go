@@ -407,7 +407,7 @@ func updateProps(readerWithProps *content.ContentReader, resultWriter *content.C
 // Run AQL to retrieve artifacts or dependencies which are associated with a specific build.
 // Return a map of the items' SHA1.
 func fetchBuildArtifactsOrDependenciesSha1(flags CommonConf, artifacts bool, builds []Build) (map[string]int, error) {
-	buildQuery := createAqlQueryForBuild(buildIncludeQueryPart([]string{"name", "repo", "path", "actual_sha1"}), artifacts, builds)
+	buildQuery := createAqlQueryForBuild([]string{"name", "repo", "path", "actual_sha1"}, artifacts, builds)
 	reader, err := aqlSearch(buildQuery, flags)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils/aql"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	createdField        = "created"
+	modifiedField       = "modified"
+	downloadedField     = "stat.downloaded"
+	downloadsCountField = "stat.downloads"
+	sizeField           = "size"
+)
+
+// A period before the search, in the relative time units of AQL.
+var periodRegexp = regexp.MustCompile(`^\d+(y|mo|w|d|h|mi|s)$`)
+
+// The date layouts of the date filters, besides periods.
+var dateFilterLayouts = []string{time.RFC3339, time.DateOnly}
+
+// Returns the date and size filters of the spec. Each filter is a separate object, since filters of
+// the same field repeat its key, so they are expected to be joined with aql.And.
+func buildItemFiltersQueryPart(params *CommonParams) ([]aql.Object, error) {
+	var filters []aql.Object
+	dateFilters := []struct {
+		field        aql.Field
+		after        string
+		before       string
+		matchMissing bool
+	}{
+		{createdField, params.CreatedAfter, params.CreatedBefore, false},
+		{modifiedField, params.ModifiedAfter, params.ModifiedBefore, false},
+		{downloadedField, params.DownloadedAfter, params.DownloadedBefore, true},
+	}
+	for _, filter := range dateFilters {
+		if filter.after != "" {
+			criterion, err := buildDateFilter(filter.field, filter.after, true)
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, aql.Criteria{criterion})
+		}
+		if filter.before != "" {
+			criterion, err := buildDateFilter(filter.field, filter.before, false)
+			if err != nil {
+				return nil, err
+			}
+			if filter.matchMissing {
+				// Items which were never downloaded have no download statistics.
+				criterion = aql.Or(aql.Criteria{criterion}, aql.Criteria{aql.Field(downloadsCountField).Equal(nil)})
+			}
+			filters = append(filters, aql.Criteria{criterion})
+		}
+	}
+
+	if params.MinSize < 0 || params.MaxSize < 0 || (params.MaxSize > 0 && params.MinSize > params.MaxSize) {
+		return nil, errorutils.CheckErrorf("invalid size range: %d-%d", params.MinSize, params.MaxSize)
+	}
+	if params.MinSize > 0 {
+		filters = append(filters, aql.Criteria{aql.Field(sizeField).GreaterOrEqual(params.MinSize)})
+	}
+	if params.MaxSize > 0 {
+		filters = append(filters, aql.Criteria{aql.Field(sizeField).LessOrEqual(params.MaxSize)})
+	}
+	return filters, nil
+}
+
+// Returns a criterion of the dates after or before the filter, which is either a date or a period.
+func buildDateFilter(field aql.Field, filter string, after bool) (aql.Criterion, error) {
+	if periodRegexp.MatchString(filter) {
+		if after {
+			return field.Last(filter), nil
+		}
+		return field.Before(filter), nil
+	}
+	for _, layout := range dateFilterLayouts {
+		date, err := time.Parse(layout, filter)
+		if err != nil {
+			continue
+		}
+		if after {
+			return field.Greater(date), nil
+		}
+		return field.Less(date), nil
+	}
+	return aql.Criterion{}, errorutils.CheckErrorf("invalid date filter of '%s': %s. Expecting a date, like '2023-05-30' or '2023-05-30T10:36:27Z', or a period, like '7d'", field, filter)
+}
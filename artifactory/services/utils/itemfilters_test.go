@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildItemFiltersQueryPart(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   CommonParams
+		expected string
+	}{
+		{"none", CommonParams{}, `[]`},
+		{"periods", CommonParams{CreatedAfter: "7d", ModifiedBefore: "2mo"},
+			`[{"created":{"$last":"7d"}},{"modified":{"$before":"2mo"}}]`},
+		{"dates", CommonParams{ModifiedAfter: "2023-05-30", CreatedBefore: "2023-05-30T10:36:27+02:00"},
+			`[{"created":{"$lt":"2023-05-30T10:36:27.000+02:00"}},{"modified":{"$gt":"2023-05-30T00:00:00.000Z"}}]`},
+		{"downloads", CommonParams{DownloadedAfter: "1y", DownloadedBefore: "30d"},
+			`[{"stat.downloaded":{"$last":"1y"}},{"$or":[{"stat.downloaded":{"$before":"30d"}},{"stat.downloads":{"$eq":null}}]}]`},
+		{"sizes", CommonParams{MinSize: 1024, MaxSize: 2048}, `[{"size":{"$gte":1024}},{"size":{"$lte":2048}}]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filters, err := buildItemFiltersQueryPart(&test.params)
+			assert.NoError(t, err)
+			var actual []string
+			for _, filter := range filters {
+				actual = append(actual, filter.String())
+			}
+			assert.Equal(t, test.expected, "["+strings.Join(actual, ",")+"]")
+			assert.Equal(t, test.name != "none", test.params.HasItemFilters())
+		})
+	}
+}
+
+func TestBuildItemFiltersQueryPartErrors(t *testing.T) {
+	for _, params := range []CommonParams{
+		{ModifiedAfter: "yesterday"},
+		{CreatedBefore: "7days"},
+		{MinSize: 10, MaxSize: 5},
+		{MinSize: -1},
+	} {
+		_, err := buildItemFiltersQueryPart(&params)
+		assert.Error(t, err, params)
+	}
+}
+
+func TestCreateAqlBodyWithItemFilters(t *testing.T) {
+	params := CommonParams{Pattern: "repo-local", Recursive: true, ModifiedBefore: "30d", MaxSize: 100}
+	aqlResult, err := CreateAqlBodyForSpecWithPattern(&params)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$and":[{"modified":{"$before":"30d"}},{"size":{"$lte":100}}],"$or":[{"$and":[{"repo":"repo-local","path":{"$match":"*"},"name":{"$match":"*"}}]}]}`, aqlResult)
+
+	// Neither the "$or" of the downloads filter nor the keys of the size range repeat keys of the query.
+	params = CommonParams{Pattern: "repo-local", Recursive: true, DownloadedBefore: "30d", MinSize: 10, MaxSize: 100, Bundle: "bundle/1"}
+	aqlResult, err = CreateAqlBodyForSpecWithPattern(&params)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$and":[{"release_artifact.release.name":"bundle","release_artifact.release.version":"1"},`+
+		`{"$or":[{"stat.downloaded":{"$before":"30d"}},{"stat.downloads":{"$eq":null}}]},{"size":{"$gte":10}},{"size":{"$lte":100}}],`+
+		`"$or":[{"$and":[{"repo":"repo-local","path":{"$match":"*"},"name":{"$match":"*"}}]}]}`, aqlResult)
+}
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+const (
+	versionGroupName  = "version"
+	artifactGroupName = "group"
+)
+
+// Extracts the versions and groups of items, according to the version fields of a spec.
+type versionSelector struct {
+	pattern       *regexp.Regexp
+	versionIndex  int
+	groupIndex    int
+	property      string
+	scheme        VersionScheme
+	versionsCount int
+}
+
+func newVersionSelector(specFile *CommonParams) (*versionSelector, error) {
+	pattern, err := regexp.Compile(specFile.VersionPattern)
+	if err != nil {
+		return nil, errorutils.CheckErrorf("invalid version pattern: %s", err.Error())
+	}
+	selector := &versionSelector{
+		pattern:       pattern,
+		versionIndex:  pattern.SubexpIndex(versionGroupName),
+		groupIndex:    pattern.SubexpIndex(artifactGroupName),
+		property:      specFile.VersionProperty,
+		scheme:        specFile.VersionScheme,
+		versionsCount: specFile.LatestVersions,
+	}
+	if selector.versionIndex < 0 {
+		if pattern.NumSubexp() == 0 {
+			return nil, errorutils.CheckErrorf("the version pattern must have a group of the version: %s", specFile.VersionPattern)
+		}
+		selector.versionIndex = 1
+	}
+	if selector.versionsCount <= 0 {
+		selector.versionsCount = 1
+	}
+	return selector, validateVersionScheme(selector.scheme)
+}
+
+// Returns the group and version of the item, or false if the item has no version.
+// The version is matched in the item's path, or in the values of the version property, and the first match is used.
+func (vs *versionSelector) match(item *ResultItem) (group string, version parsedVersion, ok bool) {
+	if item.Type == "folder" {
+		return "", nil, false
+	}
+	values := []string{item.GetItemRelativePath()}
+	if vs.property != "" {
+		values = nil
+		for _, property := range item.Properties {
+			if property.Key == vs.property {
+				values = append(values, property.Value)
+			}
+		}
+	}
+	for _, value := range values {
+		indexes := vs.pattern.FindStringSubmatchIndex(value)
+		if indexes == nil || indexes[2*vs.versionIndex] < 0 {
+			continue
+		}
+		versionStart, versionEnd := indexes[2*vs.versionIndex], indexes[2*vs.versionIndex+1]
+		parsed, err := parseVersion(vs.scheme, value[versionStart:versionEnd])
+		if err != nil {
+			log.Debug(fmt.Sprintf("Skipping '%s': %s", item.GetItemRelativePath(), err.Error()))
+			continue
+		}
+		if vs.groupIndex >= 0 && indexes[2*vs.groupIndex] >= 0 {
+			group = value[indexes[2*vs.groupIndex]:indexes[2*vs.groupIndex+1]]
+		} else {
+			group = value[:versionStart]
+		}
+		return group, parsed, true
+	}
+	return "", nil, false
+}
+
+// Returns the index of the version in the latest versions, or -1 if it isn't one of them.
+func findVersion(latestVersions []parsedVersion, version parsedVersion) int {
+	for i, latestVersion := range latestVersions {
+		if version.compare(latestVersion) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Adds the version to the latest versions of a group, which are sorted from the latest, if it's one of the latest versionsCount versions.
+func addLatestVersion(latestVersions []parsedVersion, version parsedVersion, versionsCount int) []parsedVersion {
+	if findVersion(latestVersions, version) >= 0 {
+		return latestVersions
+	}
+	index := len(latestVersions)
+	for index > 0 && version.compare(latestVersions[index-1]) > 0 {
+		index--
+	}
+	if index >= versionsCount {
+		return latestVersions
+	}
+	latestVersions = append(latestVersions[:index], append([]parsedVersion{version}, latestVersions[index:]...)...)
+	if len(latestVersions) > versionsCount {
+		latestVersions = latestVersions[:versionsCount]
+	}
+	return latestVersions
+}
+
+// Returns a reader of the items of the latest versions of each group, sorted by the groups and from the latest version.
+// Only the latest versions of each group are kept in the memory, while the items are read twice:
+// once to find the latest versions, and once to write their items.
+func selectLatestVersions(specFile *CommonParams, reader *content.ContentReader) (sortedReader *content.ContentReader, err error) {
+	selector, err := newVersionSelector(specFile)
+	if err != nil {
+		return nil, err
+	}
+	latestVersions := map[string][]parsedVersion{}
+	for item := new(ResultItem); reader.NextRecord(item) == nil; item = new(ResultItem) {
+		if group, version, ok := selector.match(item); ok {
+			latestVersions[group] = addLatestVersion(latestVersions[group], version, selector.versionsCount)
+		}
+	}
+	if err = reader.GetError(); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	for item := new(ResultItem); reader.NextRecord(item) == nil; item = new(ResultItem) {
+		if group, version, ok := selector.match(item); ok && findVersion(latestVersions[group], version) >= 0 {
+			writer.Write(*item)
+		}
+	}
+	if err = errors.Join(reader.GetError(), writer.Close()); err != nil {
+		return nil, err
+	}
+	reader.Reset()
+	if writer.IsEmpty() {
+		return content.NewEmptyContentReader(content.DefaultKey), nil
+	}
+	selected := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		err = errors.Join(err, selected.Close())
+	}()
+
+	// Each item's key is made unique by its index, because items with the same sort key are removed.
+	index := 0
+	return content.SortContentReaderByCalculatedKey(selected, func(record interface{}) (string, error) {
+		item := new(ResultItem)
+		if err := content.ConvertToStruct(record, item); err != nil {
+			return "", err
+		}
+		group, version, _ := selector.match(item)
+		index++
+		return fmt.Sprintf("%s\x00%06d\x00%020d", group, findVersion(latestVersions[group], version), index), nil
+	}, true)
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+var latestVersionsTestItems = []ResultItem{
+	{Repo: "libs", Path: "org/app/1.9.0", Name: "app-1.9.0.jar", Type: "file"},
+	{Repo: "libs", Path: "org/app/1.10.0", Name: "app-1.10.0.jar", Type: "file"},
+	{Repo: "libs", Path: "org/app/1.10.0", Name: "app-1.10.0.pom", Type: "file"},
+	{Repo: "libs", Path: "org/app/1.11.0-rc.1", Name: "app-1.11.0-rc.1.jar", Type: "file"},
+	{Repo: "libs", Path: "org/app/1.2.0", Name: "app-1.2.0.jar", Type: "file"},
+	{Repo: "libs", Path: "org/app", Name: "1.10.0", Type: "folder"},
+	{Repo: "libs", Path: "org/lib/2.0.0", Name: "lib-2.0.0.jar", Type: "file",
+		Properties: []Property{{Key: "release", Value: "2.0.0"}}},
+	{Repo: "libs", Path: "org/lib/latest", Name: "lib.jar", Type: "file"},
+}
+
+func TestSelectLatestVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     CommonParams
+		expected []string
+	}{
+		{"latest", CommonParams{VersionPattern: `/([^/]+)/[^/]+$`},
+			[]string{"app-1.11.0-rc.1.jar", "lib-2.0.0.jar"}},
+		{"named groups", CommonParams{VersionPattern: `^.*/(?P<group>[^/]+)/(?P<version>\d+\.\d+\.\d+)/[^/]+$`, LatestVersions: 3},
+			[]string{"app-1.10.0.jar", "app-1.10.0.pom", "app-1.9.0.jar", "app-1.2.0.jar", "lib-2.0.0.jar"}},
+		{"releases", CommonParams{VersionPattern: `/(\d+\.\d+\.\d+)/`, LatestVersions: 2},
+			[]string{"app-1.10.0.jar", "app-1.10.0.pom", "app-1.9.0.jar", "lib-2.0.0.jar"}},
+		{"property", CommonParams{VersionPattern: `(.+)`, VersionProperty: "release"},
+			[]string{"lib-2.0.0.jar"}},
+		{"maven", CommonParams{VersionPattern: `/app/([^/]+)/`, VersionScheme: MavenScheme, LatestVersions: 2},
+			[]string{"app-1.11.0-rc.1.jar", "app-1.10.0.jar", "app-1.10.0.pom"}},
+		{"no versions", CommonParams{VersionPattern: `/(\d+)$`}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader := createLatestVersionsTestReader(t)
+			defer func() {
+				assert.NoError(t, reader.Close())
+			}()
+			selected, err := selectLatestVersions(&test.spec, reader)
+			assert.NoError(t, err)
+			var names []string
+			for item := new(ResultItem); selected.NextRecord(item) == nil; item = new(ResultItem) {
+				names = append(names, item.Name)
+			}
+			assert.NoError(t, selected.GetError())
+			assert.NoError(t, selected.Close())
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestSelectLatestVersionsErrors(t *testing.T) {
+	reader := createLatestVersionsTestReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	for _, spec := range []CommonParams{
+		{VersionPattern: `[`},
+		{VersionPattern: `/\d+/`},
+		{VersionPattern: `/(\d+)/`, VersionScheme: "calver"},
+	} {
+		_, err := selectLatestVersions(&spec, reader)
+		assert.Error(t, err, spec.VersionPattern)
+	}
+}
+
+func createLatestVersionsTestReader(t *testing.T) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, item := range latestVersionsTestItems {
+		writer.Write(item)
+	}
+	assert.NoError(t, writer.Close())
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
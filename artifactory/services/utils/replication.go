@@ -85,3 +85,19 @@ func CreateReplicationParams(body GetReplicationBody) *ReplicationParams {
 		IncludePathPrefixPattern: body.IncludePathPrefixPattern,
 	}
 }
+
+// The body of the push replications of a local repository to multiple targets.
+// The cron expression and event replication of the targets are set for all of them.
+type MultiPushReplicationBody struct {
+	CronExp                string                  `json:"cronExp"`
+	EnableEventReplication bool                    `json:"enableEventReplication"`
+	Replications           []UpdateReplicationBody `json:"replications"`
+}
+
+func CreateMultiPushReplicationBody(cronExp string, enableEventReplication bool, replications []ReplicationParams) *MultiPushReplicationBody {
+	body := &MultiPushReplicationBody{CronExp: cronExp, EnableEventReplication: enableEventReplication, Replications: []UpdateReplicationBody{}}
+	for _, params := range replications {
+		body.Replications = append(body.Replications, *CreateUpdateReplicationBody(params))
+	}
+	return body
+}
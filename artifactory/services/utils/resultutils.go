@@ -33,8 +33,22 @@ type OperationSummary struct {
 	TransferDetailsReader *content.ContentReader
 	// A ContentReader of ArtifactDetails structs
 	ArtifactsDetailsReader *content.ContentReader
-	TotalSucceeded         int
-	TotalFailed            int
+	// A ContentReader of FailedTransferDetails structs
+	FailedDetailsReader *content.ContentReader
+	TotalSucceeded      int
+	TotalFailed         int
+}
+
+// The details of a file which failed to be transferred.
+// A ContentReader of these structs can be set as the FailedTransfers of the upload or download params, to transfer only these files again.
+type FailedTransferDetails struct {
+	// The local path of an uploaded file, or the path in Artifactory of a downloaded file.
+	SourcePath string `json:"sourcePath,omitempty"`
+	// The path in Artifactory of an uploaded file, or the local path of a downloaded file.
+	TargetPath string `json:"targetPath,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// The number of attempts made to transfer the file's content. Zero if the transfer failed before any content was sent.
+	Attempts int `json:"attempts,omitempty"`
 }
 
 type ArtifactDetails struct {
@@ -48,6 +62,11 @@ func (cs *OperationSummary) Close() error {
 	if err != nil {
 		return err
 	}
+	if cs.FailedDetailsReader != nil {
+		if err = cs.FailedDetailsReader.Close(); err != nil {
+			return err
+		}
+	}
 	return cs.ArtifactsDetailsReader.Close()
 }
 
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/madotis/jfrog-client-go/utils/log"
+)
+
+// The pages of a paged search are sorted by these fields, in addition to the spec's sort fields, so that each item is returned by exactly one page.
+var pageSortFields = []string{"repo", "path", "name"}
+
+// The page size of searches whose specs don't set one.
+const DefaultSearchPageSize = 50000
+
+// Returns a copy of the spec, which is sorted so that its pages are stable. The pages inherit the spec's offset and limit.
+func newPagedSearchSpec(specFile *CommonParams) *CommonParams {
+	pagedSpec := *specFile
+	if pagedSpec.PageSize == 0 {
+		pagedSpec.PageSize = DefaultSearchPageSize
+	}
+	pagedSpec.SortBy = appendMissingFields(pageSortFields, append([]string{}, specFile.SortBy...))
+	return &pagedSpec
+}
+
+// Returns the spec of a page of a paged search, or false if the page is beyond the spec's limit.
+func getPageSpec(pagedSpec *CommonParams, page int) (*CommonParams, bool) {
+	pageSpec := *pagedSpec
+	pageSpec.Offset = pagedSpec.Offset + page*pagedSpec.PageSize
+	pageSpec.Limit = pagedSpec.PageSize
+	if pagedSpec.Limit > 0 {
+		remaining := pagedSpec.Limit - page*pagedSpec.PageSize
+		if remaining <= 0 {
+			return nil, false
+		}
+		if remaining < pageSpec.Limit {
+			pageSpec.Limit = remaining
+		}
+	}
+	return &pageSpec, true
+}
+
+type pageSearchFunc func(pageSpec *CommonParams) (*content.ContentReader, error)
+
+// Searches the pages of the spec until a page which isn't full is returned, and merges them into a single reader, in the order of the pages.
+// Up to specFile.PageThreads pages are searched in parallel.
+func searchPages(pagedSpec *CommonParams, searchPage pageSearchFunc) (reader *content.ContentReader, err error) {
+	threads := pagedSpec.PageThreads
+	if threads < 1 {
+		threads = 1
+	}
+	var pages []*content.ContentReader
+	defer func() {
+		for _, page := range pages {
+			err = errors.Join(err, errorutils.CheckError(page.Close()))
+		}
+	}()
+	for lastPage := false; !lastPage; {
+		batch := make([]*content.ContentReader, threads)
+		batchErrors := make([]error, threads)
+		var wg sync.WaitGroup
+		for i := 0; i < threads; i++ {
+			pageSpec, ok := getPageSpec(pagedSpec, len(pages)+i)
+			if !ok {
+				lastPage = true
+				break
+			}
+			log.Debug("Searching page " + strconv.Itoa(len(pages)+i+1) + " of the AQL results...")
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				batch[i], batchErrors[i] = searchPage(pageSpec)
+			}(i)
+		}
+		wg.Wait()
+		for i, page := range batch {
+			if page == nil {
+				continue
+			}
+			pages = append(pages, page)
+			if batchErrors[i] != nil || lastPage {
+				continue
+			}
+			var length int
+			if length, batchErrors[i] = page.Length(); batchErrors[i] == nil && length < pagedSpec.PageSize {
+				lastPage = true
+			}
+		}
+		if err = errors.Join(batchErrors...); err != nil {
+			return nil, err
+		}
+	}
+	return content.MergeReaders(pages, content.DefaultKey)
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPagedSearchSpec(t *testing.T) {
+	specFile := &CommonParams{SortBy: []string{"created", "name"}, PageSize: 10}
+	pagedSpec := newPagedSearchSpec(specFile)
+	assert.Equal(t, []string{"created", "name", "repo", "path"}, pagedSpec.SortBy)
+	assert.Equal(t, []string{"created", "name"}, specFile.SortBy)
+	assert.Equal(t, 10, pagedSpec.PageSize)
+	pagedSpec = newPagedSearchSpec(&CommonParams{})
+	assert.Equal(t, pageSortFields, pagedSpec.SortBy)
+	assert.Equal(t, DefaultSearchPageSize, pagedSpec.PageSize)
+}
+
+func TestGetPageSpec(t *testing.T) {
+	pagedSpec := &CommonParams{Offset: 5, Limit: 25, PageSize: 10}
+	var pages [][2]int
+	for page := 0; ; page++ {
+		pageSpec, ok := getPageSpec(pagedSpec, page)
+		if !ok {
+			break
+		}
+		pages = append(pages, [2]int{pageSpec.Offset, pageSpec.Limit})
+	}
+	assert.Equal(t, [][2]int{{5, 10}, {15, 10}, {25, 5}}, pages)
+
+	// Without a limit, there is always another page.
+	pageSpec, ok := getPageSpec(&CommonParams{PageSize: 10}, 100)
+	assert.True(t, ok)
+	assert.Equal(t, 1000, pageSpec.Offset)
+}
+
+func TestSearchPages(t *testing.T) {
+	for _, threads := range []int{0, 1, 3} {
+		t.Run(strconv.Itoa(threads), func(t *testing.T) {
+			var mutex sync.Mutex
+			searchedOffsets := map[int]bool{}
+			// 25 items, in pages of 10.
+			reader, err := searchPages(&CommonParams{PageSize: 10, PageThreads: threads}, func(pageSpec *CommonParams) (*content.ContentReader, error) {
+				mutex.Lock()
+				searchedOffsets[pageSpec.Offset] = true
+				mutex.Unlock()
+				end := pageSpec.Offset + pageSpec.Limit
+				if end > 25 {
+					end = 25
+				}
+				return createTestPageReader(t, pageSpec.Offset, end)
+			})
+			assert.NoError(t, err)
+			var names []string
+			for item := new(ResultItem); reader.NextRecord(item) == nil; item = new(ResultItem) {
+				names = append(names, item.Name)
+			}
+			assert.NoError(t, reader.Close())
+			if assert.Len(t, names, 25) {
+				for i, name := range names {
+					assert.Equal(t, strconv.Itoa(i), name)
+				}
+			}
+			assert.True(t, searchedOffsets[20])
+			if threads <= 1 {
+				assert.Len(t, searchedOffsets, 3)
+			}
+		})
+	}
+}
+
+func TestSearchPagesError(t *testing.T) {
+	reader, err := searchPages(&CommonParams{PageSize: 10, PageThreads: 2}, func(pageSpec *CommonParams) (*content.ContentReader, error) {
+		if pageSpec.Offset > 0 {
+			return nil, errors.New("failure")
+		}
+		return createTestPageReader(t, 0, 10)
+	})
+	assert.EqualError(t, err, "failure")
+	assert.Nil(t, reader)
+}
+
+func createTestPageReader(t *testing.T, start, end int) (*content.ContentReader, error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for i := start; i < end; i++ {
+		writer.Write(ResultItem{Repo: "repo", Name: strconv.Itoa(i)})
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	if writer.IsEmpty() {
+		return content.NewEmptyContentReader(content.DefaultKey), nil
+	}
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey), nil
+}
+
+func TestCreateItemsQueryBodies(t *testing.T) {
+	// The properties of a page are fetched for the items of the page only.
+	reader, err := createTestPageReader(t, 10, 12)
+	assert.NoError(t, err)
+	itemsQueryBodies, err := createItemsQueryBodies(reader)
+	assert.NoError(t, err)
+	if assert.Len(t, itemsQueryBodies, 1) {
+		assert.Equal(t, `items.find({"$and":[{"$or":[{"repo":"repo","path":"","name":"10"},{"repo":"repo","path":"","name":"11"}]},{"$or":[{"@*":{"$match":"*"}}]}]})`+
+			`.include("name","repo","path","actual_sha1","property")`, createPropsQuery(itemsQueryBodies[0], "*", []string{"*"}))
+	}
+	// The reader can still be read.
+	length, err := reader.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, length)
+	assert.NoError(t, reader.Close())
+
+	// The items are split into batches, so that the size of each query is bounded.
+	reader, err = createTestPageReader(t, 0, 2*itemsQueryBatchSize+1)
+	assert.NoError(t, err)
+	itemsQueryBodies, err = createItemsQueryBodies(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	if assert.Len(t, itemsQueryBodies, 3) {
+		assert.Equal(t, itemsQueryBatchSize, strings.Count(itemsQueryBodies[0], `"repo":`))
+		assert.Equal(t, itemsQueryBatchSize, strings.Count(itemsQueryBodies[1], `"repo":`))
+		assert.Equal(t, `{"$or":[{"repo":"repo","path":"","name":"1000"}]}`, itemsQueryBodies[2])
+	}
+
+	itemsQueryBodies, err = createItemsQueryBodies(content.NewEmptyContentReader(content.DefaultKey))
+	assert.NoError(t, err)
+	assert.Empty(t, itemsQueryBodies)
+}
@@ -95,13 +95,13 @@ func SearchBySpecWithBuild(specFile *CommonParams, flags CommonConf) (readerCont
 }
 
 func getBuildDependenciesForBuildSearch(specFile CommonParams, flags CommonConf, builds []Build) (*content.ContentReader, error) {
-	specFile.Aql = Aql{ItemsFind: createAqlBodyForBuildDependencies(builds)}
+	specFile.Aql = Aql{ItemsFind: createAqlBodyForBuildDependencies(builds).String()}
 	executionQuery := BuildQueryFromSpecFile(&specFile, ALL)
 	return aqlSearch(executionQuery, flags)
 }
 
 func getBuildArtifactsForBuildSearch(specFile CommonParams, flags CommonConf, builds []Build) (*content.ContentReader, error) {
-	specFile.Aql = Aql{ItemsFind: createAqlBodyForBuildArtifacts(builds)}
+	specFile.Aql = Aql{ItemsFind: createAqlBodyForBuildArtifacts(builds).String()}
 	executionQuery := BuildQueryFromSpecFile(&specFile, ALL)
 	return aqlSearch(executionQuery, flags)
 }
@@ -136,7 +136,7 @@ func filterBuildArtifactsAndDependencies(artifactsReader, dependenciesReader *co
 	for _, build := range builds {
 		buildNames = append(buildNames, build.BuildName)
 	}
-	readerWithProps, err := searchProps(createAqlBodyForBuildArtifacts(builds), "build.name", buildNames, flags)
+	readerWithProps, err := searchProps(createAqlBodyForBuildArtifacts(builds).String(), "build.name", buildNames, flags)
 	if err != nil {
 		return nil, err
 	}
@@ -189,10 +189,35 @@ func SearchBySpecWithPattern(specFile *CommonParams, flags CommonConf, requiredA
 
 // Use this function when running Aql with pattern
 func SearchBySpecWithAql(specFile *CommonParams, flags CommonConf, requiredArtifactProps RequiredArtifactProps) (reader *content.ContentReader, err error) {
+	if specFile.VersionPattern == "" {
+		return searchBySpecWithAql(specFile, flags, requiredArtifactProps)
+	}
+	if specFile.VersionProperty != "" && requiredArtifactProps == NONE {
+		// The versions are read from the properties.
+		requiredArtifactProps = ALL
+	}
+	searchResults, err := searchBySpecWithAql(specFile, flags, requiredArtifactProps)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, searchResults.Close())
+	}()
+	return selectLatestVersions(specFile, searchResults)
+}
+
+func searchBySpecWithAql(specFile *CommonParams, flags CommonConf, requiredArtifactProps RequiredArtifactProps) (reader *content.ContentReader, err error) {
 	// Execute the search according to provided aql in specFile.
 	var fetchedProps *content.ContentReader
-	query := BuildQueryFromSpecFile(specFile, requiredArtifactProps)
-	reader, err = aqlSearch(query, flags)
+	paged := specFile.PageSize >= 0
+	if paged {
+		specFile = newPagedSearchSpec(specFile)
+		reader, err = searchPages(specFile, func(pageSpec *CommonParams) (*content.ContentReader, error) {
+			return searchPageWithProps(pageSpec, flags, requiredArtifactProps)
+		})
+	} else {
+		reader, err = aqlSearch(BuildQueryFromSpecFile(specFile, requiredArtifactProps), flags)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +234,10 @@ func SearchBySpecWithAql(specFile *CommonParams, flags CommonConf, requiredArtif
 		// The new reader assignment will not affect the defer statement.
 		reader = filteredReader
 	}
-	fetchedProps, err = fetchProps(specFile, flags, requiredArtifactProps, reader)
+	if !paged {
+		// The properties of paged searches are fetched page by page.
+		fetchedProps, err = fetchProps(specFile, flags, requiredArtifactProps, reader)
+	}
 	if fetchedProps != nil {
 		// Before returning the new reader, we close the one we used to creat it.
 		defer func(reader *content.ContentReader) {
@@ -262,6 +290,52 @@ func fetchProps(specFile *CommonParams, flags CommonConf, requiredArtifactProps
 	return nil, nil
 }
 
+// Searches a page of a paged search. Since paged searches are sorted, their properties can't be included in the AQL
+// results, and are fetched for the items of the page only, in batches of itemsQueryBatchSize items.
+func searchPageWithProps(pageSpec *CommonParams, flags CommonConf, requiredArtifactProps RequiredArtifactProps) (reader *content.ContentReader, err error) {
+	reader, err = aqlSearch(BuildQueryFromSpecFile(pageSpec, requiredArtifactProps), flags)
+	if err != nil || requiredArtifactProps == NONE || pageSpec.Build != "" {
+		return
+	}
+	itemsQueryBodies, err := createItemsQueryBodies(reader)
+	if err != nil {
+		return nil, errors.Join(err, errorutils.CheckError(reader.Close()))
+	}
+	if len(itemsQueryBodies) == 0 {
+		return
+	}
+	defer func(pageReader *content.ContentReader) {
+		err = errors.Join(err, errorutils.CheckError(pageReader.Close()))
+	}(reader)
+	var batchesWithProps []*content.ContentReader
+	defer func() {
+		for _, batchWithProps := range batchesWithProps {
+			err = errors.Join(err, errorutils.CheckError(batchWithProps.Close()))
+		}
+	}()
+	for _, itemsQueryBody := range itemsQueryBodies {
+		var batchWithProps *content.ContentReader
+		switch requiredArtifactProps {
+		case ALL:
+			batchWithProps, err = searchProps(itemsQueryBody, "*", []string{"*"}, flags)
+		case SYMLINK:
+			batchWithProps, err = searchProps(itemsQueryBody, "symlink.dest", []string{"*"}, flags)
+		}
+		if err != nil {
+			return nil, err
+		}
+		batchesWithProps = append(batchesWithProps, batchWithProps)
+	}
+	readerWithProps, err := content.MergeReaders(batchesWithProps, content.DefaultKey)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		err = errors.Join(err, errorutils.CheckError(readerWithProps.Close()))
+	}()
+	return loadMissingProperties(reader, readerWithProps)
+}
+
 func aqlSearch(aqlQuery string, flags CommonConf) (*content.ContentReader, error) {
 	return ExecAqlSaveToFile(aqlQuery, flags)
 }
@@ -358,13 +432,19 @@ type ResultItem struct {
 	OriginalMd5 string     `json:"original_md5,omitempty"`
 	Properties  []Property `json:"properties,omitempty"`
 	Stats       []Stat     `json:"stats,omitempty"`
+	// Returned when the archive entries are included, for items which are archives.
+	Archives []ArchiveResult `json:"archives,omitempty"`
 }
 
 type Stat struct {
-	Downloaded      string      `json:"downloaded,omitempty"`
-	Downloads       json.Number `json:"downloads,omitempty"`
-	DownloadedBy    string      `json:"downloaded_by,omitempty"`
-	RemoteDownloads json.Number `json:"remote_downloads,omitempty"`
+	Downloaded         string      `json:"downloaded,omitempty"`
+	Downloads          json.Number `json:"downloads,omitempty"`
+	DownloadedBy       string      `json:"downloaded_by,omitempty"`
+	RemoteDownloads    json.Number `json:"remote_downloads,omitempty"`
+	RemoteDownloaded   string      `json:"remote_downloaded,omitempty"`
+	RemoteDownloadedBy string      `json:"remote_downloaded_by,omitempty"`
+	RemoteOrigin       string      `json:"remote_origin,omitempty"`
+	RemotePath         string      `json:"remote_path,omitempty"`
 }
 
 func (item ResultItem) GetSortKey() string {
@@ -42,6 +42,35 @@ type CommonParams struct {
 	ArchiveEntries   string
 	Transitive       bool
 	Include          []string
+	// The search is split into pages of this number of items, DefaultSearchPageSize by default, which are sorted by their
+	// repository, path and name, in addition to SortBy. The items' properties are fetched for each page.
+	// A negative size searches with a single AQL query.
+	PageSize int
+	// The number of pages searched in parallel.
+	PageThreads int
+	// Filter the items of pattern searches by their creation, modification and last download dates.
+	// Each filter is either a date, like "2023-05-30" or "2023-05-30T10:36:27Z", or a period before the search, like "7d".
+	// The period units are y (years), mo (months), w (weeks), d (days), h (hours), mi (minutes) and s (seconds).
+	CreatedAfter   string
+	CreatedBefore  string
+	ModifiedAfter  string
+	ModifiedBefore string
+	// Items which were never downloaded match DownloadedBefore, and don't match DownloadedAfter.
+	DownloadedAfter  string
+	DownloadedBefore string
+	// Filter the items by their size in bytes, inclusively. Zero isn't a limit.
+	MinSize int64
+	MaxSize int64
+	// Selects the files of the latest versions, instead of all the found files. The version of each file is matched by
+	// VersionPattern, a regexp, in the file's "repo/path/name", or in the values of VersionProperty if it is set.
+	// The version is the pattern's group named "version", or its first group. The files are grouped by the pattern's group
+	// named "group", or by the text which precedes the version, and the LatestVersions latest versions (one by default)
+	// of each group are selected. Files without a version are omitted.
+	VersionPattern  string
+	VersionProperty string
+	// The scheme the versions are compared by. Semantic Versioning by default.
+	VersionScheme  VersionScheme
+	LatestVersions int
 }
 
 type FileGetter interface {
@@ -168,6 +197,14 @@ func (params *CommonParams) GetExclusions() []string {
 	return params.Exclusions
 }
 
+// Returns true if the items are filtered by their dates, sizes or versions.
+// These filters select files, whose folders may contain other files, which don't match them.
+func (params *CommonParams) HasItemFilters() bool {
+	return params.CreatedAfter != "" || params.CreatedBefore != "" || params.ModifiedAfter != "" || params.ModifiedBefore != "" ||
+		params.DownloadedAfter != "" || params.DownloadedBefore != "" || params.MinSize > 0 || params.MaxSize > 0 ||
+		params.VersionPattern != ""
+}
+
 func (aql *Aql) UnmarshalJSON(value []byte) error {
 	str := string(value)
 	first := strings.Index(str[strings.Index(str, "{")+1:], "{")
@@ -0,0 +1,315 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+// The scheme by which versions are compared.
+type VersionScheme string
+
+const (
+	// Semantic Versioning, like "1.10.0-rc.1". Versions may have any number of numeric parts, and a leading 'v'.
+	SemVerScheme VersionScheme = "semver"
+	// Maven versions, like "1.10.0-SNAPSHOT" or "2.0-beta-1". Any version can be compared.
+	MavenScheme VersionScheme = "maven"
+	// Python versions (PEP 440), like "1.10.0rc1", "1!2.0" or "1.0.post1.dev2".
+	Pep440Scheme VersionScheme = "pep440"
+)
+
+var (
+	semVerRegexp = regexp.MustCompile(`^[vV]?(\d+(?:\.\d+)*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+	pep440Regexp = regexp.MustCompile(`^(?i)v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+		`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d+)?)?` +
+		`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d+)?)?` +
+		`(?:[-_.]?(dev)[-_.]?(\d+)?)?` +
+		`(?:\+[a-z0-9]+(?:[-_.][a-z0-9]+)*)?$`)
+	mavenTokenRegexp = regexp.MustCompile(`\d+|[a-z]+`)
+)
+
+// The Maven qualifiers by their order. Unknown qualifiers are after all of them, ordered alphabetically.
+var mavenQualifiers = map[string]int{"alpha": 0, "beta": 1, "milestone": 2, "rc": 3, "snapshot": 4, "": 5, "sp": 6}
+
+var mavenQualifierAliases = map[string]string{"a": "alpha", "b": "beta", "m": "milestone", "cr": "rc", "ga": "", "final": "", "release": ""}
+
+// The PEP 440 pre-release phases by their order.
+var pep440Phases = map[string]int{"a": 0, "alpha": 0, "b": 1, "beta": 1, "c": 2, "rc": 2, "pre": 2, "preview": 2}
+
+// A version, which is parsed once to be compared to many other versions of the same scheme.
+type parsedVersion interface {
+	// Returns a negative number if the version precedes the other version, zero if they are equal, or a positive number otherwise.
+	compare(other parsedVersion) int
+}
+
+// Compares two versions by the scheme. Returns a negative number if v1 precedes v2, zero if they are equal, or a positive number otherwise.
+func CompareVersions(scheme VersionScheme, v1, v2 string) (int, error) {
+	parsed1, err := parseVersion(scheme, v1)
+	if err != nil {
+		return 0, err
+	}
+	parsed2, err := parseVersion(scheme, v2)
+	if err != nil {
+		return 0, err
+	}
+	return parsed1.compare(parsed2), nil
+}
+
+func validateVersionScheme(scheme VersionScheme) error {
+	switch scheme {
+	case SemVerScheme, MavenScheme, Pep440Scheme, "":
+		return nil
+	default:
+		return errorutils.CheckErrorf("unsupported version scheme: %s", scheme)
+	}
+}
+
+func parseVersion(scheme VersionScheme, version string) (parsedVersion, error) {
+	switch scheme {
+	case SemVerScheme, "":
+		return parseSemVer(version)
+	case MavenScheme:
+		return parseMavenVersion(version), nil
+	case Pep440Scheme:
+		return parsePep440Version(version)
+	default:
+		return nil, validateVersionScheme(scheme)
+	}
+}
+
+type semVersion struct {
+	release    []string
+	preRelease []string
+}
+
+func parseSemVer(version string) (parsedVersion, error) {
+	match := semVerRegexp.FindStringSubmatch(version)
+	if match == nil {
+		return nil, errorutils.CheckErrorf("invalid semantic version: %s", version)
+	}
+	parsed := &semVersion{release: strings.Split(match[1], ".")}
+	if match[2] != "" {
+		parsed.preRelease = strings.Split(match[2], ".")
+	}
+	return parsed, nil
+}
+
+func (sv *semVersion) compare(other parsedVersion) int {
+	otherVersion := other.(*semVersion)
+	if result := compareNumberLists(sv.release, otherVersion.release); result != 0 {
+		return result
+	}
+	// A pre-release precedes its release.
+	if len(sv.preRelease) == 0 || len(otherVersion.preRelease) == 0 {
+		return len(otherVersion.preRelease) - len(sv.preRelease)
+	}
+	for i := 0; i < len(sv.preRelease) && i < len(otherVersion.preRelease); i++ {
+		if result := compareSemVerIdentifiers(sv.preRelease[i], otherVersion.preRelease[i]); result != 0 {
+			return result
+		}
+	}
+	return len(sv.preRelease) - len(otherVersion.preRelease)
+}
+
+// Numeric identifiers are compared numerically, and precede alphanumeric identifiers, which are compared alphabetically.
+func compareSemVerIdentifiers(id1, id2 string) int {
+	numeric1, numeric2 := isNumber(id1), isNumber(id2)
+	switch {
+	case numeric1 && numeric2:
+		return compareNumbers(id1, id2)
+	case numeric1:
+		return -1
+	case numeric2:
+		return 1
+	default:
+		return strings.Compare(id1, id2)
+	}
+}
+
+type mavenToken struct {
+	numeric bool
+	value   string
+}
+
+type mavenVersion []mavenToken
+
+// Splits the version into numbers and qualifiers, like "1.0-RC2" into 1, 0, "rc" and 2. Qualifiers are compared case-insensitively.
+func parseMavenVersion(version string) parsedVersion {
+	var parsed mavenVersion
+	for _, token := range mavenTokenRegexp.FindAllString(strings.ToLower(version), -1) {
+		if isNumber(token) {
+			parsed = append(parsed, mavenToken{numeric: true, value: token})
+			continue
+		}
+		if alias, ok := mavenQualifierAliases[token]; ok {
+			token = alias
+		}
+		parsed = append(parsed, mavenToken{value: token})
+	}
+	return parsed
+}
+
+func (mv mavenVersion) compare(other parsedVersion) int {
+	otherVersion := other.(mavenVersion)
+	for i := 0; i < len(mv) || i < len(otherVersion); i++ {
+		// Missing tokens are equal to zeros and to releases, so "1" equals "1.0" and "1.0-ga".
+		token1, token2 := getMavenToken(mv, i, otherVersion, i), getMavenToken(otherVersion, i, mv, i)
+		if result := compareMavenTokens(token1, token2); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// Returns the token at the index, or the missing token which matches the type of the other version's token.
+func getMavenToken(version mavenVersion, index int, otherVersion mavenVersion, otherIndex int) mavenToken {
+	if index < len(version) {
+		return version[index]
+	}
+	if otherVersion[otherIndex].numeric {
+		return mavenToken{numeric: true, value: "0"}
+	}
+	return mavenToken{}
+}
+
+// Numbers follow qualifiers, so "1.0.1" follows "1.0-sp".
+func compareMavenTokens(token1, token2 mavenToken) int {
+	switch {
+	case token1.numeric && token2.numeric:
+		return compareNumbers(token1.value, token2.value)
+	case token1.numeric:
+		return 1
+	case token2.numeric:
+		return -1
+	}
+	rank1, known1 := mavenQualifiers[token1.value]
+	rank2, known2 := mavenQualifiers[token2.value]
+	switch {
+	case known1 && known2:
+		return rank1 - rank2
+	case known1:
+		return -1
+	case known2:
+		return 1
+	default:
+		return strings.Compare(token1.value, token2.value)
+	}
+}
+
+type pep440Version struct {
+	epoch   string
+	release []string
+	// The phase of the pre-release, or -1 for development releases without a pre-release, which precede all the pre-releases,
+	// or len(pep440Phases) for releases, which follow them.
+	prePhase  int
+	preNumber string
+	hasPost   bool
+	post      string
+	hasDev    bool
+	dev       string
+}
+
+func parsePep440Version(version string) (parsedVersion, error) {
+	match := pep440Regexp.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return nil, errorutils.CheckErrorf("invalid PEP 440 version: %s", version)
+	}
+	parsed := &pep440Version{epoch: defaultNumber(match[1]), release: strings.Split(match[2], "."), prePhase: len(pep440Phases)}
+	if match[3] != "" {
+		parsed.prePhase = pep440Phases[strings.ToLower(match[3])]
+		parsed.preNumber = defaultNumber(match[4])
+	}
+	if match[5] != "" || match[6] != "" {
+		parsed.hasPost = true
+		parsed.post = defaultNumber(match[5] + match[7])
+	}
+	if match[8] != "" {
+		parsed.hasDev = true
+		parsed.dev = defaultNumber(match[9])
+		if match[3] == "" && !parsed.hasPost {
+			parsed.prePhase = -1
+		}
+	}
+	return parsed, nil
+}
+
+func (pv *pep440Version) compare(other parsedVersion) int {
+	otherVersion := other.(*pep440Version)
+	if result := compareNumbers(pv.epoch, otherVersion.epoch); result != 0 {
+		return result
+	}
+	if result := compareNumberLists(pv.release, otherVersion.release); result != 0 {
+		return result
+	}
+	if result := pv.prePhase - otherVersion.prePhase; result != 0 {
+		return result
+	}
+	if result := compareNumbers(defaultNumber(pv.preNumber), defaultNumber(otherVersion.preNumber)); result != 0 {
+		return result
+	}
+	// A post-release follows its release.
+	if pv.hasPost != otherVersion.hasPost {
+		return boolToInt(pv.hasPost) - boolToInt(otherVersion.hasPost)
+	}
+	if result := compareNumbers(defaultNumber(pv.post), defaultNumber(otherVersion.post)); result != 0 {
+		return result
+	}
+	// A development release precedes its release.
+	if pv.hasDev != otherVersion.hasDev {
+		return boolToInt(otherVersion.hasDev) - boolToInt(pv.hasDev)
+	}
+	return compareNumbers(defaultNumber(pv.dev), defaultNumber(otherVersion.dev))
+}
+
+// Compares lists of numbers, in which missing numbers are zeros, so "1.0" equals "1".
+func compareNumberLists(numbers1, numbers2 []string) int {
+	for i := 0; i < len(numbers1) || i < len(numbers2); i++ {
+		number1, number2 := "0", "0"
+		if i < len(numbers1) {
+			number1 = numbers1[i]
+		}
+		if i < len(numbers2) {
+			number2 = numbers2[i]
+		}
+		if result := compareNumbers(number1, number2); result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+// Compares strings of digits of any length.
+func compareNumbers(number1, number2 string) int {
+	number1, number2 = defaultNumber(strings.TrimLeft(number1, "0")), defaultNumber(strings.TrimLeft(number2, "0"))
+	if len(number1) != len(number2) {
+		return len(number1) - len(number2)
+	}
+	return strings.Compare(number1, number2)
+}
+
+func defaultNumber(number string) string {
+	if number == "" {
+		return "0"
+	}
+	return number
+}
+
+func isNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
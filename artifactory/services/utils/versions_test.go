@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareVersions(t *testing.T) {
+	// Each list is sorted from the earliest version.
+	tests := []struct {
+		scheme   VersionScheme
+		versions []string
+	}{
+		{SemVerScheme, []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "v1.0.0", "1.9.0", "1.10.0+build.5", "1.10.1", "10.0", "18446744073709551616.0"}},
+		{MavenScheme, []string{"1.0-alpha-1", "1.0-beta2", "1.0-M1", "1.0-RC1", "1.0-SNAPSHOT", "1.0", "1.0-sp1", "1.0.1", "1.9", "1.10"}},
+		{Pep440Scheme, []string{"1.0.dev1", "1.0a1.dev1", "1.0a1", "1.0b2", "1.0rc1", "1.0", "1.0.post1.dev1", "1.0.post1", "1.1", "1.10", "1!0.5"}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.scheme), func(t *testing.T) {
+			for i := 1; i < len(test.versions); i++ {
+				result, err := CompareVersions(test.scheme, test.versions[i-1], test.versions[i])
+				assert.NoError(t, err)
+				assert.Negative(t, result, "%s < %s", test.versions[i-1], test.versions[i])
+				result, err = CompareVersions(test.scheme, test.versions[i], test.versions[i-1])
+				assert.NoError(t, err)
+				assert.Positive(t, result, "%s > %s", test.versions[i], test.versions[i-1])
+			}
+		})
+	}
+}
+
+func TestCompareEqualVersions(t *testing.T) {
+	tests := []struct {
+		scheme VersionScheme
+		v1, v2 string
+	}{
+		{SemVerScheme, "1.0", "v1.0.0+build"},
+		{MavenScheme, "1", "1.0-ga"},
+		{MavenScheme, "1.0-rc1", "1.0-CR1"},
+		{Pep440Scheme, "1.0.0", "v1.0+local.1"},
+		{Pep440Scheme, "1.0-1", "1.0.post1"},
+		{Pep440Scheme, "1.0ALPHA1", "1.0a1"},
+	}
+	for _, test := range tests {
+		result, err := CompareVersions(test.scheme, test.v1, test.v2)
+		assert.NoError(t, err)
+		assert.Zero(t, result, "%s = %s", test.v1, test.v2)
+	}
+}
+
+func TestCompareInvalidVersions(t *testing.T) {
+	_, err := CompareVersions(SemVerScheme, "1.0", "latest")
+	assert.Error(t, err)
+	_, err = CompareVersions(Pep440Scheme, "1.0-SNAPSHOT", "1.0")
+	assert.Error(t, err)
+	_, err = CompareVersions("calver", "1.0", "1.0")
+	assert.Error(t, err)
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils"
+	"github.com/madotis/jfrog-client-go/artifactory/services/utils/aql"
+	"github.com/madotis/jfrog-client-go/auth"
+	"github.com/madotis/jfrog-client-go/http/jfroghttpclient"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+// The decision of the resolution about a member of a virtual repository.
+type ResolutionDecision string
+
+const (
+	// The member has the path, and serves it.
+	ResolutionServed ResolutionDecision = "served"
+	// The remote member hasn't cached the path, and the path would be requested from its URL.
+	ResolutionRemoteFetch ResolutionDecision = "remote-fetch"
+	ResolutionNotFound    ResolutionDecision = "not-found"
+	// The path is excluded by the include or exclude patterns of the member, or of a virtual repository which includes it.
+	ResolutionExcluded   ResolutionDecision = "excluded"
+	ResolutionBlackedOut ResolutionDecision = "blacked-out"
+	ResolutionOffline    ResolutionDecision = "offline"
+	// A previous member serves the path.
+	ResolutionNotReached ResolutionDecision = "not-reached"
+)
+
+// Artifactory includes all the paths of repositories without includes patterns.
+const defaultIncludesPattern = "**/*"
+
+type VirtualResolutionParams struct {
+	RepoKey string
+	// The path in the virtual repository, like "org/example/lib/1.0/lib-1.0.jar".
+	Path string
+}
+
+func NewVirtualResolutionParams() VirtualResolutionParams {
+	return VirtualResolutionParams{}
+}
+
+type VirtualResolutionStep struct {
+	RepoKey string `json:"repoKey,omitempty"`
+	Rclass  string `json:"rclass,omitempty"`
+	// The virtual repositories through which the member is included, outermost first.
+	Via                []string           `json:"via,omitempty"`
+	PriorityResolution bool               `json:"priorityResolution,omitempty"`
+	Decision           ResolutionDecision `json:"decision,omitempty"`
+	Reason             string             `json:"reason,omitempty"`
+}
+
+// The members of a virtual repository in the order they are resolved: the members with priority resolution first,
+// and within them and the other members, the local and federated repositories, then the caches of the remote repositories,
+// and then the remote repositories themselves.
+type VirtualResolutionReport struct {
+	RepoKey string                  `json:"repoKey,omitempty"`
+	Path    string                  `json:"path,omitempty"`
+	Steps   []VirtualResolutionStep `json:"steps,omitempty"`
+	// The first member which has the path. The path would be requested from the remote members whose decision is
+	// remote-fetch before it, in the order of the steps, and the first of them which has the path would serve it instead.
+	ServedBy string `json:"servedBy,omitempty"`
+}
+
+func (vrr *VirtualResolutionReport) String() string {
+	lines := []string{fmt.Sprintf("Resolving '%s' from the virtual repository '%s':", vrr.Path, vrr.RepoKey)}
+	for _, step := range vrr.Steps {
+		line := fmt.Sprintf("  %s (%s): %s", step.RepoKey, step.Rclass, step.Decision)
+		if step.Reason != "" {
+			line += " - " + step.Reason
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+type VirtualResolutionService struct {
+	client     *jfroghttpclient.JfrogHttpClient
+	ArtDetails auth.ServiceDetails
+}
+
+func NewVirtualResolutionService(client *jfroghttpclient.JfrogHttpClient) *VirtualResolutionService {
+	return &VirtualResolutionService{client: client}
+}
+
+func (vrs *VirtualResolutionService) GetJfrogHttpClient() *jfroghttpclient.JfrogHttpClient {
+	return vrs.client
+}
+
+// Simulates the resolution of a path from a virtual repository, by the configuration of its members and by searching
+// the path in them. Nothing is downloaded, and the remote repositories aren't queried.
+func (vrs *VirtualResolutionService) Simulate(params VirtualResolutionParams) (*VirtualResolutionReport, error) {
+	repositoriesService := &RepositoriesService{client: vrs.client, ArtDetails: vrs.ArtDetails}
+	simulator := &virtualResolutionSimulator{getRepository: repositoriesService.Get, exists: vrs.exists}
+	return simulator.simulate(params)
+}
+
+// Searches the path in the repository.
+func (vrs *VirtualResolutionService) exists(repoKey, itemPath string) (bool, error) {
+	dir, name := path.Split(itemPath)
+	dir = strings.TrimSuffix(dir, "/")
+	if dir == "" {
+		dir = "."
+	}
+	query := aql.Items.Find(aql.Criteria{
+		aql.Field("repo").Equal(repoKey),
+		aql.Field("path").Equal(dir),
+		aql.Field("name").Equal(name),
+	}).Include("name").Limit(1).String()
+	stream, err := NewAqlService(vrs.ArtDetails, vrs.client).ExecAql(query)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = stream.Close()
+	}()
+	body, err := io.ReadAll(stream)
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	result := &utils.AqlSearchResult{}
+	if err = json.Unmarshal(body, result); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return len(result.Results) > 0, nil
+}
+
+// The fields of repositories which affect the resolution.
+type resolutionRepository struct {
+	RepositoryBaseParams
+	Repositories       []string `json:"repositories,omitempty"`
+	BlackedOut         *bool    `json:"blackedOut,omitempty"`
+	PriorityResolution *bool    `json:"priorityResolution,omitempty"`
+	Offline            *bool    `json:"offline,omitempty"`
+	Url                string   `json:"url,omitempty"`
+}
+
+type resolutionMember struct {
+	step *VirtualResolutionStep
+	repo *resolutionRepository
+}
+
+type virtualResolutionSimulator struct {
+	getRepository func(repoKey string, repoDetails interface{}) error
+	exists        func(repoKey, itemPath string) (bool, error)
+}
+
+func (vrs *virtualResolutionSimulator) simulate(params VirtualResolutionParams) (*VirtualResolutionReport, error) {
+	itemPath := strings.TrimPrefix(params.Path, "/")
+	if params.RepoKey == "" || itemPath == "" {
+		return nil, errorutils.CheckErrorf("a virtual repository key and a path are required")
+	}
+	virtual := &resolutionRepository{}
+	if err := vrs.getRepository(params.RepoKey, virtual); err != nil {
+		return nil, err
+	}
+	if virtual.Rclass != VirtualRepositoryRepoType {
+		return nil, errorutils.CheckErrorf("'%s' isn't a virtual repository", params.RepoKey)
+	}
+	virtual.Key = params.RepoKey
+	report := &VirtualResolutionReport{RepoKey: params.RepoKey, Path: itemPath}
+	var members []resolutionMember
+	if err := vrs.addMembers(virtual, nil, "", itemPath, map[string]bool{params.RepoKey: true}, &members); err != nil {
+		return nil, err
+	}
+	// The members with priority resolution are resolved first, and within them and the other members,
+	// the local repositories are resolved before the remote repositories.
+	var ordered []resolutionMember
+	for _, priority := range []bool{true, false} {
+		for _, remote := range []bool{false, true} {
+			for _, member := range members {
+				if member.step.PriorityResolution == priority && (member.step.Rclass == RemoteRepositoryRepoType) == remote {
+					ordered = append(ordered, member)
+				}
+			}
+		}
+	}
+	for _, member := range ordered {
+		report.Steps = append(report.Steps, *member.step)
+	}
+
+	start := 0
+	for start < len(ordered) {
+		end := start
+		for end < len(ordered) && ordered[end].step.PriorityResolution == ordered[start].step.PriorityResolution {
+			end++
+		}
+		servedBy, err := vrs.resolve(report.Steps[start:end], ordered[start:end], itemPath)
+		if err != nil {
+			return nil, err
+		}
+		if servedBy != "" {
+			report.ServedBy = servedBy
+			for i := end; i < len(report.Steps); i++ {
+				if report.Steps[i].Decision == "" {
+					report.Steps[i].Decision = ResolutionNotReached
+				}
+			}
+			break
+		}
+		start = end
+	}
+	return report, nil
+}
+
+// Adds the members of the virtual repository, recursively. excludedReason is set if a virtual repository which includes
+// this one excludes the path.
+func (vrs *virtualResolutionSimulator) addMembers(virtual *resolutionRepository, via []string, excludedReason, itemPath string, visited map[string]bool, members *[]resolutionMember) error {
+	via = append(via[:len(via):len(via)], virtual.Key)
+	if excludedReason == "" {
+		excludedReason = getPatternsExcludedReason(virtual, itemPath)
+	}
+	for _, memberKey := range virtual.Repositories {
+		if visited[memberKey] {
+			continue
+		}
+		visited[memberKey] = true
+		repo := &resolutionRepository{}
+		if err := vrs.getRepository(memberKey, repo); err != nil {
+			return err
+		}
+		repo.Key = memberKey
+		if repo.Rclass == VirtualRepositoryRepoType {
+			if err := vrs.addMembers(repo, via, excludedReason, itemPath, visited, members); err != nil {
+				return err
+			}
+			continue
+		}
+		step := &VirtualResolutionStep{RepoKey: memberKey, Rclass: repo.Rclass, Via: via, PriorityResolution: isTrue(repo.PriorityResolution)}
+		switch {
+		case excludedReason != "":
+			step.Decision, step.Reason = ResolutionExcluded, excludedReason
+		case isTrue(repo.BlackedOut):
+			step.Decision, step.Reason = ResolutionBlackedOut, "the repository is blacked out"
+		default:
+			if reason := getPatternsExcludedReason(repo, itemPath); reason != "" {
+				step.Decision, step.Reason = ResolutionExcluded, reason
+			}
+		}
+		*members = append(*members, resolutionMember{step: step, repo: repo})
+	}
+	return nil
+}
+
+// Resolves the path from members of the same priority: from the local repositories, then from the caches of the remote
+// repositories, and then from the remote repositories themselves. Returns the member which serves the path, if any.
+func (vrs *virtualResolutionSimulator) resolve(steps []VirtualResolutionStep, members []resolutionMember, itemPath string) (string, error) {
+	servedBy := ""
+	var uncached []int
+	for i, member := range members {
+		if steps[i].Decision != "" {
+			continue
+		}
+		if servedBy != "" {
+			steps[i].Decision = ResolutionNotReached
+			continue
+		}
+		isRemote := member.step.Rclass == RemoteRepositoryRepoType
+		repoKey := member.step.RepoKey
+		if isRemote {
+			repoKey += "-cache"
+		}
+		exists, err := vrs.exists(repoKey, itemPath)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case exists && isRemote:
+			steps[i].Decision, steps[i].Reason = ResolutionServed, "the path is cached"
+			servedBy = member.step.RepoKey
+		case exists:
+			steps[i].Decision = ResolutionServed
+			servedBy = member.step.RepoKey
+		case isRemote:
+			uncached = append(uncached, i)
+		default:
+			steps[i].Decision = ResolutionNotFound
+		}
+	}
+	for _, i := range uncached {
+		switch {
+		case servedBy != "":
+			steps[i].Decision, steps[i].Reason = ResolutionNotFound, "the path isn't cached"
+		case isTrue(members[i].repo.Offline):
+			steps[i].Decision, steps[i].Reason = ResolutionOffline, "the path isn't cached, and the repository is offline"
+		default:
+			steps[i].Decision, steps[i].Reason = ResolutionRemoteFetch, "the path isn't cached, and would be requested from "+members[i].repo.Url
+		}
+	}
+	return servedBy, nil
+}
+
+// Returns the reason the include and exclude patterns of the repository exclude the path, or an empty string if they include it.
+// The patterns are comma-separated Ant patterns.
+func getPatternsExcludedReason(repo *resolutionRepository, itemPath string) string {
+	includesPattern := repo.IncludesPattern
+	if includesPattern == "" {
+		includesPattern = defaultIncludesPattern
+	}
+	if !matchesAntPatterns(includesPattern, itemPath) {
+		return fmt.Sprintf("the path doesn't match the includes pattern '%s' of '%s'", includesPattern, repo.Key)
+	}
+	if repo.ExcludesPattern != "" && matchesAntPatterns(repo.ExcludesPattern, itemPath) {
+		return fmt.Sprintf("the path matches the excludes pattern '%s' of '%s'", repo.ExcludesPattern, repo.Key)
+	}
+	return ""
+}
+
+func matchesAntPatterns(patterns, itemPath string) bool {
+	for _, pattern := range strings.Split(patterns, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" && antPatternToRegexp(pattern).MatchString(itemPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// Converts an Ant pattern of repository paths to a regexp. "**" matches any number of folders, "*" matches any characters
+// but slashes, and "?" matches a single character. A pattern which ends with a slash matches everything under the folder.
+func antPatternToRegexp(pattern string) *regexp.Regexp {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	expression := strings.Builder{}
+	expression.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expression.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			expression.WriteString("(?:/.*)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expression.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expression.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expression.WriteString("[^/]")
+		default:
+			expression.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String())
+}
+
+func isTrue(value *bool) bool {
+	return value != nil && *value
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testResolutionRepositories = map[string]string{
+	"libs":           `{"key":"libs","rclass":"virtual","repositories":["libs-local","maven-remote","nested","release-local"],"excludesPattern":"**/*-sources.jar"}`,
+	"libs-local":     `{"key":"libs-local","rclass":"local","includesPattern":"com/**"}`,
+	"maven-remote":   `{"key":"maven-remote","rclass":"remote","url":"https://repo.maven.apache.org/maven2"}`,
+	"nested":         `{"key":"nested","rclass":"virtual","repositories":["libs-local","snapshot-local","other-remote"]}`,
+	"snapshot-local": `{"key":"snapshot-local","rclass":"local","blackedOut":true}`,
+	"other-remote":   `{"key":"other-remote","rclass":"remote","url":"https://example.com","offline":true}`,
+	"release-local":  `{"key":"release-local","rclass":"local","priorityResolution":true}`,
+}
+
+func newTestResolutionSimulator(existing ...string) *virtualResolutionSimulator {
+	return &virtualResolutionSimulator{
+		getRepository: func(repoKey string, repoDetails interface{}) error {
+			return json.Unmarshal([]byte(testResolutionRepositories[repoKey]), repoDetails)
+		},
+		exists: func(repoKey, itemPath string) (bool, error) {
+			for _, existingPath := range existing {
+				if existingPath == repoKey+"/"+itemPath {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	}
+}
+
+func getTestResolutionDecisions(report *VirtualResolutionReport) map[string]ResolutionDecision {
+	decisions := map[string]ResolutionDecision{}
+	for _, step := range report.Steps {
+		decisions[step.RepoKey] = step.Decision
+	}
+	return decisions
+}
+
+func TestSimulateVirtualResolution(t *testing.T) {
+	params := VirtualResolutionParams{RepoKey: "libs", Path: "org/lib/1.0/lib-1.0.jar"}
+	report, err := newTestResolutionSimulator("maven-remote-cache/org/lib/1.0/lib-1.0.jar").simulate(params)
+	assert.NoError(t, err)
+	var order []string
+	for _, step := range report.Steps {
+		order = append(order, step.RepoKey)
+	}
+	// The member with priority resolution is first, and the local repositories precede the remote repositories.
+	assert.Equal(t, []string{"release-local", "libs-local", "snapshot-local", "maven-remote", "other-remote"}, order)
+	assert.Equal(t, []string{"libs", "nested"}, report.Steps[2].Via)
+	assert.Equal(t, "maven-remote", report.ServedBy)
+	assert.Equal(t, map[string]ResolutionDecision{
+		"release-local":  ResolutionNotFound,
+		"libs-local":     ResolutionExcluded,
+		"snapshot-local": ResolutionBlackedOut,
+		"maven-remote":   ResolutionServed,
+		"other-remote":   ResolutionNotReached,
+	}, getTestResolutionDecisions(report))
+	assert.Equal(t, "the path doesn't match the includes pattern 'com/**' of 'libs-local'", report.Steps[1].Reason)
+
+	// Without a cached copy, the path would be fetched from the online remote repository.
+	report, err = newTestResolutionSimulator().simulate(params)
+	assert.NoError(t, err)
+	assert.Empty(t, report.ServedBy)
+	assert.Equal(t, ResolutionRemoteFetch, getTestResolutionDecisions(report)["maven-remote"])
+	assert.Equal(t, ResolutionOffline, getTestResolutionDecisions(report)["other-remote"])
+
+	// The virtual repository's exclude pattern excludes the path from all the members.
+	params.Path = "com/lib/1.0/lib-1.0-sources.jar"
+	report, err = newTestResolutionSimulator("libs-local/com/lib/1.0/lib-1.0-sources.jar").simulate(params)
+	assert.NoError(t, err)
+	assert.Empty(t, report.ServedBy)
+	for _, step := range report.Steps {
+		assert.Equal(t, ResolutionExcluded, step.Decision, step.RepoKey)
+	}
+
+	params.Path = "com/lib/1.0/lib-1.0.jar"
+	report, err = newTestResolutionSimulator("release-local/com/lib/1.0/lib-1.0.jar", "libs-local/com/lib/1.0/lib-1.0.jar").simulate(params)
+	assert.NoError(t, err)
+	assert.Equal(t, "release-local", report.ServedBy)
+	assert.Equal(t, ResolutionNotReached, getTestResolutionDecisions(report)["libs-local"])
+
+	_, err = newTestResolutionSimulator().simulate(VirtualResolutionParams{RepoKey: "libs-local", Path: "a"})
+	assert.Error(t, err)
+}
+
+func TestAntPatternToRegexp(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"**/*", "a/b/c.jar", true},
+		{"**/*", "c.jar", true},
+		{"com/**", "com/a/b.jar", true},
+		{"com/**", "org/com/b.jar", false},
+		{"com/", "com/a/b.jar", true},
+		{"**/*.pom", "a/b/c.pom", true},
+		{"**/*.pom", "a/b/c.jar", false},
+		{"a/*/c.jar", "a/b/c.jar", true},
+		{"a/*/c.jar", "a/b/d/c.jar", false},
+		{"a/b?.jar", "a/b1.jar", true},
+		{"a/**/c.jar", "a/c.jar", true},
+		{"a.b/*", "axb/c", false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, antPatternToRegexp(test.pattern).MatchString(test.path), test.pattern+" "+test.path)
+	}
+	assert.True(t, matchesAntPatterns("org/**, com/**", "com/a.jar"))
+}
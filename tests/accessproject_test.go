@@ -18,6 +18,159 @@ func TestAccessProjectGroups(t *testing.T) {
 	t.Run("groups-add-get-delete", testAccessProjectAddGetDeleteGroups)
 }
 
+func TestAccessProjectUsers(t *testing.T) {
+	initAccessTest(t)
+	t.Run("users-add-get-delete", testAccessProjectAddGetDeleteUsers)
+}
+
+func TestAccessProjectRoles(t *testing.T) {
+	initAccessTest(t)
+	t.Run("roles-create-update-delete", testAccessProjectCreateUpdateDeleteRoles)
+}
+
+func TestAccessProjectStorageQuota(t *testing.T) {
+	initAccessTest(t)
+	t.Run("storage-quota-get-update", testAccessProjectGetUpdateStorageQuota)
+}
+
+func TestAccessEnvironments(t *testing.T) {
+	initAccessTest(t)
+	t.Run("global-environments-create-get-delete", testAccessGlobalEnvironments)
+	t.Run("project-environments-create-get-delete", testAccessProjectEnvironments)
+}
+
+func testAccessProjectAddGetDeleteUsers(t *testing.T) {
+	projectParams := getTestProjectParams("tstprj", "testProject")
+	assert.NoError(t, testsAccessProjectService.Create(projectParams))
+	defer deleteProjectAndAssert(t, projectParams.ProjectDetails.ProjectKey)
+	projectKey := projectParams.ProjectDetails.ProjectKey
+
+	userParams := getTestUserParams(false, "prjusr")
+	assert.NoError(t, testUserService.CreateUser(userParams))
+	defer deleteUserAndAssert(t, userParams.UserDetails.Name)
+	testUser := services.ProjectUser{Name: userParams.UserDetails.Name, Roles: []string{"Viewer"}}
+	assert.NoError(t, testsAccessProjectService.UpdateUser(projectKey, testUser.Name, testUser))
+
+	allUsers, err := testsAccessProjectService.GetUsers(projectKey)
+	if assert.NoError(t, err) && assert.NotNil(t, allUsers) {
+		assert.Contains(t, *allUsers, testUser)
+	}
+
+	// Updating a member replaces its roles.
+	testUser.Roles = []string{"Developer"}
+	assert.NoError(t, testsAccessProjectService.UpdateUser(projectKey, testUser.Name, testUser))
+	singleUser, err := testsAccessProjectService.GetUser(projectKey, testUser.Name)
+	if assert.NoError(t, err) && assert.NotNil(t, singleUser, "Expected user %s but got nil", testUser.Name) {
+		assert.Equal(t, testUser, *singleUser)
+	}
+
+	assert.NoError(t, testsAccessProjectService.DeleteExistingUser(projectKey, testUser.Name))
+	deletedUser, err := testsAccessProjectService.GetUser(projectKey, testUser.Name)
+	assert.NoError(t, err)
+	assert.Nil(t, deletedUser)
+}
+
+func testAccessProjectCreateUpdateDeleteRoles(t *testing.T) {
+	projectParams := getTestProjectParams("tstprj", "testProject")
+	assert.NoError(t, testsAccessProjectService.Create(projectParams))
+	defer deleteProjectAndAssert(t, projectParams.ProjectDetails.ProjectKey)
+	projectKey := projectParams.ProjectDetails.ProjectKey
+
+	testRole := services.ProjectRole{
+		Name:         "Test Role " + timestampStr,
+		Environments: []string{"DEV"},
+		Actions:      []string{"READ_REPOSITORY", "ANNOTATE_REPOSITORY"},
+	}
+	assert.NoError(t, testsAccessProjectService.CreateRole(projectKey, testRole))
+	role, err := testsAccessProjectService.GetRole(projectKey, testRole.Name)
+	if assert.NoError(t, err) && assert.NotNil(t, role, "Expected role %s but got nil", testRole.Name) {
+		assert.Equal(t, services.ProjectRoleTypeCustom, role.Type)
+		assert.ElementsMatch(t, testRole.Environments, role.Environments)
+		assert.ElementsMatch(t, testRole.Actions, role.Actions)
+	}
+
+	testRole.Description = "Updated"
+	testRole.Environments = []string{"DEV", "PROD"}
+	assert.NoError(t, testsAccessProjectService.UpdateRole(projectKey, testRole))
+	allRoles, err := testsAccessProjectService.GetRoles(projectKey)
+	if assert.NoError(t, err) && assert.NotNil(t, allRoles) {
+		var updatedRole *services.ProjectRole
+		for i := range *allRoles {
+			if (*allRoles)[i].Name == testRole.Name {
+				updatedRole = &(*allRoles)[i]
+			}
+		}
+		if assert.NotNil(t, updatedRole, "Expected role %s in the project roles", testRole.Name) {
+			assert.Equal(t, testRole.Description, updatedRole.Description)
+			assert.ElementsMatch(t, testRole.Environments, updatedRole.Environments)
+		}
+	}
+
+	assert.NoError(t, testsAccessProjectService.DeleteRole(projectKey, testRole.Name))
+	deletedRole, err := testsAccessProjectService.GetRole(projectKey, testRole.Name)
+	assert.NoError(t, err)
+	assert.Nil(t, deletedRole)
+}
+
+func testAccessProjectGetUpdateStorageQuota(t *testing.T) {
+	projectParams := getTestProjectParams("tstprj", "testProject")
+	assert.NoError(t, testsAccessProjectService.Create(projectParams))
+	defer deleteProjectAndAssert(t, projectParams.ProjectDetails.ProjectKey)
+	projectKey := projectParams.ProjectDetails.ProjectKey
+
+	quota, err := testsAccessProjectService.GetStorageQuota(projectKey)
+	if assert.NoError(t, err) && assert.NotNil(t, quota) {
+		assert.Equal(t, services.ProjectStorageQuota{StorageQuotaBytes: projectParams.ProjectDetails.StorageQuotaBytes}, *quota)
+	}
+
+	updatedQuota := services.ProjectStorageQuota{StorageQuotaBytes: projectParams.ProjectDetails.StorageQuotaBytes * 2, SoftLimit: true}
+	assert.NoError(t, testsAccessProjectService.UpdateStorageQuota(projectKey, updatedQuota))
+	quota, err = testsAccessProjectService.GetStorageQuota(projectKey)
+	if assert.NoError(t, err) && assert.NotNil(t, quota) {
+		assert.Equal(t, updatedQuota, *quota)
+	}
+
+	// The rest of the project's details are kept.
+	project, err := testsAccessProjectService.Get(projectKey)
+	if assert.NoError(t, err) && assert.NotNil(t, project) {
+		assert.Equal(t, projectParams.ProjectDetails.DisplayName, project.DisplayName)
+		assert.Equal(t, projectParams.ProjectDetails.Description, project.Description)
+		assert.Equal(t, projectParams.ProjectDetails.AdminPrivileges, project.AdminPrivileges)
+	}
+}
+
+func testAccessGlobalEnvironments(t *testing.T) {
+	environmentName := "TEST" + timestampStr
+	assert.NoError(t, testsAccessEnvironmentService.CreateGlobalEnvironment(environmentName))
+	environments, err := testsAccessEnvironmentService.GetGlobalEnvironments()
+	assert.NoError(t, err)
+	assert.Contains(t, environments, services.Environment{Name: environmentName})
+
+	assert.NoError(t, testsAccessEnvironmentService.DeleteGlobalEnvironment(environmentName))
+	environments, err = testsAccessEnvironmentService.GetGlobalEnvironments()
+	assert.NoError(t, err)
+	assert.NotContains(t, environments, services.Environment{Name: environmentName})
+}
+
+func testAccessProjectEnvironments(t *testing.T) {
+	projectParams := getTestProjectParams("tstprj", "testProject")
+	assert.NoError(t, testsAccessProjectService.Create(projectParams))
+	defer deleteProjectAndAssert(t, projectParams.ProjectDetails.ProjectKey)
+	projectKey := projectParams.ProjectDetails.ProjectKey
+
+	// The names of project environments are prefixed with the project key.
+	environmentName := projectKey + "-QA"
+	assert.NoError(t, testsAccessEnvironmentService.CreateProjectEnvironment(projectKey, environmentName))
+	environments, err := testsAccessEnvironmentService.GetProjectEnvironments(projectKey)
+	assert.NoError(t, err)
+	assert.Contains(t, environments, services.Environment{Name: environmentName})
+
+	assert.NoError(t, testsAccessEnvironmentService.DeleteProjectEnvironment(projectKey, environmentName))
+	environments, err = testsAccessEnvironmentService.GetProjectEnvironments(projectKey)
+	assert.NoError(t, err)
+	assert.NotContains(t, environments, services.Environment{Name: environmentName})
+}
+
 func testAccessProjectAddGetDeleteGroups(t *testing.T) {
 	projectParams := getTestProjectParams("tstprj", "testProject")
 	assert.NoError(t, testsAccessProjectService.Create(projectParams))
@@ -77,6 +77,7 @@ func setupIntegrationTests() {
 		createAccessProjectManager()
 		createAccessInviteManager()
 		createAccessTokensManager()
+		createAccessEnvironmentManager()
 	}
 	if err := createRepo(); err != nil {
 		log.Error(err.Error())
@@ -119,10 +119,11 @@ var (
 	testPipelinesSyncStatusService    *pipelinesServices.SyncStatusService
 
 	// Access Services
-	testsAccessPingService    *accessServices.PingService
-	testsAccessProjectService *accessServices.ProjectService
-	testsAccessInviteService  *accessServices.InviteService
-	testsAccessTokensService  *accessServices.TokenService
+	testsAccessPingService        *accessServices.PingService
+	testsAccessProjectService     *accessServices.ProjectService
+	testsAccessInviteService      *accessServices.InviteService
+	testsAccessTokensService      *accessServices.TokenService
+	testsAccessEnvironmentService *accessServices.EnvironmentService
 
 	timestamp    = time.Now().Unix()
 	timestampStr = strconv.FormatInt(timestamp, 10)
@@ -1107,6 +1108,14 @@ func createAccessTokensManager() {
 	testsAccessTokensService.ServiceDetails = accessDetails
 }
 
+func createAccessEnvironmentManager() {
+	accessDetails := GetAccessDetails()
+	client, err := createJfrogHttpClient(&accessDetails)
+	failOnHttpClientCreation(err)
+	testsAccessEnvironmentService = accessServices.NewEnvironmentService(client)
+	testsAccessEnvironmentService.ServiceDetails = accessDetails
+}
+
 func createAccessPingManager() {
 	accessDetails := GetAccessDetails()
 	client, err := createJfrogHttpClient(&accessDetails)
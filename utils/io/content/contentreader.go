@@ -26,6 +26,8 @@ type ContentReader struct {
 	arrayKey string
 	// The objects from the source data file are being pushed into the data channel.
 	dataChannel chan map[string]interface{}
+	// Closed by 'Reset()', to stop the reading of the previous pass over the files.
+	stopChannel chan struct{}
 	errorsQueue *utils.ErrorsQueue
 	once        *sync.Once
 	// Number of elements in the array (cache)
@@ -44,6 +46,7 @@ func NewMultiSourceContentReader(filePaths []string, arrayKey string) *ContentRe
 	self.filesPaths = filePaths
 	self.arrayKey = arrayKey
 	self.dataChannel = make(chan map[string]interface{}, utils.MaxBufferSize)
+	self.stopChannel = make(chan struct{})
 	self.errorsQueue = utils.NewErrorsQueue(utils.MaxBufferSize)
 	self.once = new(sync.Once)
 	self.empty = len(filePaths) == 0
@@ -67,10 +70,11 @@ func (cr *ContentReader) NextRecord(recordOutput interface{}) error {
 		return errorutils.CheckErrorf("Empty")
 	}
 	cr.once.Do(func() {
+		dataChannel, stopChannel := cr.dataChannel, cr.stopChannel
 		go func() {
-			defer close(cr.dataChannel)
+			defer close(dataChannel)
 			cr.length = 0
-			cr.run()
+			cr.run(dataChannel, stopChannel)
 		}()
 	})
 	record, ok := <-cr.dataChannel
@@ -88,7 +92,10 @@ func (cr *ContentReader) NextRecord(recordOutput interface{}) error {
 }
 
 // Prepare the reader to read the file all over again (not thread-safe).
+// If the previous pass didn't read all the records, the rest of them are discarded.
 func (cr *ContentReader) Reset() {
+	close(cr.stopChannel)
+	cr.stopChannel = make(chan struct{})
 	cr.dataChannel = make(chan map[string]interface{}, utils.MaxBufferSize)
 	cr.once = new(sync.Once)
 }
@@ -129,18 +136,21 @@ func (cr *ContentReader) Length() (int, error) {
 
 // Open and read the files one by one. Push each array element into the channel.
 // The channel may block the thread, therefore should run async.
-func (cr *ContentReader) run() {
+func (cr *ContentReader) run(dataChannel chan<- map[string]interface{}, stopChannel <-chan struct{}) {
 	for _, filePath := range cr.filesPaths {
-		cr.readSingleFile(filePath)
+		if !cr.readSingleFile(filePath, dataChannel, stopChannel) {
+			return
+		}
 	}
 }
 
-func (cr *ContentReader) readSingleFile(filePath string) {
+// Returns false if the reading was stopped.
+func (cr *ContentReader) readSingleFile(filePath string, dataChannel chan<- map[string]interface{}, stopChannel <-chan struct{}) bool {
 	fd, err := os.Open(filePath)
 	if err != nil {
 		log.Error(err.Error())
 		cr.errorsQueue.AddError(errorutils.CheckError(err))
-		return
+		return true
 	}
 	defer func() {
 		err = fd.Close()
@@ -149,28 +159,54 @@ func (cr *ContentReader) readSingleFile(filePath string) {
 			cr.errorsQueue.AddError(errorutils.CheckError(err))
 		}
 	}()
-	br := bufio.NewReaderSize(fd, 65536)
-	dec := json.NewDecoder(br)
+	format, compression := getFileFormat(filePath)
+	decompressor, err := newDecompressingReader(bufio.NewReaderSize(fd, 65536), compression)
+	if err != nil {
+		log.Error(err.Error())
+		cr.errorsQueue.AddError(err)
+		return true
+	}
+	defer func() {
+		if err = decompressor.Close(); err != nil {
+			log.Error(err.Error())
+			cr.errorsQueue.AddError(errorutils.CheckError(err))
+		}
+	}()
+	dec := json.NewDecoder(decompressor)
+	if format == NdjsonFormat {
+		return cr.readRecords(dec, dataChannel, stopChannel)
+	}
 	err = findDecoderTargetPosition(dec, cr.arrayKey, true)
 	if err != nil {
 		if err == io.EOF {
 			cr.errorsQueue.AddError(errorutils.CheckErrorf(cr.arrayKey + " not found"))
-			return
+			return true
 		}
 		cr.errorsQueue.AddError(err)
 		log.Error(err.Error())
-		return
+		return true
 	}
+	return cr.readRecords(dec, dataChannel, stopChannel)
+}
+
+// Push each of the decoder's values into the channel, until the end of the array or the end of the input.
+// Returns false if the reading was stopped.
+func (cr *ContentReader) readRecords(dec *json.Decoder, dataChannel chan<- map[string]interface{}, stopChannel <-chan struct{}) bool {
 	for dec.More() {
 		var ResultItem map[string]interface{}
 		err := dec.Decode(&ResultItem)
 		if err != nil {
 			log.Error(err)
 			cr.errorsQueue.AddError(errorutils.CheckError(err))
-			return
+			return true
+		}
+		select {
+		case dataChannel <- ResultItem:
+		case <-stopChannel:
+			return false
 		}
-		cr.dataChannel <- ResultItem
 	}
+	return true
 }
 
 func (cr *ContentReader) GetError() error {
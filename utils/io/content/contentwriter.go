@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 
@@ -38,6 +39,8 @@ type ContentWriter struct {
 	once           sync.Once
 	empty          bool
 	useStdout      bool
+	format         FileFormat
+	compression    Compression
 }
 
 func NewContentWriter(arrayKey string, isCompleteFile, useStdout bool) (*ContentWriter, error) {
@@ -48,6 +51,10 @@ func NewContentWriter(arrayKey string, isCompleteFile, useStdout bool) (*Content
 	self.errorsQueue = utils.NewErrorsQueue(utils.MaxBufferSize)
 	self.isCompleteFile = isCompleteFile
 	self.empty = true
+	self.format, self.compression = JsonFormat, NoCompression
+	if isCompleteFile && !useStdout {
+		self.format, self.compression = getDefaultFileFormat()
+	}
 	return &self, nil
 }
 
@@ -56,6 +63,15 @@ func (rw *ContentWriter) SetArrayKey(arrKey string) *ContentWriter {
 	return rw
 }
 
+// Sets the format and compression of the file, instead of the default ones. Should be called before the first record is written.
+// Incomplete files and the standard output are always written as plain JSON.
+func (rw *ContentWriter) SetFileFormat(format FileFormat, compression Compression) *ContentWriter {
+	if rw.isCompleteFile && !rw.useStdout {
+		rw.format, rw.compression = format, compression
+	}
+	return rw
+}
+
 func (rw *ContentWriter) GetArrayKey() string {
 	return rw.arrayKey
 }
@@ -88,7 +104,11 @@ func (rw *ContentWriter) startWritingWorker() {
 		if rw.useStdout {
 			rw.outputFile = os.Stdout
 		} else {
-			rw.outputFile, err = fileutils.CreateTempFile()
+			if err = validateFileFormat(rw.format, rw.compression); err != nil {
+				rw.errorsQueue.AddError(err)
+				return
+			}
+			rw.outputFile, err = fileutils.CreateTempFileWithSuffix(getFileSuffix(rw.format, rw.compression))
 			if err != nil {
 				rw.errorsQueue.AddError(errorutils.CheckError(err))
 				return
@@ -106,19 +126,33 @@ func (rw *ContentWriter) startWritingWorker() {
 // The channel may block the thread, therefore should run async.
 func (rw *ContentWriter) run() {
 	var err error
+	var output io.Writer = rw.outputFile
 	if !rw.useStdout {
+		var compressor io.WriteCloser
+		if compressor, err = newCompressingWriter(rw.outputFile, rw.compression); err != nil {
+			rw.errorsQueue.AddError(err)
+			// Drain the channel, so that writing records doesn't block.
+			for range rw.dataChannel {
+			}
+			return
+		}
+		output = compressor
 		defer func() {
-			if err = errors.Join(rw.outputFile.Sync(), rw.outputFile.Close()); err != nil {
+			if err = errors.Join(compressor.Close(), rw.outputFile.Sync(), rw.outputFile.Close()); err != nil {
 				rw.errorsQueue.AddError(errorutils.CheckError(err))
 			}
 		}()
 	}
+	if rw.format == NdjsonFormat {
+		rw.writeNdjson(output)
+		return
+	}
 	openString := jsonArrayPrefixPattern
 	closeString := ""
 	if rw.isCompleteFile {
 		openString = "{\n" + openString
 	}
-	_, err = rw.outputFile.WriteString(fmt.Sprintf(openString, rw.arrayKey))
+	_, err = io.WriteString(output, fmt.Sprintf(openString, rw.arrayKey))
 	if err != nil {
 		rw.errorsQueue.AddError(errorutils.CheckError(err))
 		return
@@ -136,7 +170,7 @@ func (rw *ContentWriter) run() {
 			continue
 		}
 		record := recordPrefix + string(bytes.TrimRight(buf.Bytes(), "\n"))
-		_, err = rw.outputFile.WriteString(record)
+		_, err = io.WriteString(output, record)
 		if err != nil {
 			rw.errorsQueue.AddError(errorutils.CheckError(err))
 			continue
@@ -153,12 +187,22 @@ func (rw *ContentWriter) run() {
 	if rw.isCompleteFile {
 		closeString += "}\n"
 	}
-	_, err = rw.outputFile.WriteString(closeString)
+	_, err = io.WriteString(output, closeString)
 	if err != nil {
 		rw.errorsQueue.AddError(errorutils.CheckError(err))
 	}
 }
 
+// Write each record from the channel in a separate line.
+func (rw *ContentWriter) writeNdjson(output io.Writer) {
+	enc := json.NewEncoder(output)
+	for record := range rw.dataChannel {
+		if err := enc.Encode(record); err != nil {
+			rw.errorsQueue.AddError(errorutils.CheckError(err))
+		}
+	}
+}
+
 // Finish writing to the file.
 func (rw *ContentWriter) Close() error {
 	if rw.empty {
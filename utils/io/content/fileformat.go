@@ -0,0 +1,135 @@
+package content
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+)
+
+// The format of the records in the files written by ContentWriter.
+type FileFormat string
+
+const (
+	// A JSON object, whose array key holds the records.
+	JsonFormat FileFormat = "json"
+	// A record in each line. The array key isn't written, and any array key reads all the records.
+	NdjsonFormat FileFormat = "ndjson"
+)
+
+type Compression string
+
+const (
+	NoCompression   Compression = ""
+	GzipCompression Compression = "gz"
+	ZstdCompression Compression = "zst"
+)
+
+var (
+	defaultFormatMutex     sync.RWMutex
+	defaultFileFormat      = JsonFormat
+	defaultFileCompression = NoCompression
+)
+
+// Sets the format and compression of the files written by the ContentWriters created afterwards, including the temp files
+// written while merging and sorting readers. Writers of incomplete files, or to the standard output, always write plain JSON.
+// The format of a file is part of its name, so ContentReader reads the files of any format.
+func SetDefaultFileFormat(format FileFormat, compression Compression) {
+	defaultFormatMutex.Lock()
+	defer defaultFormatMutex.Unlock()
+	defaultFileFormat = format
+	defaultFileCompression = compression
+}
+
+func getDefaultFileFormat() (FileFormat, Compression) {
+	defaultFormatMutex.RLock()
+	defer defaultFormatMutex.RUnlock()
+	return defaultFileFormat, defaultFileCompression
+}
+
+// Returns the extension of the files of the format and compression, like ".ndjson.zst". Plain JSON files have no extension.
+func getFileSuffix(format FileFormat, compression Compression) string {
+	suffix := ""
+	if format == NdjsonFormat {
+		suffix = "." + string(NdjsonFormat)
+	}
+	if compression != NoCompression {
+		suffix += "." + string(compression)
+	}
+	return suffix
+}
+
+// Returns the format and compression of a file, according to its extensions.
+func getFileFormat(filePath string) (format FileFormat, compression Compression) {
+	format = JsonFormat
+	if strings.HasSuffix(filePath, "."+string(GzipCompression)) {
+		compression = GzipCompression
+	} else if strings.HasSuffix(filePath, "."+string(ZstdCompression)) {
+		compression = ZstdCompression
+	}
+	if strings.HasSuffix(strings.TrimSuffix(filePath, "."+string(compression)), "."+string(NdjsonFormat)) {
+		format = NdjsonFormat
+	}
+	return
+}
+
+func validateFileFormat(format FileFormat, compression Compression) error {
+	if format != JsonFormat && format != NdjsonFormat {
+		return errorutils.CheckErrorf("unsupported content file format: %s", format)
+	}
+	if compression != NoCompression && compression != GzipCompression && compression != ZstdCompression {
+		return errorutils.CheckErrorf("unsupported content file compression: %s", compression)
+	}
+	return nil
+}
+
+// Returns a writer which compresses into w. Closing it flushes the compressed data, without closing w.
+func newCompressingWriter(w io.Writer, compression Compression) (io.WriteCloser, error) {
+	switch compression {
+	case GzipCompression:
+		return gzip.NewWriter(w), nil
+	case ZstdCompression:
+		encoder, err := zstd.NewWriter(w)
+		return encoder, errorutils.CheckError(err)
+	default:
+		return nopWriteCloser{w}, nil
+	}
+}
+
+// Returns a reader which decompresses r. Closing it doesn't close r.
+func newDecompressingReader(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case GzipCompression:
+		reader, err := gzip.NewReader(r)
+		return reader, errorutils.CheckError(err)
+	case ZstdCompression:
+		decoder, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return zstdReadCloser{decoder}, nil
+	default:
+		return io.NopCloser(r), nil
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// zstd.Decoder.Close doesn't return an error.
+type zstdReadCloser struct {
+	*zstd.Decoder
+}
+
+func (zrc zstdReadCloser) Close() error {
+	zrc.Decoder.Close()
+	return nil
+}
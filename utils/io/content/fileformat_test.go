@@ -0,0 +1,136 @@
+package content
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFileFormats = []struct {
+	format      FileFormat
+	compression Compression
+	suffix      string
+}{
+	{JsonFormat, NoCompression, ""},
+	{JsonFormat, GzipCompression, ".gz"},
+	{NdjsonFormat, NoCompression, ".ndjson"},
+	{NdjsonFormat, GzipCompression, ".ndjson.gz"},
+	{NdjsonFormat, ZstdCompression, ".ndjson.zst"},
+}
+
+func TestContentFileFormats(t *testing.T) {
+	for _, test := range testFileFormats {
+		t.Run(string(test.format)+"_"+string(test.compression), func(t *testing.T) {
+			SetDefaultFileFormat(test.format, test.compression)
+			defer SetDefaultFileFormat(JsonFormat, NoCompression)
+
+			writer, err := NewContentWriter(DefaultKey, true, false)
+			assert.NoError(t, err)
+			writeTestRecords(t, writer)
+			assert.True(t, strings.HasSuffix(writer.GetFilePath(), test.suffix))
+			format, compression := getFileFormat(writer.GetFilePath())
+			assert.Equal(t, test.format, format)
+			assert.Equal(t, test.compression, compression)
+
+			reader := NewContentReader(writer.GetFilePath(), DefaultKey)
+			length, err := reader.Length()
+			assert.NoError(t, err)
+			assert.Equal(t, len(records), length)
+			for item := new(outputRecord); reader.NextRecord(item) == nil; item = new(outputRecord) {
+				assert.Contains(t, records, *item)
+			}
+			getErrorAndAssert(t, reader)
+			reader.Reset()
+
+			// Merging and sorting write their results in the default format too.
+			otherWriter, err := NewContentWriter(DefaultKey, true, false)
+			assert.NoError(t, err)
+			writeTestRecords(t, otherWriter)
+			otherReader := NewContentReader(otherWriter.GetFilePath(), DefaultKey)
+			defer closeAndAssert(t, otherReader)
+			merged, err := MergeReaders([]*ContentReader{reader, otherReader}, DefaultKey)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(merged.GetFilesPaths()[0], test.suffix))
+			length, err = merged.Length()
+			assert.NoError(t, err)
+			assert.Equal(t, 2*len(records), length)
+
+			sorted, err := SortContentReaderByCalculatedKey(merged, func(record interface{}) (string, error) {
+				item := new(outputRecord)
+				err := ConvertToStruct(record, item)
+				return fmt.Sprintf("%03d", item.IntKey), err
+			}, false)
+			assert.NoError(t, err)
+			first := new(outputRecord)
+			assert.NoError(t, sorted.NextRecord(first))
+			assert.Equal(t, records[len(records)-1], *first)
+			closeAndAssert(t, sorted)
+			closeAndAssert(t, merged)
+			closeAndAssert(t, reader)
+		})
+	}
+}
+
+func TestContentWriterFileFormat(t *testing.T) {
+	writer, err := NewContentWriter(DefaultKey, true, false)
+	assert.NoError(t, err)
+	writer.SetFileFormat(NdjsonFormat, ZstdCompression)
+	writer.Write(records[0])
+	assert.NoError(t, writer.Close())
+	assert.True(t, strings.HasSuffix(writer.GetFilePath(), ".ndjson.zst"))
+	assert.NoError(t, writer.RemoveOutputFilePath())
+
+	// Incomplete files are always plain JSON.
+	writer, err = NewContentWriter(DefaultKey, false, false)
+	assert.NoError(t, err)
+	writer.SetFileFormat(NdjsonFormat, GzipCompression)
+	assert.Equal(t, JsonFormat, writer.format)
+	assert.Equal(t, NoCompression, writer.compression)
+
+	assert.Error(t, validateFileFormat("xml", NoCompression))
+	assert.Error(t, validateFileFormat(JsonFormat, "bz2"))
+}
+
+func TestContentFileFormatsSize(t *testing.T) {
+	plainSize := writeLargeTestFile(t, JsonFormat, NoCompression)
+	assert.Less(t, writeLargeTestFile(t, NdjsonFormat, NoCompression), plainSize)
+	// Search results are highly repetitive, so they are compressed to a fraction of their size.
+	assert.Less(t, writeLargeTestFile(t, NdjsonFormat, GzipCompression), plainSize/5)
+	assert.Less(t, writeLargeTestFile(t, NdjsonFormat, ZstdCompression), plainSize/5)
+}
+
+func BenchmarkContentFileFormats(b *testing.B) {
+	for _, test := range testFileFormats {
+		b.Run(string(test.format)+"_"+string(test.compression), func(b *testing.B) {
+			var size int64
+			for i := 0; i < b.N; i++ {
+				size = writeLargeTestFile(b, test.format, test.compression)
+			}
+			b.ReportMetric(float64(size), "file-bytes")
+		})
+	}
+}
+
+// Writes search results to a file of the format, reads them back, and returns the size of the file.
+func writeLargeTestFile(tb testing.TB, format FileFormat, compression Compression) int64 {
+	const recordsCount = 10000
+	writer, err := NewContentWriter(DefaultKey, true, false)
+	assert.NoError(tb, err)
+	writer.SetFileFormat(format, compression)
+	for i := 0; i < recordsCount; i++ {
+		writer.Write(ReaderTestItem{Repo: "libs-release-local", Path: "org/example/artifact/" + strconv.Itoa(i/100), Name: "artifact-" + strconv.Itoa(i) + ".jar", Type: "file"})
+	}
+	assert.NoError(tb, writer.Close())
+	info, err := os.Stat(writer.GetFilePath())
+	assert.NoError(tb, err)
+	reader := NewContentReader(writer.GetFilePath(), DefaultKey)
+	length, err := reader.Length()
+	assert.NoError(tb, err)
+	assert.Equal(tb, recordsCount, length)
+	assert.NoError(tb, reader.Close())
+	return info.Size()
+}
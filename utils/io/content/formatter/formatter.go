@@ -0,0 +1,381 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/madotis/jfrog-client-go/utils/errorutils"
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+)
+
+type Format string
+
+const (
+	Csv    Format = "csv"
+	Ndjson Format = "ndjson"
+	// A text table, whose columns are aligned with spaces.
+	Table Format = "table"
+)
+
+const (
+	propertiesField = "properties"
+	tableSeparator  = "  "
+)
+
+// A column of the output, whose values are read from a field of each of the records.
+type Column struct {
+	Header string
+	// The JSON field of the values. The fields of nested objects are separated by dots, like "stats.downloads".
+	// The values of a field of an array of objects are joined by commas.
+	Field string
+	// Formats the value of the field of each record. If nil, the value is formatted by FormatValue.
+	Format func(value interface{}) string
+	// Returns the value of the column from the whole record, instead of reading Field.
+	value func(record map[string]interface{}) interface{}
+}
+
+func FieldColumn(header, field string) Column {
+	return Column{Header: header, Field: field}
+}
+
+// A column of sizes in bytes, formatted like "1.5 MB".
+func SizeColumn(header, field string) Column {
+	return Column{Header: header, Field: field, Format: FormatSize}
+}
+
+// A column of RFC 3339 dates, as returned by Artifactory, formatted like "2023-05-30 10:36:27".
+func DateColumn(header, field string) Column {
+	return Column{Header: header, Field: field, Format: FormatDate}
+}
+
+// A column of the values of a property of the records, joined by commas.
+// The properties are read from the "properties" array of the records, like in the search results.
+func PropertyColumn(key string) Column {
+	return Column{Header: key, value: func(record map[string]interface{}) interface{} {
+		return getPropertyValues(record, key)
+	}}
+}
+
+// Returns a PropertyColumn for each of the property keys of the records, sorted by the keys.
+// The reader is read once to find the keys, and reset afterwards.
+func PropertyColumns(reader *content.ContentReader) ([]Column, error) {
+	keys := map[string]bool{}
+	for record, err := range content.NewTypedReader[map[string]interface{}](reader).All() {
+		if err != nil {
+			return nil, err
+		}
+		for _, property := range getProperties(record) {
+			keys[property.key] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+	columns := make([]Column, 0, len(sortedKeys))
+	for _, key := range sortedKeys {
+		columns = append(columns, PropertyColumn(key))
+	}
+	return columns, nil
+}
+
+// The columns of search results, like the results of SearchFiles.
+func SearchResultColumns() []Column {
+	return []Column{
+		FieldColumn("Repo", "repo"),
+		FieldColumn("Path", "path"),
+		FieldColumn("Name", "name"),
+		FieldColumn("Type", "type"),
+		SizeColumn("Size", "size"),
+		DateColumn("Modified", "modified"),
+	}
+}
+
+// The columns of file transfer details, like the TransferDetailsReader of uploads and downloads.
+func TransferDetailsColumns() []Column {
+	return []Column{
+		FieldColumn("Source", "sourcePath"),
+		FieldColumn("Target", "targetPath"),
+		FieldColumn("Sha256", "sha256"),
+	}
+}
+
+// Writes the records of the reader to w in the format, one record at a time. The reader is reset afterwards.
+// The columns are required, except for NDJSON, which writes the records as they are if no columns are given.
+// A table is written by reading the reader twice: once to find the width of each column, and once to write the rows.
+func Write(w io.Writer, reader *content.ContentReader, format Format, columns ...Column) error {
+	if format != Ndjson && len(columns) == 0 {
+		return errorutils.CheckErrorf("no columns were given for the %s format", format)
+	}
+	switch format {
+	case Csv:
+		return writeCsv(w, reader, columns)
+	case Ndjson:
+		return writeNdjson(w, reader, columns)
+	case Table:
+		return writeTable(w, reader, columns)
+	default:
+		return errorutils.CheckErrorf("unsupported output format: %s", format)
+	}
+}
+
+func writeCsv(w io.Writer, reader *content.ContentReader, columns []Column) error {
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(getHeaders(columns)); err != nil {
+		return errorutils.CheckError(err)
+	}
+	err := forEachRow(reader, columns, func(row []string) error {
+		return errorutils.CheckError(csvWriter.Write(row))
+	})
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return errorutils.CheckError(csvWriter.Error())
+}
+
+func writeNdjson(w io.Writer, reader *content.ContentReader, columns []Column) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	for record, err := range content.NewTypedReader[map[string]interface{}](reader).All() {
+		if err != nil {
+			return err
+		}
+		var output interface{} = record
+		if len(columns) > 0 {
+			row := make(map[string]string, len(columns))
+			for _, column := range columns {
+				row[column.Header] = column.formatValue(record)
+			}
+			output = row
+		}
+		if err = encoder.Encode(output); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, reader *content.ContentReader, columns []Column) error {
+	headers := getHeaders(columns)
+	widths := make([]int, len(columns))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	err := forEachRow(reader, columns, func(row []string) error {
+		for i, value := range row {
+			if width := utf8.RuneCountInString(value); width > widths[i] {
+				widths[i] = width
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	separators := make([]string, len(columns))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("-", width)
+	}
+	if err = writeTableRow(w, headers, widths); err != nil {
+		return err
+	}
+	if err = writeTableRow(w, separators, widths); err != nil {
+		return err
+	}
+	return forEachRow(reader, columns, func(row []string) error {
+		return writeTableRow(w, row, widths)
+	})
+}
+
+func writeTableRow(w io.Writer, row []string, widths []int) error {
+	var line strings.Builder
+	for i, value := range row {
+		line.WriteString(value)
+		// The last column isn't padded, to avoid trailing spaces.
+		if i < len(row)-1 {
+			line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)))
+			line.WriteString(tableSeparator)
+		}
+	}
+	line.WriteString("\n")
+	_, err := io.WriteString(w, line.String())
+	return errorutils.CheckError(err)
+}
+
+// Calls handleRow with the formatted values of the columns of each of the records.
+func forEachRow(reader *content.ContentReader, columns []Column, handleRow func(row []string) error) error {
+	for record, err := range content.NewTypedReader[map[string]interface{}](reader).All() {
+		if err != nil {
+			return err
+		}
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = column.formatValue(record)
+		}
+		if err = handleRow(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getHeaders(columns []Column) []string {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	return headers
+}
+
+func (c Column) formatValue(record map[string]interface{}) string {
+	var value interface{}
+	if c.value != nil {
+		value = c.value(record)
+	} else {
+		value = getFieldValue(record, strings.Split(c.Field, "."))
+	}
+	if c.Format != nil {
+		return c.Format(value)
+	}
+	return FormatValue(value)
+}
+
+func getFieldValue(value interface{}, fieldPath []string) interface{} {
+	if len(fieldPath) == 0 {
+		return value
+	}
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return getFieldValue(typedValue[fieldPath[0]], fieldPath[1:])
+	case []interface{}:
+		var values []string
+		for _, element := range typedValue {
+			if elementValue := getFieldValue(element, fieldPath); elementValue != nil {
+				values = append(values, FormatValue(elementValue))
+			}
+		}
+		if len(values) == 0 {
+			return nil
+		}
+		return strings.Join(values, ",")
+	default:
+		return nil
+	}
+}
+
+type property struct {
+	key   string
+	value string
+}
+
+// Returns the properties of the record. Properties are written with either "key" or "Key" fields, depending on whether
+// they were written from an AQL result or from a ResultItem, so the fields are matched case-insensitively.
+func getProperties(record map[string]interface{}) (properties []property) {
+	values, ok := record[propertiesField].([]interface{})
+	if !ok {
+		return
+	}
+	for _, value := range values {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var prop property
+		for field, fieldValue := range fields {
+			switch strings.ToLower(field) {
+			case "key":
+				prop.key = FormatValue(fieldValue)
+			case "value":
+				prop.value = FormatValue(fieldValue)
+			}
+		}
+		if prop.key != "" {
+			properties = append(properties, prop)
+		}
+	}
+	return
+}
+
+func getPropertyValues(record map[string]interface{}, key string) interface{} {
+	var values []string
+	for _, prop := range getProperties(record) {
+		if prop.key == key {
+			values = append(values, prop.value)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return strings.Join(values, ",")
+}
+
+// Formats a JSON value. Missing values are formatted as empty strings, and objects and arrays as JSON.
+func FormatValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typedValue
+	case bool:
+		return strconv.FormatBool(typedValue)
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	default:
+		data, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprint(typedValue)
+		}
+		return string(data)
+	}
+}
+
+var sizeUnits = []string{"B", "KB", "MB", "GB", "TB", "PB"}
+
+// Formats a size in bytes with binary units, like "1.5 MB". Values which aren't numbers are formatted by FormatValue.
+func FormatSize(value interface{}) string {
+	var size float64
+	switch typedValue := value.(type) {
+	case float64:
+		size = typedValue
+	case string:
+		parsed, err := strconv.ParseFloat(typedValue, 64)
+		if err != nil {
+			return typedValue
+		}
+		size = parsed
+	default:
+		return FormatValue(value)
+	}
+	unit := 0
+	for size >= 1024 && unit < len(sizeUnits)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%.0f %s", size, sizeUnits[unit])
+	}
+	return fmt.Sprintf("%.1f %s", size, sizeUnits[unit])
+}
+
+// Formats an RFC 3339 date like "2023-05-30 10:36:27", in the date's time zone.
+// Values which aren't RFC 3339 dates are formatted by FormatValue.
+func FormatDate(value interface{}) string {
+	date, ok := value.(string)
+	if !ok {
+		return FormatValue(value)
+	}
+	parsed, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format(time.DateTime)
+}
@@ -0,0 +1,110 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/utils/io/content"
+	"github.com/stretchr/testify/assert"
+)
+
+type testProperty struct {
+	Key   string
+	Value string
+}
+
+type testResultItem struct {
+	Repo       string         `json:"repo,omitempty"`
+	Path       string         `json:"path,omitempty"`
+	Name       string         `json:"name,omitempty"`
+	Type       string         `json:"type,omitempty"`
+	Size       int64          `json:"size,omitempty"`
+	Modified   string         `json:"modified,omitempty"`
+	Properties []testProperty `json:"properties,omitempty"`
+}
+
+var testResultItems = []testResultItem{
+	{Repo: "libs-local", Path: "org/a", Name: "a.jar", Type: "file", Size: 1536, Modified: "2023-05-30T10:36:27.521Z",
+		Properties: []testProperty{{"build.name", "a"}, {"tag", "x"}, {"tag", "y"}}},
+	{Repo: "libs-local", Path: "org/b", Name: "b, \"quoted\".jar", Type: "file", Size: 100,
+		Properties: []testProperty{{"build.number", "2"}}},
+}
+
+func createTestReader(t *testing.T) *content.ContentReader {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, item := range testResultItems {
+		writer.Write(item)
+	}
+	assert.NoError(t, writer.Close())
+	return content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+}
+
+func TestWriteCsv(t *testing.T) {
+	reader := createTestReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	propertyColumns, err := PropertyColumns(reader)
+	assert.NoError(t, err)
+	var output bytes.Buffer
+	assert.NoError(t, Write(&output, reader, Csv, append(SearchResultColumns(), propertyColumns...)...))
+	assert.Equal(t, "Repo,Path,Name,Type,Size,Modified,build.name,build.number,tag\n"+
+		"libs-local,org/a,a.jar,file,1.5 KB,2023-05-30 10:36:27,a,,\"x,y\"\n"+
+		"libs-local,org/b,\"b, \"\"quoted\"\".jar\",file,100 B,,,2,\n", output.String())
+}
+
+func TestWriteNdjson(t *testing.T) {
+	reader := createTestReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	var output bytes.Buffer
+	assert.NoError(t, Write(&output, reader, Ndjson, FieldColumn("Name", "name"), PropertyColumn("tag")))
+	assert.Equal(t, "{\"Name\":\"a.jar\",\"tag\":\"x,y\"}\n{\"Name\":\"b, \\\"quoted\\\".jar\",\"tag\":\"\"}\n", output.String())
+
+	// Without columns, the records are written as they are.
+	output.Reset()
+	assert.NoError(t, Write(&output, reader, Ndjson))
+	assert.Contains(t, output.String(), "\"properties\":[{\"Key\":\"build.number\",\"Value\":\"2\"}]")
+	assert.Equal(t, 2, bytes.Count(output.Bytes(), []byte("\n")))
+}
+
+func TestWriteTable(t *testing.T) {
+	reader := createTestReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	var output bytes.Buffer
+	assert.NoError(t, Write(&output, reader, Table, FieldColumn("Name", "name"), SizeColumn("Size", "size"), FieldColumn("Path", "path")))
+	assert.Equal(t, ""+
+		"Name             Size    Path\n"+
+		"---------------  ------  -----\n"+
+		"a.jar            1.5 KB  org/a\n"+
+		"b, \"quoted\".jar  100 B   org/b\n", output.String())
+}
+
+func TestWriteErrors(t *testing.T) {
+	reader := content.NewEmptyContentReader(content.DefaultKey)
+	var output bytes.Buffer
+	assert.Error(t, Write(&output, reader, Csv))
+	assert.Error(t, Write(&output, reader, "xml", FieldColumn("Name", "name")))
+
+	// An empty reader writes only the headers.
+	assert.NoError(t, Write(&output, reader, Csv, FieldColumn("Name", "name")))
+	assert.Equal(t, "Name\n", output.String())
+}
+
+func TestFormatValues(t *testing.T) {
+	assert.Equal(t, "", FormatValue(nil))
+	assert.Equal(t, "3", FormatValue(float64(3)))
+	assert.Equal(t, "true", FormatValue(true))
+	assert.Equal(t, "{\"a\":1}", FormatValue(map[string]interface{}{"a": 1}))
+	assert.Equal(t, "0 B", FormatSize(float64(0)))
+	assert.Equal(t, "2.0 GB", FormatSize("2147483648"))
+	assert.Equal(t, "unknown", FormatSize("unknown"))
+	assert.Equal(t, "2023-05-30 10:36:27", FormatDate("2023-05-30T10:36:27.521+03:00"))
+	assert.Equal(t, "yesterday", FormatDate("yesterday"))
+	record := map[string]interface{}{"stats": []interface{}{map[string]interface{}{"downloads": float64(5)}, map[string]interface{}{}}}
+	assert.Equal(t, "5", FieldColumn("Downloads", "stats.downloads").formatValue(record))
+}
@@ -0,0 +1,209 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// A ContentReader of records of type T, which are read by iterating over All:
+//
+//	for item, err := range reader.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// The operators write their results to new readers, so the records are never loaded into the memory all at once.
+// The records are converted from and to JSON objects, like in ContentReader.NextRecord, so T should be a struct or a map.
+type TypedReader[T any] struct {
+	reader *ContentReader
+}
+
+func NewTypedReader[T any](reader *ContentReader) *TypedReader[T] {
+	return &TypedReader[T]{reader: reader}
+}
+
+// Returns the ContentReader of the records, for the APIs which accept a ContentReader.
+func (tr *TypedReader[T]) ContentReader() *ContentReader {
+	return tr.reader
+}
+
+// Returns an iterator over the records. If reading a record fails, the error is yielded and the iteration ends.
+// The reader is reset when the iteration ends, even if it ends early, so the records can be iterated over again.
+func (tr *TypedReader[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if tr.reader.IsEmpty() {
+			return
+		}
+		defer tr.reader.Reset()
+		for {
+			record := new(T)
+			err := tr.reader.NextRecord(record)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				yield(*new(T), err)
+				return
+			}
+			if !yield(*record, nil) {
+				return
+			}
+		}
+		if err := tr.reader.GetError(); err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
+
+func (tr *TypedReader[T]) Length() (int, error) {
+	return tr.reader.Length()
+}
+
+// Removes the files of the reader.
+func (tr *TypedReader[T]) Close() error {
+	return tr.reader.Close()
+}
+
+// Returns a reader of the records for which keep returns true.
+func (tr *TypedReader[T]) Filter(keep func(T) bool) (*TypedReader[T], error) {
+	return writeTypedReader[T](func(writer *ContentWriter) error {
+		for record, err := range tr.All() {
+			if err != nil {
+				return err
+			}
+			if keep(record) {
+				writer.Write(record)
+			}
+		}
+		return nil
+	})
+}
+
+// Returns a reader of the first n records.
+func (tr *TypedReader[T]) Take(n int) (*TypedReader[T], error) {
+	return writeTypedReader[T](func(writer *ContentWriter) error {
+		if n <= 0 {
+			return nil
+		}
+		taken := 0
+		for record, err := range tr.All() {
+			if err != nil {
+				return err
+			}
+			writer.Write(record)
+			if taken++; taken == n {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// Returns a reader of the first record of each key, sorted by the keys.
+func (tr *TypedReader[T]) DistinctBy(key func(T) string) (*TypedReader[T], error) {
+	sorted, err := SortContentReaderByCalculatedKey(tr.reader, func(record interface{}) (string, error) {
+		typedRecord := new(T)
+		if err := ConvertToStruct(record, typedRecord); err != nil {
+			return "", err
+		}
+		return key(*typedRecord), nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	return NewTypedReader[T](sorted), nil
+}
+
+// Returns a reader of the records of each key. The records of each reader are in their original order.
+// The records are grouped by sorting them, so only a single group is written at a time.
+func (tr *TypedReader[T]) GroupBy(key func(T) string) (groups map[string]*TypedReader[T], err error) {
+	index := 0
+	sorted, err := SortContentReaderByCalculatedKey(tr.reader, func(record interface{}) (string, error) {
+		typedRecord := new(T)
+		if err := ConvertToStruct(record, typedRecord); err != nil {
+			return "", err
+		}
+		// Records with the same sort key are removed, so each record's key is made unique by its index.
+		// The separator sorts before any other character, so that all the records of a key are sorted together.
+		index++
+		return fmt.Sprintf("%s\x00%020d", key(*typedRecord), index), nil
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	groups = make(map[string]*TypedReader[T])
+	defer func() {
+		err = errors.Join(err, sorted.Close())
+		if err != nil {
+			for _, group := range groups {
+				err = errors.Join(err, group.Close())
+			}
+			groups = nil
+		}
+	}()
+	var writer *ContentWriter
+	var currentKey string
+	closeGroup := func() error {
+		if writer == nil {
+			return nil
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		groups[currentKey] = NewTypedReader[T](NewContentReader(writer.GetFilePath(), DefaultKey))
+		return nil
+	}
+	// The groups are returned with the errors, so that they are closed by the deferred function.
+	for record, err := range NewTypedReader[T](sorted).All() {
+		if err != nil {
+			return groups, errors.Join(err, closeGroup())
+		}
+		if recordKey := key(record); writer == nil || recordKey != currentKey {
+			if err = closeGroup(); err != nil {
+				return groups, err
+			}
+			if writer, err = NewContentWriter(DefaultKey, true, false); err != nil {
+				return groups, err
+			}
+			currentKey = recordKey
+		}
+		writer.Write(record)
+	}
+	return groups, closeGroup()
+}
+
+// Returns a reader of the results of mapFunc for each of the records.
+func Map[T, U any](tr *TypedReader[T], mapFunc func(T) (U, error)) (*TypedReader[U], error) {
+	return writeTypedReader[U](func(writer *ContentWriter) error {
+		for record, err := range tr.All() {
+			if err != nil {
+				return err
+			}
+			mapped, err := mapFunc(record)
+			if err != nil {
+				return err
+			}
+			writer.Write(mapped)
+		}
+		return nil
+	})
+}
+
+// Returns a reader of the records written by write. If write fails, the written records are removed.
+func writeTypedReader[T any](write func(writer *ContentWriter) error) (*TypedReader[T], error) {
+	writer, err := NewContentWriter(DefaultKey, true, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = errors.Join(write(writer), writer.Close()); err != nil {
+		if writer.GetFilePath() != "" {
+			err = errors.Join(err, writer.RemoveOutputFilePath())
+		}
+		return nil, err
+	}
+	return NewTypedReader[T](NewContentReader(writer.GetFilePath(), DefaultKey)), nil
+}
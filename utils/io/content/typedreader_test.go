@@ -0,0 +1,155 @@
+package content
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/madotis/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestTypedReader(t *testing.T) *TypedReader[outputRecord] {
+	writer, err := NewContentWriter(DefaultKey, true, false)
+	assert.NoError(t, err)
+	for _, record := range records {
+		writer.Write(record)
+	}
+	assert.NoError(t, writer.Close())
+	return NewTypedReader[outputRecord](NewContentReader(writer.GetFilePath(), DefaultKey))
+}
+
+func collectTestRecords[T any](t *testing.T, reader *TypedReader[T]) (collected []T) {
+	for record, err := range reader.All() {
+		assert.NoError(t, err)
+		collected = append(collected, record)
+	}
+	return
+}
+
+func TestTypedReaderAll(t *testing.T) {
+	reader := createTestTypedReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	assert.Equal(t, records, collectTestRecords(t, reader))
+
+	// Stopping early resets the reader too.
+	for record, err := range reader.All() {
+		assert.NoError(t, err)
+		assert.Equal(t, records[0], record)
+		break
+	}
+	assert.Equal(t, records, collectTestRecords(t, reader))
+	length, err := reader.Length()
+	assert.NoError(t, err)
+	assert.Equal(t, len(records), length)
+
+	assert.Empty(t, collectTestRecords(t, NewTypedReader[outputRecord](NewEmptyContentReader(DefaultKey))))
+}
+
+func TestTypedReaderAllError(t *testing.T) {
+	reader := createTestTypedReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+	// The records can't be converted to strings.
+	var errs []error
+	for _, err := range NewTypedReader[string](reader.ContentReader()).All() {
+		errs = append(errs, err)
+	}
+	if assert.Len(t, errs, 1) {
+		assert.Error(t, errs[0])
+	}
+}
+
+func TestTypedReaderOperators(t *testing.T) {
+	reader := createTestTypedReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	filtered, err := reader.Filter(func(record outputRecord) bool { return record.BoolKey })
+	assert.NoError(t, err)
+	filteredRecords := collectTestRecords(t, filtered)
+	assert.Len(t, filteredRecords, 16)
+	for _, record := range filteredRecords {
+		assert.True(t, record.BoolKey)
+	}
+
+	taken, err := filtered.Take(2)
+	assert.NoError(t, err)
+	assert.Equal(t, filteredRecords[:2], collectTestRecords(t, taken))
+	assert.NoError(t, taken.Close())
+	assert.NoError(t, filtered.Close())
+
+	mapped, err := Map(reader, func(record outputRecord) (ArrayValue, error) {
+		return ArrayValue{Key: record.StrKey, Value: strconv.Itoa(record.IntKey * 2)}, nil
+	})
+	assert.NoError(t, err)
+	mappedRecords := collectTestRecords(t, mapped)
+	if assert.Len(t, mappedRecords, len(records)) {
+		assert.Equal(t, ArrayValue{Key: "30", Value: "60"}, mappedRecords[29])
+	}
+	assert.NoError(t, mapped.Close())
+
+	_, err = Map(reader, func(record outputRecord) (ArrayValue, error) { return ArrayValue{}, errors.New("failure") })
+	assert.EqualError(t, err, "failure")
+
+	distinct, err := reader.DistinctBy(func(record outputRecord) string { return strconv.FormatBool(record.BoolKey) })
+	assert.NoError(t, err)
+	assert.Equal(t, []outputRecord{records[1], records[0]}, collectTestRecords(t, distinct))
+	assert.NoError(t, distinct.Close())
+
+	groups, err := reader.GroupBy(func(record outputRecord) string { return strconv.Itoa(record.IntKey % 3) })
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 3) {
+		for key, group := range groups {
+			groupRecords := collectTestRecords(t, group)
+			assert.Len(t, groupRecords, 10)
+			for i, record := range groupRecords {
+				assert.Equal(t, key, strconv.Itoa(record.IntKey%3))
+				if i > 0 {
+					// The records are in their original order.
+					assert.Less(t, groupRecords[i-1].IntKey, record.IntKey)
+				}
+			}
+			assert.NoError(t, group.Close())
+		}
+	}
+
+	// The reader can still be read after all the operators.
+	assert.Equal(t, records, collectTestRecords(t, reader))
+}
+
+func TestTypedReaderGroupByRemovesGroupsOnError(t *testing.T) {
+	tempDirBase := fileutils.GetTempDirBase()
+	defer fileutils.SetTempDirBase(tempDirBase)
+	tempDir := t.TempDir()
+	fileutils.SetTempDirBase(tempDir)
+	reader := createTestTypedReader(t)
+	defer func() {
+		assert.NoError(t, reader.Close())
+	}()
+
+	// The key is calculated once for each record while the records are sorted, and again while they are grouped.
+	// After the first groups are written, the temp files can no longer be created, so writing the next group fails.
+	calls := 0
+	groups, err := reader.GroupBy(func(record outputRecord) string {
+		calls++
+		if calls == len(records)+len(records)/2 {
+			fileutils.SetTempDirBase(filepath.Join(tempDir, "missing"))
+		}
+		return strconv.Itoa(record.IntKey)
+	})
+	assert.Error(t, err)
+	assert.Nil(t, groups)
+	fileutils.SetTempDirBase(tempDir)
+
+	// Only the file of the reader is left.
+	entries, err := os.ReadDir(tempDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -68,11 +68,16 @@ func RemoveTempDir(dirPath string) error {
 
 // Create a new temp file named "tempPrefix+timeStamp".
 func CreateTempFile() (*os.File, error) {
+	return CreateTempFileWithSuffix("")
+}
+
+// Create a new temp file named "tempPrefix+timeStamp", which ends with the suffix, like a file extension.
+func CreateTempFileWithSuffix(suffix string) (*os.File, error) {
 	if tempDirBase == "" {
 		return nil, errorutils.CheckErrorf("Temp File cannot be created in an empty base dir.")
 	}
 	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
-	fd, err := os.CreateTemp(tempDirBase, tempPrefix+"-"+timestamp+"-")
+	fd, err := os.CreateTemp(tempDirBase, tempPrefix+"-"+timestamp+"-*"+suffix)
 	return fd, err
 }
 
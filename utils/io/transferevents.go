@@ -0,0 +1,66 @@
+package io
+
+import (
+	"time"
+
+	"github.com/jfrog/build-info-go/entities"
+)
+
+type TransferEventType string
+
+const (
+	// The file was added to the queue of files to transfer.
+	TransferQueued TransferEventType = "queued"
+	// A worker started transferring the file.
+	TransferStarted TransferEventType = "started"
+	// More bytes of the file were transferred. TransferredBytes holds the bytes transferred in the current attempt.
+	TransferProgressed TransferEventType = "progressed"
+	// A previous attempt to transfer the file failed, and it is being transferred again. Attempt holds the attempt number.
+	TransferRetried TransferEventType = "retried"
+	// The file already existed in Artifactory, so it was deployed by its checksum without transferring its content.
+	TransferChecksumDeployed TransferEventType = "checksum-deployed"
+	// The file was not transferred, because its target is already up-to-date. Reason explains why.
+	TransferSkipped   TransferEventType = "skipped"
+	TransferSucceeded TransferEventType = "succeeded"
+	// The transfer failed. Err holds the failure.
+	TransferFailed TransferEventType = "failed"
+)
+
+type TransferOperation string
+
+const (
+	UploadOperation   TransferOperation = "upload"
+	DownloadOperation TransferOperation = "download"
+	MoveOperation     TransferOperation = "move"
+	CopyOperation     TransferOperation = "copy"
+	DeleteOperation   TransferOperation = "delete"
+)
+
+type TransferEvent struct {
+	Type      TransferEventType
+	Operation TransferOperation
+	// The local path of an uploaded file, or the repository path of the file for the other operations. Empty for archives uploaded from multiple files.
+	SourcePath string
+	TargetPath string
+	// The checksums of the file, if known when the event is emitted.
+	Checksum         entities.Checksum
+	Size             int64
+	TransferredBytes int64
+	Attempt          int
+	Reason           string
+	Err              error
+	Time             time.Time
+}
+
+// You may implement this interface to receive per file events of uploads, downloads, moves, copies and deletions.
+// OnTransferEvent is called concurrently by the transferring threads, and should return quickly.
+type TransferObserver interface {
+	OnTransferEvent(event TransferEvent)
+}
+
+// TransferObserverFunc allows using an ordinary function as a TransferObserver.
+type TransferObserverFunc func(event TransferEvent)
+
+func (f TransferObserverFunc) OnTransferEvent(event TransferEvent) {
+	f(event)
+}